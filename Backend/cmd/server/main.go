@@ -7,7 +7,10 @@ import (
 	"school-erp-backend/internal/handlers"
 	"school-erp-backend/internal/middleware"
 	"school-erp-backend/internal/models"
+	"school-erp-backend/internal/services"
 	"school-erp-backend/pkg/database"
+	"school-erp-backend/pkg/mailer"
+	"school-erp-backend/pkg/storage"
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
@@ -44,6 +47,16 @@ func main() {
 		log.Fatal("Failed to connect to database:", err)
 	}
 
+	// Initialize file storage
+	if err := storage.Init(); err != nil {
+		log.Fatal("Failed to initialize file storage:", err)
+	}
+
+	// Initialize mail sender
+	if err := mailer.Init(); err != nil {
+		log.Fatal("Failed to initialize mail sender:", err)
+	}
+
 	// Auto migrate database
 	if err := database.DB.AutoMigrate(
 		&models.User{},
@@ -61,20 +74,82 @@ func main() {
 		&models.AssignmentSubmission{},
 		&models.Timetable{},
 		&models.Notice{},
+		&models.NoticeTarget{},
+		&models.NoticeRead{},
 		&models.CalendarEvent{},
+		&models.CalendarFeedToken{},
 		&models.LeaveRequest{},
+		&models.LeaveEntitlement{},
+		&models.Substitution{},
+		&models.StudentLeaveRequest{},
+		&models.GradingScale{},
+		&models.GradeBand{},
+		&models.ClassGradingScale{},
+		&models.Job{},
+		&models.ReportCardRemark{},
+		&models.TeachingAssignment{},
+		&models.CurriculumSubject{},
+		&models.ElectiveEnrollment{},
+		&models.PasswordResetToken{},
+		&models.LoginEvent{},
+		&models.AccountLockout{},
+		&models.TwoFactorAuth{},
+		&models.RecoveryCode{},
+		&models.Permission{},
+		&models.Role{},
+		&models.UserRole{},
+		&models.Guardian{},
+		&models.StudentGuardian{},
 	); err != nil {
 		log.Fatal("Failed to migrate database:", err)
 	}
 
+	// Roles are no longer a fixed list, see migrations/015_permissions.sql
+	for _, constraint := range []string{"chk_users_role", "users_role_check"} {
+		if database.DB.Migrator().HasConstraint(&models.User{}, constraint) {
+			if err := database.DB.Migrator().DropConstraint(&models.User{}, constraint); err != nil {
+				log.Fatal("Failed to drop role constraint:", err)
+			}
+		}
+	}
+
+	if err := services.SeedPermissions(database.DB); err != nil {
+		log.Fatal("Failed to seed permissions:", err)
+	}
+
 	// Initialize handlers
 	authHandler := handlers.NewAuthHandler()
 	userHandler := handlers.NewUserHandler()
+	sessionHandler := handlers.NewSessionHandler()
+	loginSecurityHandler := handlers.NewLoginSecurityHandler()
+	twoFactorHandler := handlers.NewTwoFactorHandler()
+	roleHandler := handlers.NewRoleHandler()
 	studentHandler := handlers.NewStudentHandler()
+	guardianHandler := handlers.NewGuardianHandler()
+	parentHandler := handlers.NewParentHandler()
+	noticeHandler := handlers.NewNoticeHandler()
+	calendarHandler := handlers.NewCalendarHandler()
+	leaveHandler := handlers.NewLeaveHandler()
+	substitutionHandler := handlers.NewSubstitutionHandler()
+	studentLeaveHandler := handlers.NewStudentLeaveHandler()
 	teacherHandler := handlers.NewTeacherHandler()
 	classHandler := handlers.NewClassHandler()
 	sectionHandler := handlers.NewSectionHandler()
 	subjectHandler := handlers.NewSubjectHandler()
+	attendanceHandler := handlers.NewAttendanceHandler()
+	attendanceReportHandler := handlers.NewAttendanceReportHandler()
+	examHandler := handlers.NewExamHandler()
+	markHandler := handlers.NewMarkHandler()
+	gradingScaleHandler := handlers.NewGradingScaleHandler()
+	jobHandler := handlers.NewJobHandler()
+	reportCardHandler := handlers.NewReportCardHandler()
+	assignmentHandler := handlers.NewAssignmentHandler()
+	fileHandler := handlers.NewFileHandler()
+	timetableHandler := handlers.NewTimetableHandler()
+	timetableGeneratorHandler := handlers.NewTimetableGeneratorHandler()
+	teachingAssignmentHandler := handlers.NewTeachingAssignmentHandler()
+	curriculumHandler := handlers.NewCurriculumHandler()
+	electiveHandler := handlers.NewElectiveHandler()
 
 	// Setup router
 	router := gin.Default()
@@ -106,89 +181,453 @@ func main() {
 		auth := api.Group("/auth")
 		{
 			auth.POST("/login", authHandler.Login)
-			auth.POST("/register", middleware.AuthMiddleware(), middleware.RoleMiddleware("admin"), authHandler.Register)
+			auth.POST("/refresh", authHandler.Refresh)
+			auth.POST("/logout", middleware.AuthMiddleware(), authHandler.Logout)
+			auth.GET("/sessions", middleware.AuthMiddleware(), sessionHandler.GetMySessions)
+			auth.DELETE("/sessions/:id", middleware.AuthMiddleware(), sessionHandler.RevokeMySession)
+			auth.POST("/change-password", middleware.AuthMiddleware(), authHandler.ChangePassword)
+			auth.POST("/forgot-password", authHandler.ForgotPassword)
+			auth.POST("/reset-password", authHandler.ResetPassword)
+			auth.GET("/me", middleware.AuthMiddleware(), authHandler.Me)
+			auth.POST("/register", middleware.AuthMiddleware(), middleware.RequirePermission("users.write"), authHandler.Register)
+			auth.POST("/2fa/verify", authHandler.VerifyTwoFactor)
+			auth.POST("/2fa/enroll", authHandler.EnrollTwoFactor)
+			auth.POST("/2fa/enroll/confirm", authHandler.ConfirmTwoFactorEnrollment)
+			auth.GET("/2fa", middleware.AuthMiddleware(), twoFactorHandler.GetStatus)
+			auth.POST("/2fa/setup", middleware.AuthMiddleware(), twoFactorHandler.SetUp)
+			auth.POST("/2fa/enable", middleware.AuthMiddleware(), twoFactorHandler.Enable)
+			auth.POST("/2fa/disable", middleware.AuthMiddleware(), twoFactorHandler.Disable)
+			auth.POST("/2fa/recovery-codes", middleware.AuthMiddleware(), twoFactorHandler.RegenerateRecoveryCodes)
 		}
 
-		// Admin routes
+		// File downloads, authorized per owning record
+		files := api.Group("/files")
+		{
+			files.GET("/signed", fileHandler.DownloadSigned)
+			files.GET("/submissions/:id", middleware.AuthMiddleware(), fileHandler.DownloadSubmission)
+			files.GET("/submissions/:id/url", middleware.AuthMiddleware(), fileHandler.GetSubmissionFileURL)
+			files.GET("/student-leave/:id", middleware.AuthMiddleware(), fileHandler.DownloadStudentLeaveAttachment)
+		}
+
+		// Admin routes, each allowed by a permission. Admins have every
+		// permission, other staff roles get the ones they need.
 		admin := api.Group("/admin")
-		admin.Use(middleware.AuthMiddleware(), middleware.RoleMiddleware("admin"))
+		admin.Use(middleware.AuthMiddleware())
 		{
 			// Users
 			users := admin.Group("/users")
 			{
-				users.GET("", userHandler.GetUsers)
-				users.GET("/:id", userHandler.GetUser)
-				users.POST("", userHandler.CreateUser)
-				users.PUT("/:id", userHandler.UpdateUser)
-				users.DELETE("/:id", userHandler.DeleteUser)
+				users.GET("", middleware.RequirePermission("users.read"), userHandler.GetUsers)
+				users.GET("/:id", middleware.RequirePermission("users.read"), userHandler.GetUser)
+				users.POST("", middleware.RequirePermission("users.write"), userHandler.CreateUser)
+				users.PUT("/:id", middleware.RequirePermission("users.write"), userHandler.UpdateUser)
+				users.DELETE("/:id", middleware.RequirePermission("users.write"), userHandler.DeleteUser)
+				users.GET("/:id/sessions", middleware.RequirePermission("security.manage"), sessionHandler.GetUserSessions)
+				users.DELETE("/:id/sessions", middleware.RequirePermission("security.manage"), sessionHandler.RevokeUserSessions)
+				users.DELETE("/:id/two-factor", middleware.RequirePermission("security.manage"), twoFactorHandler.ResetUserTwoFactor)
+				users.GET("/:id/roles", middleware.RequirePermission("users.read"), roleHandler.GetUserRoles)
+				users.PUT("/:id/roles", middleware.RequirePermission("users.write"), roleHandler.SetUserRoles)
+			}
+
+			// Login security
+			security := admin.Group("/security")
+			{
+				security.GET("/lockouts", middleware.RequirePermission("security.manage"), loginSecurityHandler.GetLockouts)
+				security.DELETE("/lockouts/:id", middleware.RequirePermission("security.manage"), loginSecurityHandler.ClearLockout)
+				security.GET("/login-events", middleware.RequirePermission("security.manage"), loginSecurityHandler.GetLoginEvents)
 			}
 
 			// Students
 			students := admin.Group("/students")
 			{
-				students.GET("", studentHandler.GetStudents)
-				students.GET("/:id", studentHandler.GetStudent)
-				students.POST("", studentHandler.CreateStudent)
-				students.PUT("/:id", studentHandler.UpdateStudent)
-				students.DELETE("/:id", studentHandler.DeleteStudent)
+				students.GET("", middleware.RequirePermission("students.read"), studentHandler.GetStudents)
+				students.GET("/:id", middleware.RequirePermission("students.read"), studentHandler.GetStudent)
+				students.POST("", middleware.RequirePermission("students.write"), studentHandler.CreateStudent)
+				students.PUT("/:id", middleware.RequirePermission("students.write"), studentHandler.UpdateStudent)
+				students.DELETE("/:id", middleware.RequirePermission("students.write"), studentHandler.DeleteStudent)
+			}
+
+			// Guardians
+			guardians := admin.Group("/guardians")
+			{
+				guardians.GET("", middleware.RequirePermission("guardians.read"), guardianHandler.GetGuardians)
+				guardians.GET("/:id", middleware.RequirePermission("guardians.read"), guardianHandler.GetGuardian)
+				guardians.POST("", middleware.RequirePermission("guardians.write"), guardianHandler.CreateGuardian)
+				guardians.PUT("/:id", middleware.RequirePermission("guardians.write"), guardianHandler.UpdateGuardian)
+				guardians.DELETE("/:id", middleware.RequirePermission("guardians.write"), guardianHandler.DeleteGuardian)
+				guardians.POST("/bulk", middleware.RequirePermission("guardians.write"), guardianHandler.ImportGuardians)
+				guardians.POST("/:id/students", middleware.RequirePermission("guardians.write"), guardianHandler.LinkStudent)
+				guardians.DELETE("/:id/students/:student_id", middleware.RequirePermission("guardians.write"), guardianHandler.UnlinkStudent)
 			}
 
 			// Teachers
 			teachers := admin.Group("/teachers")
 			{
-				teachers.GET("", teacherHandler.GetTeachers)
-				teachers.GET("/:id", teacherHandler.GetTeacher)
-				teachers.POST("", teacherHandler.CreateTeacher)
-				teachers.PUT("/:id", teacherHandler.UpdateTeacher)
-				teachers.DELETE("/:id", teacherHandler.DeleteTeacher)
+				teachers.GET("", middleware.RequirePermission("teachers.read"), teacherHandler.GetTeachers)
+				teachers.GET("/:id", middleware.RequirePermission("teachers.read"), teacherHandler.GetTeacher)
+				teachers.POST("", middleware.RequirePermission("teachers.write"), teacherHandler.CreateTeacher)
+				teachers.PUT("/:id", middleware.RequirePermission("teachers.write"), teacherHandler.UpdateTeacher)
+				teachers.DELETE("/:id", middleware.RequirePermission("teachers.write"), teacherHandler.DeleteTeacher)
 			}
 
 			// Classes
 			classes := admin.Group("/classes")
 			{
-				classes.GET("", classHandler.GetClasses)
-				classes.GET("/:id", classHandler.GetClass)
-				classes.POST("", classHandler.CreateClass)
-				classes.PUT("/:id", classHandler.UpdateClass)
-				classes.DELETE("/:id", classHandler.DeleteClass)
+				classes.GET("", middleware.RequirePermission("classes.read"), classHandler.GetClasses)
+				classes.GET("/:id", middleware.RequirePermission("classes.read"), classHandler.GetClass)
+				classes.POST("", middleware.RequirePermission("classes.write"), classHandler.CreateClass)
+				classes.PUT("/:id", middleware.RequirePermission("classes.write"), classHandler.UpdateClass)
+				classes.DELETE("/:id", middleware.RequirePermission("classes.write"), classHandler.DeleteClass)
 			}
 
 			// Sections
 			sections := admin.Group("/sections")
 			{
-				sections.GET("", sectionHandler.GetSections)
-				sections.GET("/:id", sectionHandler.GetSection)
-				sections.POST("", sectionHandler.CreateSection)
-				sections.PUT("/:id", sectionHandler.UpdateSection)
-				sections.DELETE("/:id", sectionHandler.DeleteSection)
-				sections.POST("/assign", sectionHandler.AssignSectionToClass)
+				sections.GET("", middleware.RequirePermission("classes.read"), sectionHandler.GetSections)
+				sections.GET("/:id", middleware.RequirePermission("classes.read"), sectionHandler.GetSection)
+				sections.POST("", middleware.RequirePermission("classes.write"), sectionHandler.CreateSection)
+				sections.PUT("/:id", middleware.RequirePermission("classes.write"), sectionHandler.UpdateSection)
+				sections.DELETE("/:id", middleware.RequirePermission("classes.write"), sectionHandler.DeleteSection)
+				sections.POST("/assign", middleware.RequirePermission("classes.write"), sectionHandler.AssignSectionToClass)
 			}
 
 			// Subjects
 			subjects := admin.Group("/subjects")
 			{
-				subjects.GET("", subjectHandler.GetSubjects)
-				subjects.GET("/:id", subjectHandler.GetSubject)
-				subjects.POST("", subjectHandler.CreateSubject)
-				subjects.PUT("/:id", subjectHandler.UpdateSubject)
-				subjects.DELETE("/:id", subjectHandler.DeleteSubject)
+				subjects.GET("", middleware.RequirePermission("classes.read"), subjectHandler.GetSubjects)
+				subjects.GET("/:id", middleware.RequirePermission("classes.read"), subjectHandler.GetSubject)
+				subjects.POST("", middleware.RequirePermission("classes.write"), subjectHandler.CreateSubject)
+				subjects.PUT("/:id", middleware.RequirePermission("classes.write"), subjectHandler.UpdateSubject)
+				subjects.DELETE("/:id", middleware.RequirePermission("classes.write"), subjectHandler.DeleteSubject)
+			}
+
+			// Attendance
+			attendance := admin.Group("/attendance")
+			{
+				attendance.GET("/roster", middleware.RequirePermission("attendance.read"), attendanceHandler.GetRoster)
+				attendance.POST("", middleware.RequirePermission("attendance.mark"), attendanceHandler.MarkAttendance)
+				attendance.GET("/student/:id", middleware.RequirePermission("attendance.read"), attendanceHandler.GetStudentAttendance)
+
+				reports := attendance.Group("/reports")
+				{
+					reports.GET("/student/:id", middleware.RequirePermission("attendance.read"), attendanceReportHandler.GetStudentReport)
+					reports.GET("/section", middleware.RequirePermission("attendance.read"), attendanceReportHandler.GetSectionReport)
+					reports.GET("/class/:id", middleware.RequirePermission("attendance.read"), attendanceReportHandler.GetClassReport)
+					reports.GET("/absence-streaks", middleware.RequirePermission("attendance.read"), attendanceReportHandler.GetAbsenceStreaks)
+					reports.GET("/at-risk", middleware.RequirePermission("attendance.read"), attendanceReportHandler.GetAtRiskStudents)
+				}
 			}
 
-			// Add more admin routes here (attendance, etc.)
+			// Exams
+			exams := admin.Group("/exams")
+			{
+				exams.GET("", middleware.RequirePermission("exams.read"), examHandler.GetExams)
+				exams.GET("/:id", middleware.RequirePermission("exams.read"), examHandler.GetExam)
+				exams.POST("", middleware.RequirePermission("exams.write"), examHandler.CreateExam)
+				exams.PUT("/:id", middleware.RequirePermission("exams.write"), examHandler.UpdateExam)
+				exams.DELETE("/:id", middleware.RequirePermission("exams.write"), examHandler.DeleteExam)
+				exams.POST("/:id/publish", middleware.RequirePermission("marks.publish"), examHandler.PublishExam)
+				exams.POST("/:id/unpublish", middleware.RequirePermission("marks.publish"), examHandler.UnpublishExam)
+				exams.GET("/:id/marks", middleware.RequirePermission("marks.read"), markHandler.GetExamMarks)
+			}
+
+			// Grading scales
+			gradingScales := admin.Group("/grading-scales")
+			{
+				gradingScales.GET("", middleware.RequirePermission("grading.read"), gradingScaleHandler.GetGradingScales)
+				gradingScales.GET("/:id", middleware.RequirePermission("grading.read"), gradingScaleHandler.GetGradingScale)
+				gradingScales.POST("", middleware.RequirePermission("grading.write"), gradingScaleHandler.CreateGradingScale)
+				gradingScales.PUT("/:id", middleware.RequirePermission("grading.write"), gradingScaleHandler.UpdateGradingScale)
+				gradingScales.DELETE("/:id", middleware.RequirePermission("grading.write"), gradingScaleHandler.DeleteGradingScale)
+				gradingScales.GET("/assignments", middleware.RequirePermission("grading.read"), gradingScaleHandler.GetAssignments)
+				gradingScales.POST("/assignments", middleware.RequirePermission("grading.write"), gradingScaleHandler.AssignGradingScale)
+				gradingScales.POST("/recompute", middleware.RequirePermission("grading.write"), gradingScaleHandler.RecomputeGrades)
+			}
+
+			// Background jobs
+			jobs := admin.Group("/jobs")
+			{
+				jobs.GET("", middleware.RequirePermission("jobs.read"), jobHandler.GetJobs)
+				jobs.GET("/:id", middleware.RequirePermission("jobs.read"), jobHandler.GetJob)
+			}
+
+			// Report cards
+			reportCards := admin.Group("/report-cards")
+			{
+				reportCards.GET("/student/:id", middleware.RequirePermission("report_cards.read"), reportCardHandler.GetStudentReportCard)
+				reportCards.GET("/section", middleware.RequirePermission("report_cards.read"), reportCardHandler.GetSectionReportCards)
+				reportCards.GET("/remarks", middleware.RequirePermission("report_cards.read"), reportCardHandler.GetRemarks)
+				reportCards.PUT("/remarks", middleware.RequirePermission("report_cards.write"), reportCardHandler.SaveRemark)
+			}
+
+			// Timetable
+			timetable := admin.Group("/timetable")
+			{
+				timetable.GET("", middleware.RequirePermission("timetable.read"), timetableHandler.GetTimetableSlots)
+				timetable.GET("/:id", middleware.RequirePermission("timetable.read"), timetableHandler.GetTimetableSlot)
+				timetable.POST("", middleware.RequirePermission("timetable.write"), timetableHandler.CreateTimetableSlot)
+				timetable.PUT("/:id", middleware.RequirePermission("timetable.write"), timetableHandler.UpdateTimetableSlot)
+				timetable.DELETE("/:id", middleware.RequirePermission("timetable.write"), timetableHandler.DeleteTimetableSlot)
+				timetable.GET("/section/:id", middleware.RequirePermission("timetable.read"), timetableHandler.GetSectionTimetable)
+				timetable.GET("/teacher/:id", middleware.RequirePermission("timetable.read"), timetableHandler.GetTeacherTimetable)
+				timetable.POST("/generate", middleware.RequirePermission("timetable.write"), timetableGeneratorHandler.GenerateTimetable)
+				timetable.GET("/generate/:job_id", middleware.RequirePermission("timetable.read"), timetableGeneratorHandler.GetGeneratedTimetable)
+				timetable.POST("/generate/:job_id/commit", middleware.RequirePermission("timetable.write"), timetableGeneratorHandler.CommitGeneratedTimetable)
+			}
+
+			// Teaching assignments
+			teachingAssignments := admin.Group("/teaching-assignments")
+			{
+				teachingAssignments.GET("", middleware.RequirePermission("curriculum.read"), teachingAssignmentHandler.GetTeachingAssignments)
+				teachingAssignments.GET("/:id", middleware.RequirePermission("curriculum.read"), teachingAssignmentHandler.GetTeachingAssignment)
+				teachingAssignments.POST("", middleware.RequirePermission("curriculum.write"), teachingAssignmentHandler.CreateTeachingAssignment)
+				teachingAssignments.PUT("/:id", middleware.RequirePermission("curriculum.write"), teachingAssignmentHandler.UpdateTeachingAssignment)
+				teachingAssignments.DELETE("/:id", middleware.RequirePermission("curriculum.write"), teachingAssignmentHandler.DeleteTeachingAssignment)
+			}
+
+			// Curriculum
+			curriculum := admin.Group("/curriculum")
+			{
+				curriculum.GET("", middleware.RequirePermission("curriculum.read"), curriculumHandler.GetCurriculum)
+				curriculum.GET("/:id", middleware.RequirePermission("curriculum.read"), curriculumHandler.GetCurriculumSubject)
+				curriculum.POST("", middleware.RequirePermission("curriculum.write"), curriculumHandler.CreateCurriculumSubject)
+				curriculum.PUT("/:id", middleware.RequirePermission("curriculum.write"), curriculumHandler.UpdateCurriculumSubject)
+				curriculum.DELETE("/:id", middleware.RequirePermission("curriculum.write"), curriculumHandler.DeleteCurriculumSubject)
+				curriculum.POST("/copy", middleware.RequirePermission("curriculum.write"), curriculumHandler.CopyCurriculum)
+			}
+
+			// Elective enrollments
+			electives := admin.Group("/electives")
+			{
+				electives.GET("", middleware.RequirePermission("curriculum.read"), electiveHandler.GetEnrollments)
+				electives.GET("/roster", middleware.RequirePermission("curriculum.read"), electiveHandler.GetElectiveRoster)
+				electives.POST("", middleware.RequirePermission("curriculum.write"), electiveHandler.EnrollStudent)
+				electives.POST("/bulk", middleware.RequirePermission("curriculum.write"), electiveHandler.BulkEnrollStudents)
+				electives.DELETE("/:id", middleware.RequirePermission("curriculum.write"), electiveHandler.DeleteEnrollment)
+			}
+
+			// Calendar
+			calendarEvents := admin.Group("/calendar/events")
+			{
+				calendarEvents.GET("", middleware.RequirePermission("calendar.read"), calendarHandler.GetEvents)
+				calendarEvents.GET("/:id", middleware.RequirePermission("calendar.read"), calendarHandler.GetEvent)
+				calendarEvents.POST("", middleware.RequirePermission("calendar.write"), calendarHandler.CreateEvent)
+				calendarEvents.PUT("/:id", middleware.RequirePermission("calendar.write"), calendarHandler.UpdateEvent)
+				calendarEvents.DELETE("/:id", middleware.RequirePermission("calendar.write"), calendarHandler.DeleteEvent)
+			}
+
+			// Teacher leave
+			leave := admin.Group("/leave")
+			{
+				leave.GET("/requests", middleware.RequirePermission("leave.read"), leaveHandler.GetLeaveRequests)
+				leave.GET("/requests/:id", middleware.RequirePermission("leave.read"), leaveHandler.GetLeaveRequest)
+				leave.POST("/requests/:id/approve", middleware.RequirePermission("leave.approve"), leaveHandler.ApproveLeaveRequest)
+				leave.POST("/requests/:id/reject", middleware.RequirePermission("leave.approve"), leaveHandler.RejectLeaveRequest)
+				leave.GET("/balances/:id", middleware.RequirePermission("leave.read"), leaveHandler.GetTeacherLeaveBalance)
+				leave.GET("/entitlements", middleware.RequirePermission("leave.read"), leaveHandler.GetLeaveEntitlements)
+				leave.PUT("/entitlements/:leave_type", middleware.RequirePermission("leave.configure"), leaveHandler.SetLeaveEntitlement)
+				leave.DELETE("/entitlements/:leave_type", middleware.RequirePermission("leave.configure"), leaveHandler.DeleteLeaveEntitlement)
+			}
+
+			// Substitutions
+			substitutions := admin.Group("/substitutions")
+			{
+				substitutions.GET("/affected", middleware.RequirePermission("substitutions.read"), substitutionHandler.GetAffectedPeriods)
+				substitutions.GET("/suggestions", middleware.RequirePermission("substitutions.read"), substitutionHandler.GetSubstituteSuggestions)
+				substitutions.GET("/sheet", middleware.RequirePermission("substitutions.read"), substitutionHandler.GetSubstitutionSheet)
+				substitutions.GET("/teacher/:id/day", middleware.RequirePermission("substitutions.read"), substitutionHandler.GetTeacherDaySchedule)
+				substitutions.POST("", middleware.RequirePermission("substitutions.write"), substitutionHandler.CreateSubstitution)
+				substitutions.DELETE("/:id", middleware.RequirePermission("substitutions.write"), substitutionHandler.DeleteSubstitution)
+			}
+
+			// Student leave
+			studentLeave := admin.Group("/student-leave")
+			{
+				studentLeave.GET("", middleware.RequirePermission("student_leave.read"), studentLeaveHandler.GetLeaveRequests)
+				studentLeave.GET("/:id", middleware.RequirePermission("student_leave.read"), studentLeaveHandler.GetLeaveRequest)
+				studentLeave.POST("/:id/approve", middleware.RequirePermission("student_leave.approve"), studentLeaveHandler.ApproveLeaveRequest)
+				studentLeave.POST("/:id/reject", middleware.RequirePermission("student_leave.approve"), studentLeaveHandler.RejectLeaveRequest)
+			}
+
+			// Notices
+			notices := admin.Group("/notices")
+			{
+				notices.GET("", middleware.RequirePermission("notices.read"), noticeHandler.GetNotices)
+				notices.GET("/:id", middleware.RequirePermission("notices.read"), noticeHandler.GetNotice)
+				notices.GET("/:id/reads", middleware.RequirePermission("notices.read"), noticeHandler.GetNoticeReads)
+				notices.POST("", middleware.RequirePermission("notices.write"), noticeHandler.CreateNotice)
+				notices.PUT("/:id", middleware.RequirePermission("notices.write"), noticeHandler.UpdateNotice)
+				notices.DELETE("/:id", middleware.RequirePermission("notices.write"), noticeHandler.DeleteNotice)
+			}
+
+			// Roles and permissions
+			admin.GET("/permissions", middleware.RequirePermission("roles.manage"), roleHandler.GetPermissions)
+			roles := admin.Group("/roles")
+			roles.Use(middleware.RequirePermission("roles.manage"))
+			{
+				roles.GET("", roleHandler.GetRoles)
+				roles.GET("/:id", roleHandler.GetRole)
+				roles.POST("", roleHandler.CreateRole)
+				roles.PUT("/:id", roleHandler.UpdateRole)
+				roles.DELETE("/:id", roleHandler.DeleteRole)
+			}
+
+			// Add more admin routes here
 		}
 
 		// Teacher routes
 		teacher := api.Group("/teacher")
 		teacher.Use(middleware.AuthMiddleware(), middleware.RoleMiddleware("teacher"))
 		{
-			// Add teacher routes here
+			// Attendance
+			attendance := teacher.Group("/attendance")
+			{
+				attendance.GET("/roster", attendanceHandler.GetRoster)
+				attendance.POST("", attendanceHandler.MarkAttendance)
+
+				reports := attendance.Group("/reports")
+				{
+					reports.GET("/student/:id", attendanceReportHandler.GetStudentReport)
+					reports.GET("/section", attendanceReportHandler.GetSectionReport)
+					reports.GET("/absence-streaks", attendanceReportHandler.GetAbsenceStreaks)
+					reports.GET("/at-risk", attendanceReportHandler.GetAtRiskStudents)
+				}
+			}
+
+			// Exams and marks
+			exams := teacher.Group("/exams")
+			{
+				exams.GET("", examHandler.GetExams)
+				exams.GET("/:id/marks", markHandler.GetExamMarks)
+				exams.POST("/:id/marks", markHandler.EnterMarks)
+			}
+
+			// Report card remarks
+			reportCards := teacher.Group("/report-cards")
+			{
+				reportCards.GET("/remarks", reportCardHandler.GetRemarks)
+				reportCards.PUT("/remarks", reportCardHandler.SaveRemark)
+			}
+
+			// Assignments
+			assignments := teacher.Group("/assignments")
+			{
+				assignments.GET("", assignmentHandler.GetTeacherAssignments)
+				assignments.GET("/:id", assignmentHandler.GetTeacherAssignment)
+				assignments.POST("", assignmentHandler.CreateAssignment)
+				assignments.PUT("/:id", assignmentHandler.UpdateAssignment)
+				assignments.DELETE("/:id", assignmentHandler.DeleteAssignment)
+				assignments.GET("/:id/submissions", assignmentHandler.GetSubmissions)
+				assignments.PUT("/:id/submissions/:submission_id/grade", assignmentHandler.GradeSubmission)
+			}
+
+			// Timetable
+			timetable := teacher.Group("/timetable")
+			{
+				timetable.GET("", timetableHandler.GetMyTeacherTimetable)
+				timetable.GET("/section/:id", timetableHandler.GetSectionTimetable)
+			}
+
+			// Teaching assignments
+			teacher.GET("/teaching-assignments", teachingAssignmentHandler.GetMyTeachingAssignments)
+
+			// Curriculum
+			teacher.GET("/curriculum", curriculumHandler.GetCurriculum)
+			teacher.GET("/electives/roster", electiveHandler.GetElectiveRoster)
+
+			// Leave
+			leave := teacher.Group("/leave")
+			{
+				leave.GET("", leaveHandler.GetMyLeaveRequests)
+				leave.POST("", leaveHandler.ApplyForLeave)
+				leave.GET("/balance", leaveHandler.GetMyLeaveBalance)
+				leave.POST("/:id/cancel", leaveHandler.CancelLeaveRequest)
+			}
+
+			// Substitutions
+			teacher.GET("/substitutions/day", substitutionHandler.GetMyDaySchedule)
+
+			// Student leave of the sections they are class teacher of
+			studentLeave := teacher.Group("/student-leave")
+			{
+				studentLeave.GET("", studentLeaveHandler.GetSectionLeaveRequests)
+				studentLeave.POST("/:id/approve", studentLeaveHandler.ApproveLeaveRequest)
+				studentLeave.POST("/:id/reject", studentLeaveHandler.RejectLeaveRequest)
+			}
+
+			// Notices published by the teacher
+			notices := teacher.Group("/notices")
+			{
+				notices.GET("", noticeHandler.GetNotices)
+				notices.GET("/:id", noticeHandler.GetNotice)
+				notices.GET("/:id/reads", noticeHandler.GetNoticeReads)
+				notices.POST("", noticeHandler.CreateNotice)
+				notices.PUT("/:id", noticeHandler.UpdateNotice)
+				notices.DELETE("/:id", noticeHandler.DeleteNotice)
+			}
 		}
 
 		// Student routes
 		student := api.Group("/student")
 		student.Use(middleware.AuthMiddleware(), middleware.RoleMiddleware("student"))
 		{
-			// Add student routes here
+			// Assignments
+			assignments := student.Group("/assignments")
+			{
+				assignments.GET("", assignmentHandler.GetStudentAssignments)
+				assignments.GET("/:id", assignmentHandler.GetStudentAssignment)
+				assignments.POST("/:id/submit", assignmentHandler.SubmitAssignment)
+			}
+
+			// Timetable
+			student.GET("/timetable", timetableHandler.GetMyStudentTimetable)
+
+			// Subjects
+			student.GET("/subjects", curriculumHandler.GetMySubjects)
+
+			// Leave
+			leave := student.Group("/leave")
+			{
+				leave.GET("", studentLeaveHandler.GetMyLeaveRequests)
+				leave.POST("", studentLeaveHandler.ApplyForLeave)
+				leave.POST("/:id/cancel", studentLeaveHandler.CancelLeaveRequest)
+			}
+		}
+
+		// Calendar of every logged in user. The iCalendar feed is
+		// authenticated by the secret token in its URL.
+		calendar := api.Group("/calendar")
+		{
+			calendar.GET("", middleware.AuthMiddleware(), calendarHandler.GetCalendar)
+			calendar.GET("/school-days", middleware.AuthMiddleware(), calendarHandler.GetSchoolDays)
+			calendar.GET("/feed", middleware.AuthMiddleware(), calendarHandler.GetFeed)
+			calendar.POST("/feed", middleware.AuthMiddleware(), calendarHandler.CreateFeed)
+			calendar.DELETE("/feed", middleware.AuthMiddleware(), calendarHandler.DeleteFeed)
+			calendar.GET("/ics/:token", calendarHandler.GetICS)
+		}
+
+		// Notice feed of every logged in user
+		notices := api.Group("/notices")
+		notices.Use(middleware.AuthMiddleware())
+		{
+			notices.GET("", noticeHandler.GetFeed)
+			notices.GET("/unread-count", noticeHandler.GetUnreadCount)
+			notices.POST("/:id/read", noticeHandler.MarkNoticeRead)
+		}
+
+		// Parent routes, views of the guardian's children and their leave
+		parent := api.Group("/parent")
+		parent.Use(middleware.AuthMiddleware(), middleware.RoleMiddleware("parent"))
+		{
+			parent.GET("/children", parentHandler.GetChildren)
+			parent.GET("/children/:id/attendance", parentHandler.GetChildAttendance)
+			parent.GET("/children/:id/marks", parentHandler.GetChildMarks)
+			parent.GET("/children/:id/assignments", parentHandler.GetChildAssignments)
+			parent.GET("/children/:id/timetable", parentHandler.GetChildTimetable)
+			parent.GET("/children/:id/leave", studentLeaveHandler.GetChildLeaveRequests)
+			parent.POST("/children/:id/leave", studentLeaveHandler.ApplyForChildLeave)
+			parent.POST("/children/:id/leave/:leave_id/cancel", studentLeaveHandler.CancelChildLeaveRequest)
+			parent.GET("/notices", noticeHandler.GetFeed)
 		}
 	}
 
@@ -1,7 +1,12 @@
 package config
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
@@ -15,8 +20,69 @@ type Config struct {
 	DBName       string
 	DBDriver     string
 	JWTSecret    string
-	JWTExpiry    int
 	Environment  string
+
+	// Access tokens are short-lived and renewed with a rotating refresh token
+	AccessTokenExpiry  int // minutes
+	RefreshTokenExpiry int // hours
+
+	// Attendance percentage below which a student is reported as at risk
+	AttendanceThreshold float64
+
+	// Printed on report cards
+	SchoolName    string
+	SchoolAddress string
+
+	// Month (1-12) in which an academic year such as "2024-2025" starts
+	AcademicYearStartMonth int
+
+	// Weekdays on which the school is closed, e.g. "Sunday"
+	WeeklyOffDays []string
+
+	// Uploaded files
+	UploadDir       string
+	MaxUploadSizeMB int
+
+	// File storage backend: "local" keeps files below UploadDir, "s3" uses an
+	// S3-compatible object store
+	StorageBackend   string
+	StorageURLSecret string // signs download URLs of the local backend, derived from JWTSecret when unset
+	PublicURL        string // base URL of this server, used in signed download URLs
+	S3Endpoint       string
+	S3Region         string
+	S3Bucket         string
+	S3AccessKey      string
+	S3SecretKey      string
+	S3PathStyle      bool
+
+	// Outgoing mail: "smtp" sends messages through an SMTP server, "log"
+	// writes them to the server log and is only allowed in development, where
+	// it is the default
+	MailBackend  string
+	MailFrom     string
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+
+	// Page of the frontend that reset links point to, and how long they work
+	PasswordResetURL    string
+	PasswordResetExpiry int // minutes
+
+	// Reset requests allowed per email and per IP address within
+	// PasswordResetWindow; requests beyond them are silently dropped
+	PasswordResetMaxPerEmail int
+	PasswordResetMaxPerIP    int
+	PasswordResetWindow      int // minutes
+
+	// Failed logins allowed per email and per IP address before further
+	// attempts are locked out
+	LoginMaxFailures      int
+	LoginMaxFailuresPerIP int
+	LoginLockoutMinutes   int
+
+	// Roles whose users must set up two-factor authentication to log in
+	TwoFactorRequiredRoles []string
 }
 
 var AppConfig *Config
@@ -34,13 +100,68 @@ func LoadConfig() error {
 		DBName:      getEnv("DB_NAME", "school_erp"),
 		DBDriver:    getEnv("DB_DRIVER", "postgres"),
 		JWTSecret:   getEnv("JWT_SECRET", "your-secret-key-change-in-production"),
-		JWTExpiry:   24, // hours
 		Environment: getEnv("ENVIRONMENT", "development"),
+
+		AccessTokenExpiry:  getEnvInt("ACCESS_TOKEN_EXPIRY_MINUTES", 15),
+		RefreshTokenExpiry: getEnvInt("REFRESH_TOKEN_EXPIRY_HOURS", 168),
+
+		AttendanceThreshold: getEnvFloat("ATTENDANCE_THRESHOLD", 75),
+
+		SchoolName:    getEnv("SCHOOL_NAME", "School ERP"),
+		SchoolAddress: getEnv("SCHOOL_ADDRESS", ""),
+
+		AcademicYearStartMonth: getEnvInt("ACADEMIC_YEAR_START_MONTH", 4),
+		WeeklyOffDays:          getEnvList("WEEKLY_OFF_DAYS", []string{"Sunday"}),
+
+		UploadDir:       getEnv("UPLOAD_DIR", "uploads"),
+		MaxUploadSizeMB: getEnvInt("MAX_UPLOAD_SIZE_MB", 10),
+
+		StorageBackend: getEnv("STORAGE_BACKEND", "local"),
+		PublicURL:      getEnv("PUBLIC_URL", "http://localhost:8080"),
+		S3Endpoint:     getEnv("S3_ENDPOINT", "https://s3.amazonaws.com"),
+		S3Region:       getEnv("S3_REGION", "us-east-1"),
+		S3Bucket:       getEnv("S3_BUCKET", ""),
+		S3AccessKey:    getEnv("S3_ACCESS_KEY", ""),
+		S3SecretKey:    getEnv("S3_SECRET_KEY", ""),
+		S3PathStyle:    getEnvBool("S3_PATH_STYLE", true),
+
+		MailBackend:  getEnv("MAIL_BACKEND", ""),
+		MailFrom:     getEnv("MAIL_FROM", "School ERP <no-reply@localhost>"),
+		SMTPHost:     getEnv("SMTP_HOST", ""),
+		SMTPPort:     getEnvInt("SMTP_PORT", 587),
+		SMTPUsername: getEnv("SMTP_USERNAME", ""),
+		SMTPPassword: getEnv("SMTP_PASSWORD", ""),
+
+		PasswordResetURL:    getEnv("PASSWORD_RESET_URL", "http://localhost:3000/reset-password"),
+		PasswordResetExpiry: getEnvInt("PASSWORD_RESET_EXPIRY_MINUTES", 60),
+
+		PasswordResetMaxPerEmail: getEnvInt("PASSWORD_RESET_MAX_PER_EMAIL", 3),
+		PasswordResetMaxPerIP:    getEnvInt("PASSWORD_RESET_MAX_PER_IP", 20),
+		PasswordResetWindow:      getEnvInt("PASSWORD_RESET_WINDOW_MINUTES", 60),
+
+		LoginMaxFailures:      getEnvInt("LOGIN_MAX_FAILURES", 5),
+		LoginMaxFailuresPerIP: getEnvInt("LOGIN_MAX_FAILURES_PER_IP", 50),
+		LoginLockoutMinutes:   getEnvInt("LOGIN_LOCKOUT_MINUTES", 15),
+
+		TwoFactorRequiredRoles: getEnvList("TWO_FACTOR_REQUIRED_ROLES", []string{"admin"}),
+	}
+
+	if AppConfig.MailBackend == "" && AppConfig.Environment == "development" {
+		AppConfig.MailBackend = "log"
 	}
+	AppConfig.StorageURLSecret = getEnv("STORAGE_URL_SECRET", deriveSecret(AppConfig.JWTSecret, "storage-url-signing"))
 
 	return nil
 }
 
+// deriveSecret returns a key for one purpose derived from secret, so that the
+// same configured secret never signs two kinds of data.
+func deriveSecret(secret, purpose string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(purpose))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
@@ -48,5 +169,39 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+func getEnvFloat(key string, defaultValue float64) float64 {
+	if value, err := strconv.ParseFloat(os.Getenv(key), 64); err == nil {
+		return value
+	}
+	return defaultValue
+}
 
+func getEnvInt(key string, defaultValue int) int {
+	if value, err := strconv.Atoi(os.Getenv(key)); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
+func getEnvBool(key string, defaultValue bool) bool {
+	if value, err := strconv.ParseBool(os.Getenv(key)); err == nil {
+		return value
+	}
+	return defaultValue
+}
 
+// getEnvList reads a comma separated list. A variable that is set but empty
+// gives an empty list.
+func getEnvList(key string, defaultValue []string) []string {
+	value, ok := os.LookupEnv(key)
+	if !ok {
+		return defaultValue
+	}
+	list := []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
@@ -0,0 +1,740 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+	"school-erp-backend/config"
+	"school-erp-backend/internal/models"
+	"school-erp-backend/internal/repository"
+	"school-erp-backend/internal/services"
+	"school-erp-backend/pkg/database"
+	"school-erp-backend/pkg/storage"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type AssignmentHandler struct {
+	assignmentRepo *repository.AssignmentRepository
+	submissionRepo *repository.AssignmentSubmissionRepository
+	studentRepo    *repository.StudentRepository
+	teacherRepo    *repository.TeacherRepository
+	access         teachingAccess
+}
+
+func NewAssignmentHandler() *AssignmentHandler {
+	return &AssignmentHandler{
+		assignmentRepo: repository.NewAssignmentRepository(database.DB),
+		submissionRepo: repository.NewAssignmentSubmissionRepository(database.DB),
+		studentRepo:    repository.NewStudentRepository(database.DB),
+		teacherRepo:    repository.NewTeacherRepository(database.DB),
+		access:         newTeachingAccess(),
+	}
+}
+
+// GetTeacherAssignments godoc
+// @Summary Get own assignments
+// @Description Get the assignments published by the logged in teacher
+// @Tags Assignments
+// @Accept json
+// @Produce json
+// @Param class_id query int false "Filter by class ID"
+// @Param subject_id query int false "Filter by subject ID"
+// @Success 200 {array} models.Assignment
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /teacher/assignments [get]
+// @Security BearerAuth
+func (h *AssignmentHandler) GetTeacherAssignments(c *gin.Context) {
+	teacher, err := h.teacherRepo.FindByUserID(c.GetUint("user_id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Teacher profile not found"})
+		return
+	}
+
+	classID, _ := strconv.ParseUint(c.Query("class_id"), 10, 32)
+	subjectID, _ := strconv.ParseUint(c.Query("subject_id"), 10, 32)
+	scope, ok := requestScope(c)
+	if !ok {
+		return
+	}
+
+	assignments, err := h.assignmentRepo.Scoped(scope).FindByTeacher(teacher.ID, uint(classID), uint(subjectID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, assignments)
+}
+
+// CreateAssignment godoc
+// @Summary Publish assignment
+// @Description Publish an assignment to a class in which the teacher teaches the subject. The due date is either a date (due by the end of that day) or an RFC 3339 timestamp.
+// @Tags Assignments
+// @Accept json
+// @Produce json
+// @Param assignment body CreateAssignmentRequest true "Assignment data"
+// @Success 201 {object} models.Assignment
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /teacher/assignments [post]
+// @Security BearerAuth
+func (h *AssignmentHandler) CreateAssignment(c *gin.Context) {
+	var req CreateAssignmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	teacher, err := h.teacherRepo.FindByUserID(c.GetUint("user_id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Teacher profile not found"})
+		return
+	}
+
+	dueDate, err := parseDueDate(req.DueDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if dueDate.Before(time.Now()) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Due date must be in the future"})
+		return
+	}
+
+	if !h.access.allow(c, req.ClassID, 0, req.SubjectID, academicYearOf(time.Now())) {
+		return
+	}
+
+	assignment := &models.Assignment{
+		Title:       req.Title,
+		Description: req.Description,
+		SubjectID:   req.SubjectID,
+		ClassID:     req.ClassID,
+		TeacherID:   teacher.ID,
+		DueDate:     dueDate,
+		TotalMarks:  req.TotalMarks,
+	}
+
+	if err := h.assignmentRepo.Create(assignment); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, assignment)
+}
+
+// GetTeacherAssignment godoc
+// @Summary Get own assignment by ID
+// @Description Get an assignment published by the logged in teacher
+// @Tags Assignments
+// @Accept json
+// @Produce json
+// @Param id path int true "Assignment ID"
+// @Success 200 {object} models.Assignment
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /teacher/assignments/{id} [get]
+// @Security BearerAuth
+func (h *AssignmentHandler) GetTeacherAssignment(c *gin.Context) {
+	assignment, ok := h.ownAssignment(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, assignment)
+}
+
+// UpdateAssignment godoc
+// @Summary Update assignment
+// @Description Update an assignment published by the logged in teacher. Moving the due date re-evaluates which submissions are late. The class cannot change once submissions exist, and the teacher must teach the subject in the class.
+// @Tags Assignments
+// @Accept json
+// @Produce json
+// @Param id path int true "Assignment ID"
+// @Param assignment body UpdateAssignmentRequest true "Assignment data"
+// @Success 200 {object} models.Assignment
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /teacher/assignments/{id} [put]
+// @Security BearerAuth
+func (h *AssignmentHandler) UpdateAssignment(c *gin.Context) {
+	var req UpdateAssignmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	assignment, ok := h.ownAssignment(c)
+	if !ok {
+		return
+	}
+
+	submissions, err := h.submissionRepo.FindByAssignment(assignment.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if req.ClassID != 0 && req.ClassID != assignment.ClassID {
+		if len(submissions) > 0 {
+			c.JSON(http.StatusConflict, gin.H{"error": "Cannot move an assignment to another class once students have submitted"})
+			return
+		}
+		assignment.ClassID = req.ClassID
+	}
+	if req.Title != "" {
+		assignment.Title = req.Title
+	}
+	if req.Description != "" {
+		assignment.Description = req.Description
+	}
+	if req.SubjectID != 0 {
+		assignment.SubjectID = req.SubjectID
+	}
+	if req.TotalMarks != 0 {
+		for _, submission := range submissions {
+			if submission.Status == "graded" && submission.MarksObtained > req.TotalMarks {
+				c.JSON(http.StatusConflict, gin.H{"error": "Total marks cannot be lower than marks already awarded"})
+				return
+			}
+		}
+		assignment.TotalMarks = req.TotalMarks
+	}
+
+	dueDateChanged := false
+	if req.DueDate != "" {
+		dueDate, err := parseDueDate(req.DueDate)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		dueDateChanged = !dueDate.Equal(assignment.DueDate)
+		assignment.DueDate = dueDate
+	}
+
+	if req.ClassID != 0 || req.SubjectID != 0 {
+		if !h.access.allow(c, assignment.ClassID, 0, assignment.SubjectID, academicYearOf(time.Now())) {
+			return
+		}
+	}
+
+	if err := h.assignmentRepo.Update(assignment); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if dueDateChanged {
+		for i := range submissions {
+			submission := &submissions[i]
+			isLate := submission.SubmittedAt.After(assignment.DueDate)
+			if submission.IsLate == isLate {
+				continue
+			}
+			submission.IsLate = isLate
+			if submission.Status != "graded" {
+				submission.Status = submissionStatus(isLate)
+			}
+			if err := h.submissionRepo.Update(submission); err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+		}
+	}
+
+	c.JSON(http.StatusOK, assignment)
+}
+
+// DeleteAssignment godoc
+// @Summary Delete assignment
+// @Description Delete an assignment published by the logged in teacher that has no submissions
+// @Tags Assignments
+// @Accept json
+// @Produce json
+// @Param id path int true "Assignment ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /teacher/assignments/{id} [delete]
+// @Security BearerAuth
+func (h *AssignmentHandler) DeleteAssignment(c *gin.Context) {
+	assignment, ok := h.ownAssignment(c)
+	if !ok {
+		return
+	}
+
+	count, err := h.submissionRepo.CountByAssignment(assignment.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if count > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "Cannot delete an assignment with submissions"})
+		return
+	}
+
+	if err := h.assignmentRepo.Delete(assignment.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Assignment deleted successfully"})
+}
+
+// GetSubmissions godoc
+// @Summary Get submissions of an assignment
+// @Description Get the students of the assignment's class (optionally one section) with their submissions. Assignments of an elective list the enrolled students only.
+// @Tags Assignments
+// @Accept json
+// @Produce json
+// @Param id path int true "Assignment ID"
+// @Param section_id query int false "Section ID"
+// @Success 200 {object} AssignmentSubmissionsResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /teacher/assignments/{id}/submissions [get]
+// @Security BearerAuth
+func (h *AssignmentHandler) GetSubmissions(c *gin.Context) {
+	assignment, ok := h.ownAssignment(c)
+	if !ok {
+		return
+	}
+
+	var sectionID uint64
+	if value := c.Query("section_id"); value != "" {
+		parsed, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid section ID"})
+			return
+		}
+		sectionID = parsed
+	}
+	scope, ok := requestScope(c)
+	if !ok {
+		return
+	}
+
+	students, err := services.SubjectRoster(database.DB, assignment.ClassID, uint(sectionID), assignment.SubjectID, academicYearOf(assignment.DueDate))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	submissions, err := h.submissionRepo.Scoped(scope).FindByAssignment(assignment.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	submissionByStudent := make(map[uint]models.AssignmentSubmission, len(submissions))
+	for _, submission := range submissions {
+		submission.Student = models.Student{}
+		submissionByStudent[submission.StudentID] = submission
+	}
+
+	response := AssignmentSubmissionsResponse{
+		Assignment: *assignment,
+		Students:   make([]AssignmentSubmissionEntry, 0, len(students)),
+	}
+	for _, student := range students {
+		entry := AssignmentSubmissionEntry{
+			StudentID:       student.ID,
+			AdmissionNumber: student.AdmissionNumber,
+			FirstName:       student.FirstName,
+			LastName:        student.LastName,
+			SectionID:       student.SectionID,
+		}
+		if submission, ok := submissionByStudent[student.ID]; ok {
+			entry.Submission = &submission
+			response.Submitted++
+			if submission.IsLate {
+				response.Late++
+			}
+			if submission.Status == "graded" {
+				response.Graded++
+			}
+		}
+		response.Students = append(response.Students, entry)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GradeSubmission godoc
+// @Summary Grade a submission
+// @Description Set the marks and feedback of a submission to an assignment of the logged in teacher
+// @Tags Assignments
+// @Accept json
+// @Produce json
+// @Param id path int true "Assignment ID"
+// @Param submission_id path int true "Submission ID"
+// @Param grade body GradeSubmissionRequest true "Grade data"
+// @Success 200 {object} models.AssignmentSubmission
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /teacher/assignments/{id}/submissions/{submission_id}/grade [put]
+// @Security BearerAuth
+func (h *AssignmentHandler) GradeSubmission(c *gin.Context) {
+	var req GradeSubmissionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	assignment, ok := h.ownAssignment(c)
+	if !ok {
+		return
+	}
+
+	scope, ok := requestScope(c)
+	if !ok {
+		return
+	}
+
+	submissionID, _ := strconv.ParseUint(c.Param("submission_id"), 10, 32)
+	submission, err := h.submissionRepo.Scoped(scope).FindByID(uint(submissionID))
+	if err != nil || submission.AssignmentID != assignment.ID {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Submission not found"})
+		return
+	}
+
+	if assignment.TotalMarks > 0 && *req.MarksObtained > assignment.TotalMarks {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Marks exceed the total marks of the assignment"})
+		return
+	}
+
+	now := time.Now()
+	gradedBy := c.GetUint("user_id")
+	submission.MarksObtained = *req.MarksObtained
+	submission.Feedback = req.Feedback
+	submission.Status = "graded"
+	submission.GradedBy = &gradedBy
+	submission.GradedAt = &now
+
+	if err := h.submissionRepo.Update(submission); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, submission)
+}
+
+// GetStudentAssignments godoc
+// @Summary Get assignments of own class
+// @Description Get the assignments of the logged in student's class with the student's own submission
+// @Tags Student - Assignments
+// @Accept json
+// @Produce json
+// @Param subject_id query int false "Filter by subject ID"
+// @Success 200 {array} StudentAssignmentEntry
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /student/assignments [get]
+// @Security BearerAuth
+func (h *AssignmentHandler) GetStudentAssignments(c *gin.Context) {
+	student, err := h.studentRepo.FindByUserID(c.GetUint("user_id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Student profile not found"})
+		return
+	}
+
+	scope, ok := requestScope(c)
+	if !ok {
+		return
+	}
+
+	subjectID, _ := strconv.ParseUint(c.Query("subject_id"), 10, 32)
+	assignments, err := h.assignmentRepo.Scoped(scope).FindByClass(student.ClassID, uint(subjectID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	assignmentIDs := make([]uint, len(assignments))
+	for i, assignment := range assignments {
+		assignmentIDs[i] = assignment.ID
+	}
+	submissions, err := h.submissionRepo.Scoped(scope).FindByStudent(student.ID, assignmentIDs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, studentAssignmentEntries(assignments, submissions))
+}
+
+// GetStudentAssignment godoc
+// @Summary Get assignment of own class by ID
+// @Description Get an assignment of the logged in student's class with the student's own submission
+// @Tags Student - Assignments
+// @Accept json
+// @Produce json
+// @Param id path int true "Assignment ID"
+// @Success 200 {object} StudentAssignmentEntry
+// @Failure 404 {object} ErrorResponse
+// @Router /student/assignments/{id} [get]
+// @Security BearerAuth
+func (h *AssignmentHandler) GetStudentAssignment(c *gin.Context) {
+	student, assignment, ok := h.classAssignment(c)
+	if !ok {
+		return
+	}
+
+	entry := StudentAssignmentEntry{Assignment: *assignment}
+	submission, err := h.submissionRepo.FindByAssignmentAndStudent(assignment.ID, student.ID)
+	if err == nil {
+		entry.Submission = submission
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
+
+// SubmitAssignment godoc
+// @Summary Submit assignment
+// @Description Upload the submission file of an assignment of the logged in student's class. Submitting again replaces the earlier file until the submission is graded. Submissions after the due date are marked late.
+// @Tags Student - Assignments
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path int true "Assignment ID"
+// @Param file formData file true "Submission file (PDF, PNG, JPEG, plain text, ZIP or Office document)"
+// @Success 201 {object} models.AssignmentSubmission
+// @Success 200 {object} models.AssignmentSubmission
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 413 {object} ErrorResponse
+// @Failure 415 {object} ErrorResponse
+// @Router /student/assignments/{id}/submit [post]
+// @Security BearerAuth
+func (h *AssignmentHandler) SubmitAssignment(c *gin.Context) {
+	maxBytes := int64(config.AppConfig.MaxUploadSizeMB) << 20
+	// Leave room for the multipart envelope around the file
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBytes+1<<20)
+
+	student, assignment, ok := h.classAssignment(c)
+	if !ok {
+		return
+	}
+
+	existing, err := h.submissionRepo.FindByAssignmentAndStudent(assignment.ID, student.ID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if err == nil && existing.Status == "graded" {
+		c.JSON(http.StatusConflict, gin.H{"error": "Submission has already been graded"})
+		return
+	}
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": services.ErrFileTooLarge.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "file is required"})
+		return
+	}
+
+	keyPrefix := fmt.Sprintf("assignments/%d/%d", assignment.ID, student.ID)
+	upload, err := services.StoreUpload(c.Request.Context(), storage.Files, header, keyPrefix, maxBytes)
+	if errors.Is(err, services.ErrFileTooLarge) {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, services.ErrFileTypeNotAllowed) {
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	now := time.Now()
+	isLate := now.After(assignment.DueDate)
+
+	if existing.ID != 0 {
+		oldKey := existing.FilePath
+		existing.SubmissionDate = now
+		existing.SubmittedAt = now
+		existing.FilePath = upload.Key
+		existing.FileName = upload.FileName
+		existing.ContentType = upload.ContentType
+		existing.FileSize = upload.Size
+		existing.IsLate = isLate
+		existing.Status = submissionStatus(isLate)
+		if err := h.submissionRepo.Update(existing); err != nil {
+			storage.Files.Delete(c.Request.Context(), upload.Key)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if oldKey != "" && oldKey != upload.Key {
+			storage.Files.Delete(c.Request.Context(), oldKey)
+		}
+		c.JSON(http.StatusOK, existing)
+		return
+	}
+
+	submission := &models.AssignmentSubmission{
+		AssignmentID:   assignment.ID,
+		StudentID:      student.ID,
+		SubmissionDate: now,
+		SubmittedAt:    now,
+		FilePath:       upload.Key,
+		FileName:       upload.FileName,
+		ContentType:    upload.ContentType,
+		FileSize:       upload.Size,
+		IsLate:         isLate,
+		Status:         submissionStatus(isLate),
+	}
+	if err := h.submissionRepo.Create(submission); err != nil {
+		storage.Files.Delete(c.Request.Context(), upload.Key)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, submission)
+}
+
+// ownAssignment loads the assignment in the id path parameter and checks that
+// it was published by the logged in teacher.
+func (h *AssignmentHandler) ownAssignment(c *gin.Context) (*models.Assignment, bool) {
+	teacher, err := h.teacherRepo.FindByUserID(c.GetUint("user_id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Teacher profile not found"})
+		return nil, false
+	}
+
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	assignment, err := h.assignmentRepo.FindByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Assignment not found"})
+		return nil, false
+	}
+	if assignment.TeacherID != teacher.ID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Assignment belongs to another teacher"})
+		return nil, false
+	}
+	return assignment, true
+}
+
+// classAssignment loads the logged in student and the assignment in the id
+// path parameter. Assignments of other classes are reported as not found.
+func (h *AssignmentHandler) classAssignment(c *gin.Context) (*models.Student, *models.Assignment, bool) {
+	student, err := h.studentRepo.FindByUserID(c.GetUint("user_id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Student profile not found"})
+		return nil, nil, false
+	}
+
+	scope, ok := requestScope(c)
+	if !ok {
+		return nil, nil, false
+	}
+
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	assignment, err := h.assignmentRepo.Scoped(scope).FindByID(uint(id))
+	if err != nil || assignment.ClassID != student.ClassID {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Assignment not found"})
+		return nil, nil, false
+	}
+	return student, assignment, true
+}
+
+// studentAssignmentEntries pairs the assignments of a student's class with
+// the student's submissions
+func studentAssignmentEntries(assignments []models.Assignment, submissions []models.AssignmentSubmission) []StudentAssignmentEntry {
+	submissionByAssignment := make(map[uint]models.AssignmentSubmission, len(submissions))
+	for _, submission := range submissions {
+		submissionByAssignment[submission.AssignmentID] = submission
+	}
+
+	entries := make([]StudentAssignmentEntry, 0, len(assignments))
+	for _, assignment := range assignments {
+		entry := StudentAssignmentEntry{Assignment: assignment}
+		if submission, ok := submissionByAssignment[assignment.ID]; ok {
+			entry.Submission = &submission
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func submissionStatus(isLate bool) string {
+	if isLate {
+		return "late"
+	}
+	return "pending"
+}
+
+// parseDueDate accepts a date, meaning the end of that day, or an RFC 3339 timestamp.
+func parseDueDate(value string) (time.Time, error) {
+	if date, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return date.Add(24*time.Hour - time.Second), nil
+	}
+	dueDate, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, errors.New("Invalid due date. Use YYYY-MM-DD or RFC 3339")
+	}
+	return dueDate, nil
+}
+
+// Request Types
+type CreateAssignmentRequest struct {
+	Title       string  `json:"title" binding:"required"`
+	Description string  `json:"description"`
+	SubjectID   uint    `json:"subject_id" binding:"required"`
+	ClassID     uint    `json:"class_id" binding:"required"`
+	DueDate     string  `json:"due_date" binding:"required"`
+	TotalMarks  float64 `json:"total_marks" binding:"gte=0"`
+}
+
+type UpdateAssignmentRequest struct {
+	Title       string  `json:"title"`
+	Description string  `json:"description"`
+	SubjectID   uint    `json:"subject_id"`
+	ClassID     uint    `json:"class_id"`
+	DueDate     string  `json:"due_date"`
+	TotalMarks  float64 `json:"total_marks" binding:"gte=0"`
+}
+
+type GradeSubmissionRequest struct {
+	MarksObtained *float64 `json:"marks_obtained" binding:"required,gte=0"`
+	Feedback      string   `json:"feedback"`
+}
+
+// Response Types
+type AssignmentSubmissionsResponse struct {
+	Assignment models.Assignment           `json:"assignment"`
+	Submitted  int                         `json:"submitted"`
+	Late       int                         `json:"late"`
+	Graded     int                         `json:"graded"`
+	Students   []AssignmentSubmissionEntry `json:"students"`
+}
+
+type AssignmentSubmissionEntry struct {
+	StudentID       uint                         `json:"student_id"`
+	AdmissionNumber string                       `json:"admission_number"`
+	FirstName       string                       `json:"first_name"`
+	LastName        string                       `json:"last_name"`
+	SectionID       uint                         `json:"section_id"`
+	Submission      *models.AssignmentSubmission `json:"submission"`
+}
+
+type StudentAssignmentEntry struct {
+	models.Assignment
+	Submission *models.AssignmentSubmission `json:"submission"`
+}
@@ -0,0 +1,278 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+	"school-erp-backend/internal/models"
+	"school-erp-backend/internal/repository"
+	"school-erp-backend/internal/services"
+	"school-erp-backend/pkg/database"
+	"github.com/gin-gonic/gin"
+)
+
+type AttendanceHandler struct {
+	attendanceRepo   *repository.AttendanceRepository
+	studentRepo      *repository.StudentRepository
+	studentLeaveRepo *repository.StudentLeaveRequestRepository
+	access           teachingAccess
+}
+
+func NewAttendanceHandler() *AttendanceHandler {
+	return &AttendanceHandler{
+		attendanceRepo:   repository.NewAttendanceRepository(database.DB),
+		studentRepo:      repository.NewStudentRepository(database.DB),
+		studentLeaveRepo: repository.NewStudentLeaveRequestRepository(database.DB),
+		access:           newTeachingAccess(),
+	}
+}
+
+// GetRoster godoc
+// @Summary Get attendance roster
+// @Description Get the students of a class/section together with any attendance already marked for the date and whether they have approved leave that day. Teachers may only open sections they teach.
+// @Tags Attendance
+// @Accept json
+// @Produce json
+// @Param class_id query int true "Class ID"
+// @Param section_id query int true "Section ID"
+// @Param date query string false "Date (YYYY-MM-DD), defaults to today"
+// @Success 200 {object} AttendanceRosterResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /teacher/attendance/roster [get]
+// @Router /admin/attendance/roster [get]
+// @Security BearerAuth
+func (h *AttendanceHandler) GetRoster(c *gin.Context) {
+	classID, sectionID, ok := parseClassSection(c)
+	if !ok {
+		return
+	}
+
+	date, err := time.Parse("2006-01-02", c.DefaultQuery("date", time.Now().Format("2006-01-02")))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date format. Use YYYY-MM-DD"})
+		return
+	}
+
+	if !h.access.allow(c, classID, sectionID, 0, academicYearOf(date)) {
+		return
+	}
+	scope, ok := requestScope(c)
+	if !ok {
+		return
+	}
+
+	students, err := h.studentRepo.Scoped(scope).FindByClassAndSection(classID, sectionID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	records, err := h.attendanceRepo.Scoped(scope).FindByClassSectionAndDate(classID, sectionID, date)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	statusByStudent := make(map[uint]string)
+	for _, record := range records {
+		statusByStudent[record.StudentID] = record.Status
+	}
+
+	studentIDs := make([]uint, 0, len(students))
+	for _, student := range students {
+		studentIDs = append(studentIDs, student.ID)
+	}
+	onLeave, err := h.studentLeaveRepo.StudentsOnLeave(studentIDs, date)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	leaveByStudent := make(map[uint]bool, len(onLeave))
+	for _, id := range onLeave {
+		leaveByStudent[id] = true
+	}
+
+	entries := make([]AttendanceRosterEntry, 0, len(students))
+	for _, student := range students {
+		entries = append(entries, AttendanceRosterEntry{
+			StudentID:       student.ID,
+			AdmissionNumber: student.AdmissionNumber,
+			FirstName:       student.FirstName,
+			LastName:        student.LastName,
+			Status:          statusByStudent[student.ID],
+			OnLeave:         leaveByStudent[student.ID],
+		})
+	}
+
+	c.JSON(http.StatusOK, AttendanceRosterResponse{
+		ClassID:   classID,
+		SectionID: sectionID,
+		Date:      date.Format("2006-01-02"),
+		Marked:    len(records) > 0,
+		Students:  entries,
+	})
+}
+
+// MarkAttendance godoc
+// @Summary Mark attendance for a section
+// @Description Submit attendance for every student of a class/section in one request. Re-submitting the same date updates the existing records. Students absent on a day of approved leave are recorded as excused. Teachers may only mark sections they teach.
+// @Tags Attendance
+// @Accept json
+// @Produce json
+// @Param attendance body MarkAttendanceRequest true "Attendance data"
+// @Success 200 {array} models.Attendance
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /teacher/attendance [post]
+// @Router /admin/attendance [post]
+// @Security BearerAuth
+func (h *AttendanceHandler) MarkAttendance(c *gin.Context) {
+	var req MarkAttendanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	date, err := time.Parse("2006-01-02", req.Date)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date format. Use YYYY-MM-DD"})
+		return
+	}
+	if date.After(time.Now()) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Attendance cannot be marked for a future date"})
+		return
+	}
+
+	if !h.access.allow(c, req.ClassID, req.SectionID, 0, academicYearOf(date)) {
+		return
+	}
+	scope, ok := requestScope(c)
+	if !ok {
+		return
+	}
+
+	students, err := h.studentRepo.Scoped(scope).FindByClassAndSection(req.ClassID, req.SectionID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	inSection := make(map[uint]bool, len(students))
+	for _, student := range students {
+		inSection[student.ID] = true
+	}
+
+	markedBy := c.GetUint("user_id")
+	seen := make(map[uint]bool, len(req.Records))
+	records := make([]models.Attendance, 0, len(req.Records))
+	for _, entry := range req.Records {
+		if !inSection[entry.StudentID] {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Student " + strconv.FormatUint(uint64(entry.StudentID), 10) + " does not belong to this class and section"})
+			return
+		}
+		if seen[entry.StudentID] {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Duplicate attendance entry for student " + strconv.FormatUint(uint64(entry.StudentID), 10)})
+			return
+		}
+		seen[entry.StudentID] = true
+
+		records = append(records, models.Attendance{
+			StudentID: entry.StudentID,
+			ClassID:   req.ClassID,
+			SectionID: req.SectionID,
+			Date:      date,
+			Status:    entry.Status,
+			MarkedBy:  markedBy,
+		})
+	}
+
+	if len(seen) != len(inSection) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Attendance must be submitted for every student in the section"})
+		return
+	}
+
+	if err := services.ExcuseOnLeave(database.DB, records); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.attendanceRepo.UpsertBulk(records); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, records)
+}
+
+// GetStudentAttendance godoc
+// @Summary Get attendance history of a student
+// @Description Get the attendance records of a student within a date range
+// @Tags Attendance
+// @Accept json
+// @Produce json
+// @Param id path int true "Student ID"
+// @Param from query string true "Start date (YYYY-MM-DD)"
+// @Param to query string true "End date (YYYY-MM-DD)"
+// @Success 200 {array} models.Attendance
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/attendance/student/{id} [get]
+// @Security BearerAuth
+func (h *AttendanceHandler) GetStudentAttendance(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid student ID"})
+		return
+	}
+
+	from, to, ok := parseDateRange(c)
+	if !ok {
+		return
+	}
+	scope, ok := requestScope(c)
+	if !ok {
+		return
+	}
+
+	records, err := h.attendanceRepo.Scoped(scope).FindByStudent(uint(id), from, to)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, records)
+}
+
+// Request Types
+type MarkAttendanceRequest struct {
+	ClassID   uint                      `json:"class_id" binding:"required"`
+	SectionID uint                      `json:"section_id" binding:"required"`
+	Date      string                    `json:"date" binding:"required"`
+	Records   []AttendanceRecordRequest `json:"records" binding:"required,min=1,dive"`
+}
+
+type AttendanceRecordRequest struct {
+	StudentID uint   `json:"student_id" binding:"required"`
+	Status    string `json:"status" binding:"required,oneof=present absent late excused"`
+}
+
+// Response Types
+type AttendanceRosterResponse struct {
+	ClassID   uint                    `json:"class_id"`
+	SectionID uint                    `json:"section_id"`
+	Date      string                  `json:"date"`
+	Marked    bool                    `json:"marked"`
+	Students  []AttendanceRosterEntry `json:"students"`
+}
+
+type AttendanceRosterEntry struct {
+	StudentID       uint   `json:"student_id"`
+	AdmissionNumber string `json:"admission_number"`
+	FirstName       string `json:"first_name"`
+	LastName        string `json:"last_name"`
+	Status          string `json:"status,omitempty"`
+	OnLeave         bool   `json:"on_leave"` // approved leave covers the date
+}
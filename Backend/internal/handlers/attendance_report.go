@@ -0,0 +1,496 @@
+package handlers
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+	"school-erp-backend/config"
+	"school-erp-backend/internal/models"
+	"school-erp-backend/internal/repository"
+	"school-erp-backend/internal/services"
+	"school-erp-backend/pkg/database"
+	"github.com/gin-gonic/gin"
+)
+
+type AttendanceReportHandler struct {
+	attendanceRepo *repository.AttendanceRepository
+	studentRepo    *repository.StudentRepository
+	sectionRepo    *repository.SectionRepository
+	access         teachingAccess
+}
+
+func NewAttendanceReportHandler() *AttendanceReportHandler {
+	return &AttendanceReportHandler{
+		attendanceRepo: repository.NewAttendanceRepository(database.DB),
+		studentRepo:    repository.NewStudentRepository(database.DB),
+		sectionRepo:    repository.NewSectionRepository(database.DB),
+		access:         newTeachingAccess(),
+	}
+}
+
+// GetStudentReport godoc
+// @Summary Get attendance report of a student
+// @Description Get attendance totals, percentage and monthly breakdown of a student over a date range
+// @Tags Attendance Reports
+// @Accept json
+// @Produce json
+// @Param id path int true "Student ID"
+// @Param from query string true "Start date (YYYY-MM-DD)"
+// @Param to query string true "End date (YYYY-MM-DD)"
+// @Success 200 {object} StudentAttendanceReport
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/attendance/reports/student/{id} [get]
+// @Router /teacher/attendance/reports/student/{id} [get]
+// @Security BearerAuth
+func (h *AttendanceReportHandler) GetStudentReport(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid student ID"})
+		return
+	}
+
+	from, to, ok := parseDateRange(c)
+	if !ok {
+		return
+	}
+	scope, ok := requestScope(c)
+	if !ok {
+		return
+	}
+
+	student, err := h.studentRepo.Scoped(scope).FindByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Student not found"})
+		return
+	}
+
+	if !h.access.allow(c, student.ClassID, student.SectionID, 0, academicYearOf(from)) {
+		return
+	}
+
+	records, err := h.attendanceRepo.Scoped(scope).FindByStudent(student.ID, from, to)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, StudentAttendanceReport{
+		StudentAttendanceRow: StudentAttendanceRow{
+			StudentID:         student.ID,
+			AdmissionNumber:   student.AdmissionNumber,
+			FirstName:         student.FirstName,
+			LastName:          student.LastName,
+			AttendanceSummary: services.SummarizeAttendance(records),
+		},
+		From:    from.Format("2006-01-02"),
+		To:      to.Format("2006-01-02"),
+		Monthly: services.MonthlyAttendanceBreakdown(records),
+	})
+}
+
+// GetSectionReport godoc
+// @Summary Get attendance report of a section
+// @Description Get the overall and per-student attendance rates of a class/section over a date range
+// @Tags Attendance Reports
+// @Accept json
+// @Produce json
+// @Param class_id query int true "Class ID"
+// @Param section_id query int true "Section ID"
+// @Param from query string true "Start date (YYYY-MM-DD)"
+// @Param to query string true "End date (YYYY-MM-DD)"
+// @Success 200 {object} SectionAttendanceReport
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/attendance/reports/section [get]
+// @Router /teacher/attendance/reports/section [get]
+// @Security BearerAuth
+func (h *AttendanceReportHandler) GetSectionReport(c *gin.Context) {
+	classID, sectionID, ok := parseClassSection(c)
+	if !ok {
+		return
+	}
+
+	from, to, ok := parseDateRange(c)
+	if !ok {
+		return
+	}
+
+	if !h.access.allow(c, classID, sectionID, 0, academicYearOf(from)) {
+		return
+	}
+	scope, ok := requestScope(c)
+	if !ok {
+		return
+	}
+
+	students, err := h.studentRepo.Scoped(scope).FindByClassAndSection(classID, sectionID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	records, err := h.attendanceRepo.Scoped(scope).FindBySectionInRange(classID, sectionID, from, to)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, SectionAttendanceReport{
+		ClassID:   classID,
+		SectionID: sectionID,
+		From:      from.Format("2006-01-02"),
+		To:        to.Format("2006-01-02"),
+		Summary:   services.SummarizeAttendance(records),
+		Monthly:   services.MonthlyAttendanceBreakdown(records),
+		Students:  studentAttendanceRows(students, services.SummarizeAttendanceByStudent(records)),
+	})
+}
+
+// GetClassReport godoc
+// @Summary Get attendance report of a class
+// @Description Get the overall and per-section attendance rates of a class over a date range
+// @Tags Attendance Reports
+// @Accept json
+// @Produce json
+// @Param id path int true "Class ID"
+// @Param from query string true "Start date (YYYY-MM-DD)"
+// @Param to query string true "End date (YYYY-MM-DD)"
+// @Success 200 {object} ClassAttendanceReport
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/attendance/reports/class/{id} [get]
+// @Security BearerAuth
+func (h *AttendanceReportHandler) GetClassReport(c *gin.Context) {
+	classID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid class ID"})
+		return
+	}
+
+	from, to, ok := parseDateRange(c)
+	if !ok {
+		return
+	}
+	scope, ok := requestScope(c)
+	if !ok {
+		return
+	}
+
+	sections, err := h.sectionRepo.Scoped(scope).FindByClassID(uint(classID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	records, err := h.attendanceRepo.Scoped(scope).FindByClassInRange(uint(classID), from, to)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	bySection := services.SummarizeAttendanceBySection(records)
+	rows := make([]SectionAttendanceRow, 0, len(sections))
+	for _, section := range sections {
+		row := SectionAttendanceRow{SectionID: section.ID, SectionName: section.Name}
+		if summary, ok := bySection[section.ID]; ok {
+			row.AttendanceSummary = *summary
+		}
+		rows = append(rows, row)
+	}
+
+	c.JSON(http.StatusOK, ClassAttendanceReport{
+		ClassID:  uint(classID),
+		From:     from.Format("2006-01-02"),
+		To:       to.Format("2006-01-02"),
+		Summary:  services.SummarizeAttendance(records),
+		Monthly:  services.MonthlyAttendanceBreakdown(records),
+		Sections: rows,
+	})
+}
+
+// GetAbsenceStreaks godoc
+// @Summary Get consecutive absence streaks
+// @Description List students of a class/section whose longest run of consecutive absences reaches min_days
+// @Tags Attendance Reports
+// @Accept json
+// @Produce json
+// @Param class_id query int true "Class ID"
+// @Param section_id query int true "Section ID"
+// @Param from query string true "Start date (YYYY-MM-DD)"
+// @Param to query string true "End date (YYYY-MM-DD)"
+// @Param min_days query int false "Minimum streak length" default(3)
+// @Success 200 {array} AbsenceStreakRow
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/attendance/reports/absence-streaks [get]
+// @Router /teacher/attendance/reports/absence-streaks [get]
+// @Security BearerAuth
+func (h *AttendanceReportHandler) GetAbsenceStreaks(c *gin.Context) {
+	classID, sectionID, ok := parseClassSection(c)
+	if !ok {
+		return
+	}
+
+	from, to, ok := parseDateRange(c)
+	if !ok {
+		return
+	}
+
+	minDays, err := strconv.Atoi(c.DefaultQuery("min_days", "3"))
+	if err != nil || minDays < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "min_days must be a positive number"})
+		return
+	}
+
+	if !h.access.allow(c, classID, sectionID, 0, academicYearOf(from)) {
+		return
+	}
+	scope, ok := requestScope(c)
+	if !ok {
+		return
+	}
+
+	students, err := h.studentRepo.Scoped(scope).FindByClassAndSection(classID, sectionID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	records, err := h.attendanceRepo.Scoped(scope).FindBySectionInRange(classID, sectionID, from, to)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	streaks := services.AbsenceStreaks(records)
+	rows := make([]AbsenceStreakRow, 0)
+	for _, student := range students {
+		streak, ok := streaks[student.ID]
+		if !ok || streak.LongestStreak < minDays {
+			continue
+		}
+		rows = append(rows, AbsenceStreakRow{
+			AdmissionNumber: student.AdmissionNumber,
+			FirstName:       student.FirstName,
+			LastName:        student.LastName,
+			AbsenceStreak:   *streak,
+		})
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		return rows[i].LongestStreak > rows[j].LongestStreak
+	})
+
+	c.JSON(http.StatusOK, rows)
+}
+
+// GetAtRiskStudents godoc
+// @Summary Get students with low attendance
+// @Description List students whose attendance percentage is below the threshold. Omit section_id to report on the whole class (admins only).
+// @Tags Attendance Reports
+// @Accept json
+// @Produce json
+// @Param class_id query int true "Class ID"
+// @Param section_id query int false "Section ID"
+// @Param from query string true "Start date (YYYY-MM-DD)"
+// @Param to query string true "End date (YYYY-MM-DD)"
+// @Param threshold query number false "Attendance percentage threshold (defaults to ATTENDANCE_THRESHOLD)"
+// @Success 200 {object} AtRiskReport
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/attendance/reports/at-risk [get]
+// @Router /teacher/attendance/reports/at-risk [get]
+// @Security BearerAuth
+func (h *AttendanceReportHandler) GetAtRiskStudents(c *gin.Context) {
+	classID, err := strconv.ParseUint(c.Query("class_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid class ID"})
+		return
+	}
+
+	from, to, ok := parseDateRange(c)
+	if !ok {
+		return
+	}
+
+	threshold := config.AppConfig.AttendanceThreshold
+	if value := c.Query("threshold"); value != "" {
+		threshold, err = strconv.ParseFloat(value, 64)
+		if err != nil || threshold <= 0 || threshold > 100 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "threshold must be between 0 and 100"})
+			return
+		}
+	}
+
+	scope, ok := requestScope(c)
+	if !ok {
+		return
+	}
+
+	var students []models.Student
+	var records []models.Attendance
+	if value := c.Query("section_id"); value != "" {
+		sectionID, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid section ID"})
+			return
+		}
+		if !h.access.allow(c, uint(classID), uint(sectionID), 0, academicYearOf(from)) {
+			return
+		}
+		students, err = h.studentRepo.Scoped(scope).FindByClassAndSection(uint(classID), uint(sectionID))
+		if err == nil {
+			records, err = h.attendanceRepo.Scoped(scope).FindBySectionInRange(uint(classID), uint(sectionID), from, to)
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	} else {
+		if c.GetString("user_role") == "teacher" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "section_id is required"})
+			return
+		}
+		students, err = h.studentRepo.Scoped(scope).FindByClass(uint(classID))
+		if err == nil {
+			records, err = h.attendanceRepo.Scoped(scope).FindByClassInRange(uint(classID), from, to)
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	atRisk := make([]StudentAttendanceRow, 0)
+	for _, row := range studentAttendanceRows(students, services.SummarizeAttendanceByStudent(records)) {
+		if row.Counted() && row.Percentage < threshold {
+			atRisk = append(atRisk, row)
+		}
+	}
+	sort.Slice(atRisk, func(i, j int) bool {
+		return atRisk[i].Percentage < atRisk[j].Percentage
+	})
+
+	c.JSON(http.StatusOK, AtRiskReport{
+		ClassID:   uint(classID),
+		From:      from.Format("2006-01-02"),
+		To:        to.Format("2006-01-02"),
+		Threshold: threshold,
+		Students:  atRisk,
+	})
+}
+
+func studentAttendanceRows(students []models.Student, summaries map[uint]*services.AttendanceSummary) []StudentAttendanceRow {
+	rows := make([]StudentAttendanceRow, 0, len(students))
+	for _, student := range students {
+		row := StudentAttendanceRow{
+			StudentID:       student.ID,
+			AdmissionNumber: student.AdmissionNumber,
+			FirstName:       student.FirstName,
+			LastName:        student.LastName,
+			SectionID:       student.SectionID,
+		}
+		if summary, ok := summaries[student.ID]; ok {
+			row.AttendanceSummary = *summary
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// parseDateRange reads the required from/to query parameters and writes a
+// 400 response when they are missing or invalid.
+func parseDateRange(c *gin.Context) (time.Time, time.Time, bool) {
+	from, err := time.Parse("2006-01-02", c.Query("from"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid from date. Use YYYY-MM-DD"})
+		return time.Time{}, time.Time{}, false
+	}
+	to, err := time.Parse("2006-01-02", c.Query("to"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid to date. Use YYYY-MM-DD"})
+		return time.Time{}, time.Time{}, false
+	}
+	if to.Before(from) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "to date must not be before from date"})
+		return time.Time{}, time.Time{}, false
+	}
+	return from, to, true
+}
+
+// parseClassSection reads the required class_id/section_id query parameters.
+func parseClassSection(c *gin.Context) (uint, uint, bool) {
+	classID, err := strconv.ParseUint(c.Query("class_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid class ID"})
+		return 0, 0, false
+	}
+	sectionID, err := strconv.ParseUint(c.Query("section_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid section ID"})
+		return 0, 0, false
+	}
+	return uint(classID), uint(sectionID), true
+}
+
+// Response Types
+type StudentAttendanceRow struct {
+	StudentID       uint   `json:"student_id"`
+	AdmissionNumber string `json:"admission_number"`
+	FirstName       string `json:"first_name"`
+	LastName        string `json:"last_name"`
+	SectionID       uint   `json:"section_id,omitempty"`
+	services.AttendanceSummary
+}
+
+type StudentAttendanceReport struct {
+	StudentAttendanceRow
+	From    string                       `json:"from"`
+	To      string                       `json:"to"`
+	Monthly []services.MonthlyAttendance `json:"monthly"`
+}
+
+type SectionAttendanceReport struct {
+	ClassID   uint                         `json:"class_id"`
+	SectionID uint                         `json:"section_id"`
+	From      string                       `json:"from"`
+	To        string                       `json:"to"`
+	Summary   services.AttendanceSummary   `json:"summary"`
+	Monthly   []services.MonthlyAttendance `json:"monthly"`
+	Students  []StudentAttendanceRow       `json:"students"`
+}
+
+type SectionAttendanceRow struct {
+	SectionID   uint   `json:"section_id"`
+	SectionName string `json:"section_name"`
+	services.AttendanceSummary
+}
+
+type ClassAttendanceReport struct {
+	ClassID  uint                         `json:"class_id"`
+	From     string                       `json:"from"`
+	To       string                       `json:"to"`
+	Summary  services.AttendanceSummary   `json:"summary"`
+	Monthly  []services.MonthlyAttendance `json:"monthly"`
+	Sections []SectionAttendanceRow       `json:"sections"`
+}
+
+type AbsenceStreakRow struct {
+	AdmissionNumber string `json:"admission_number"`
+	FirstName       string `json:"first_name"`
+	LastName        string `json:"last_name"`
+	services.AbsenceStreak
+}
+
+type AtRiskReport struct {
+	ClassID   uint                   `json:"class_id"`
+	From      string                 `json:"from"`
+	To        string                 `json:"to"`
+	Threshold float64                `json:"threshold"`
+	Students  []StudentAttendanceRow `json:"students"`
+}
@@ -1,35 +1,62 @@
 package handlers
 
 import (
+	"context"
+	"errors"
+	"log"
+	"math"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"school-erp-backend/config"
 	"school-erp-backend/internal/models"
 	"school-erp-backend/internal/repository"
+	"school-erp-backend/internal/services"
 	"school-erp-backend/pkg/database"
 	"school-erp-backend/pkg/jwt"
+	"school-erp-backend/pkg/mailer"
 	"school-erp-backend/pkg/utils"
 	"github.com/gin-gonic/gin"
 )
 
+// twoFactorChallengeExpiry is how long a login may take to give its
+// two-factor code
+const twoFactorChallengeExpiry = 5 * time.Minute
+
 type AuthHandler struct {
-	userRepo *repository.UserRepository
+	userRepo       *repository.UserRepository
+	sessionRepo    *repository.SessionRepository
+	lockoutRepo    *repository.AccountLockoutRepository
+	loginEventRepo *repository.LoginEventRepository
+	twoFactorRepo  *repository.TwoFactorRepository
+	roleRepo       *repository.RoleRepository
 }
 
 func NewAuthHandler() *AuthHandler {
 	return &AuthHandler{
-		userRepo: repository.NewUserRepository(database.DB),
+		userRepo:       repository.NewUserRepository(database.DB),
+		sessionRepo:    repository.NewSessionRepository(database.DB),
+		lockoutRepo:    repository.NewAccountLockoutRepository(database.DB),
+		loginEventRepo: repository.NewLoginEventRepository(database.DB),
+		twoFactorRepo:  repository.NewTwoFactorRepository(database.DB),
+		roleRepo:       repository.NewRoleRepository(database.DB),
 	}
 }
 
 // Login godoc
 // @Summary User login
-// @Description Authenticate user and return JWT token
+// @Description Authenticate user and return a short-lived access token with a refresh token to renew it. After a failed attempt the next one for the same email or IP address has to wait, for longer after each failure, and too many failures lock logins out for a while. Users with two-factor authentication, or with a role that requires it, get a challenge token instead, to exchange at /auth/2fa/verify or, when they still have to set it up, at /auth/2fa/enroll.
 // @Tags Authentication
 // @Accept json
 // @Produce json
 // @Param login body LoginRequest true "Login credentials"
 // @Success 200 {object} LoginResponse
+// @Success 202 {object} TwoFactorChallengeResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 429 {object} ErrorResponse
 // @Router /auth/login [post]
 func (h *AuthHandler) Login(c *gin.Context) {
 	var req LoginRequest
@@ -38,41 +65,435 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	user, err := h.userRepo.FindByEmail(req.Email)
-	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid credentials"})
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+	client := sessionClient(c)
+	now := time.Now()
+
+	if !h.checkLogin(c, nil, email, client, now) {
 		return
 	}
 
-	if !utils.CheckPasswordHash(req.Password, user.PasswordHash) {
+	user, err := h.userRepo.FindByEmail(email)
+	if err != nil || !utils.CheckPasswordHash(req.Password, user.PasswordHash) {
+		var userID *uint
+		if user != nil {
+			userID = &user.ID
+		}
+		h.loginFailed(userID, email, client, now, "invalid_credentials")
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid credentials"})
 		return
 	}
 
 	if user.Status != "active" {
+		h.recordLogin(&user.ID, email, client, false, "inactive")
 		c.JSON(http.StatusForbidden, gin.H{"error": "Account is inactive"})
 		return
 	}
 
-	token, err := jwt.GenerateToken(user.ID, user.Email, user.Role)
+	// With two-factor authentication the password only earns a challenge,
+	// and the login completes once a code is given for it
+	enabled, err := services.TwoFactorEnabled(h.twoFactorRepo, user.ID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check two-factor authentication"})
 		return
 	}
+	roles, err := services.UserRoleNames(h.roleRepo, user.ID, user.Role)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check two-factor authentication"})
+		return
+	}
+	required := services.TwoFactorRequired(roles, config.AppConfig.TwoFactorRequiredRoles)
+	if enabled || required {
+		tokenType := jwt.TokenTypeTwoFactor
+		if !enabled {
+			tokenType = jwt.TokenTypeTwoFactorEnroll
+		}
+		challenge, err := jwt.GenerateChallengeToken(user.ID, user.Email, user.Role, tokenType, twoFactorChallengeExpiry)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
+			return
+		}
 
-	c.JSON(http.StatusOK, LoginResponse{
-		Token: token,
-		User: UserResponse{
-			ID:    user.ID,
-			Email: user.Email,
-			Role:  user.Role,
+		c.JSON(http.StatusAccepted, TwoFactorChallengeResponse{
+			ChallengeToken:     challenge,
+			EnrollmentRequired: !enabled,
+			ExpiresIn:          int(twoFactorChallengeExpiry.Seconds()),
+		})
+		return
+	}
+
+	response, ok := h.completeLogin(c, user, email, client)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// VerifyTwoFactor godoc
+// @Summary Complete login with a two-factor code
+// @Description Exchange the challenge token of a login for an access token and refresh token, with a code from the authenticator app or one of the recovery codes. Wrong codes count as failed logins.
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Param verify body TwoFactorVerifyRequest true "Challenge token and code"
+// @Success 200 {object} LoginResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 429 {object} ErrorResponse
+// @Router /auth/2fa/verify [post]
+func (h *AuthHandler) VerifyTwoFactor(c *gin.Context) {
+	var req TwoFactorVerifyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, ok := h.challengeUser(c, req.ChallengeToken, jwt.TokenTypeTwoFactor)
+	if !ok {
+		return
+	}
+	email := strings.ToLower(user.Email)
+	client := sessionClient(c)
+	now := time.Now()
+	if !h.checkLogin(c, &user.ID, email, client, now) {
+		return
+	}
+
+	err := services.VerifyTwoFactor(h.twoFactorRepo, user.ID, req.Code)
+	if errors.Is(err, services.ErrInvalidTwoFactorCode) {
+		h.loginFailed(&user.ID, email, client, now, "invalid_two_factor_code")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid two-factor code"})
+		return
+	}
+	if errors.Is(err, services.ErrTwoFactorNotSetUp) {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Two-factor authentication is no longer enabled, please log in again"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to verify two-factor code"})
+		return
+	}
+
+	response, ok := h.completeLogin(c, user, email, client)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// EnrollTwoFactor godoc
+// @Summary Set up two-factor authentication during login
+// @Description For users whose role requires two-factor authentication but who have not set it up yet. Exchanges the enrollment challenge of a login for a new TOTP secret and its otpauth:// URI, to be shown as a QR code.
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Param enroll body TwoFactorChallengeRequest true "Enrollment challenge token"
+// @Success 200 {object} TwoFactorSetupResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /auth/2fa/enroll [post]
+func (h *AuthHandler) EnrollTwoFactor(c *gin.Context) {
+	var req TwoFactorChallengeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, ok := h.challengeUser(c, req.ChallengeToken, jwt.TokenTypeTwoFactorEnroll)
+	if !ok {
+		return
+	}
+
+	setup, err := services.SetUpTwoFactor(h.twoFactorRepo, user, config.AppConfig.SchoolName)
+	if errors.Is(err, services.ErrTwoFactorAlreadyEnabled) {
+		c.JSON(http.StatusConflict, gin.H{"error": "Two-factor authentication is already enabled"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to set up two-factor authentication"})
+		return
+	}
+
+	c.JSON(http.StatusOK, newTwoFactorSetupResponse(setup))
+}
+
+// ConfirmTwoFactorEnrollment godoc
+// @Summary Confirm two-factor setup and complete login
+// @Description Enable the secret from the enrollment step with a code from the authenticator app and complete the login. The response carries recovery codes, which are shown only this once.
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Param confirm body TwoFactorVerifyRequest true "Enrollment challenge token and code"
+// @Success 200 {object} TwoFactorEnrollmentResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 429 {object} ErrorResponse
+// @Router /auth/2fa/enroll/confirm [post]
+func (h *AuthHandler) ConfirmTwoFactorEnrollment(c *gin.Context) {
+	var req TwoFactorVerifyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, ok := h.challengeUser(c, req.ChallengeToken, jwt.TokenTypeTwoFactorEnroll)
+	if !ok {
+		return
+	}
+	email := strings.ToLower(user.Email)
+	client := sessionClient(c)
+	now := time.Now()
+	if !h.checkLogin(c, &user.ID, email, client, now) {
+		return
+	}
+
+	codes, err := services.EnableTwoFactor(database.DB, user.ID, req.Code)
+	switch {
+	case errors.Is(err, services.ErrInvalidTwoFactorCode):
+		h.loginFailed(&user.ID, email, client, now, "invalid_two_factor_code")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid two-factor code"})
+		return
+	case errors.Is(err, services.ErrTwoFactorNotSetUp):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Set up two-factor authentication first"})
+		return
+	case errors.Is(err, services.ErrTwoFactorAlreadyEnabled):
+		c.JSON(http.StatusConflict, gin.H{"error": "Two-factor authentication is already enabled"})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to enable two-factor authentication"})
+		return
+	}
+
+	response, ok := h.completeLogin(c, user, email, client)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, TwoFactorEnrollmentResponse{
+		LoginResponse: response,
+		RecoveryCodes: codes,
+	})
+}
+
+// Me godoc
+// @Summary Get current user
+// @Description Get the logged in user with their roles and the permissions these add up to, so the frontend can show only what the user may use
+// @Tags Authentication
+// @Produce json
+// @Success 200 {object} MeResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /auth/me [get]
+// @Security BearerAuth
+func (h *AuthHandler) Me(c *gin.Context) {
+	user, err := h.userRepo.FindByID(c.GetUint("user_id"))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		return
+	}
+
+	roles, err := services.UserRoleNames(h.roleRepo, user.ID, user.Role)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load roles"})
+		return
+	}
+	permissions, err := services.RolePermissions(h.roleRepo, roles)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load permissions"})
+		return
+	}
+
+	c.JSON(http.StatusOK, MeResponse{
+		UserResponse: UserResponse{
+			ID:     user.ID,
+			Email:  user.Email,
+			Role:   user.Role,
+			Status: user.Status,
 		},
+		Roles:       roles,
+		Permissions: permissions,
 	})
 }
 
+// Refresh godoc
+// @Summary Refresh access token
+// @Description Exchange a refresh token for a new access token and refresh token. Each refresh token can be used once; presenting a used one again revokes every session of that login.
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Param refresh body RefreshRequest true "Refresh token"
+// @Success 200 {object} TokenResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /auth/refresh [post]
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	var req RefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tokens, err := services.RefreshSession(h.sessionRepo, h.userRepo, req.RefreshToken, sessionClient(c), refreshTokenExpiry())
+	switch {
+	case errors.Is(err, services.ErrInvalidRefreshToken):
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired refresh token"})
+		return
+	case errors.Is(err, services.ErrRefreshTokenReused):
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Refresh token has already been used. The session has been revoked, please log in again"})
+		return
+	case errors.Is(err, services.ErrAccountInactive):
+		c.JSON(http.StatusForbidden, gin.H{"error": "Account is inactive"})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to refresh token"})
+		return
+	}
+
+	c.JSON(http.StatusOK, newTokenResponse(tokens))
+}
+
+// Logout godoc
+// @Summary Log out
+// @Description Revoke the current session, including its refresh token and every access token issued for it
+// @Tags Authentication
+// @Produce json
+// @Success 200 {object} SuccessResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /auth/logout [post]
+// @Security BearerAuth
+func (h *AuthHandler) Logout(c *gin.Context) {
+	if err := services.EndSession(h.sessionRepo, c.GetUint("session_id")); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to revoke session"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Logged out successfully"})
+}
+
+// ChangePassword godoc
+// @Summary Change own password
+// @Description Change the password of the logged in user, who must give the current one. Every other session of the user is signed out.
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Param password body ChangePasswordRequest true "Current and new password"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /auth/change-password [post]
+// @Security BearerAuth
+func (h *AuthHandler) ChangePassword(c *gin.Context) {
+	var req ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := h.userRepo.FindByID(c.GetUint("user_id"))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		return
+	}
+
+	err = services.ChangePassword(database.DB, user, c.GetUint("session_id"), req.CurrentPassword, req.NewPassword)
+	if errors.Is(err, services.ErrWrongPassword) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Current password is incorrect"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to change password"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Password changed successfully"})
+}
+
+// ForgotPassword godoc
+// @Summary Request a password reset
+// @Description Mail a single-use password reset link to the given address. Requests are limited per email and per IP address. The response is the same whether or not an account with that email exists and whether or not the request was throttled.
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Param request body ForgotPasswordRequest true "Account email"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /auth/forgot-password [post]
+func (h *AuthHandler) ForgotPassword(c *gin.Context) {
+	var req ForgotPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Handled in the background, so neither the response nor its timing
+	// tells whether the account exists or the request was throttled
+	options := services.PasswordResetOptions{
+		URL:    config.AppConfig.PasswordResetURL,
+		Expiry: time.Duration(config.AppConfig.PasswordResetExpiry) * time.Minute,
+	}
+	limit := services.PasswordResetLimit{
+		MaxPerEmail: config.AppConfig.PasswordResetMaxPerEmail,
+		MaxPerIP:    config.AppConfig.PasswordResetMaxPerIP,
+		Window:      time.Duration(config.AppConfig.PasswordResetWindow) * time.Minute,
+	}
+	go func(email, ip string) {
+		allowed, err := services.AllowPasswordReset(h.lockoutRepo, limit, email, ip, time.Now())
+		if err != nil {
+			log.Printf("Failed to count password reset request: %v", err)
+			return
+		}
+		if !allowed {
+			log.Printf("Password reset request throttled for %s from %s", email, ip)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		if err := services.RequestPasswordReset(ctx, database.DB, mailer.Mail, email, options); err != nil {
+			log.Printf("Password reset request failed: %v", err)
+		}
+	}(strings.ToLower(strings.TrimSpace(req.Email)), c.ClientIP())
+
+	c.JSON(http.StatusOK, gin.H{"message": "If an account with that email exists, a password reset link has been sent"})
+}
+
+// ResetPassword godoc
+// @Summary Reset password
+// @Description Set a new password with the token of a password reset link. The token works once and the user is signed out of every session.
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Param reset body ResetPasswordRequest true "Reset token and new password"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /auth/reset-password [post]
+func (h *AuthHandler) ResetPassword(c *gin.Context) {
+	var req ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err := services.ResetPassword(database.DB, req.Token, req.NewPassword)
+	if errors.Is(err, services.ErrInvalidResetToken) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid or expired password reset token"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reset password"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Password reset successfully"})
+}
+
 // Register godoc
 // @Summary Register new user
-// @Description Register a new user (Admin only)
+// @Description Register a new user. Requires the users.write permission, and only roles whose permissions the current user holds can be given.
 // @Tags Authentication
 // @Accept json
 // @Produce json
@@ -80,6 +501,7 @@ func (h *AuthHandler) Login(c *gin.Context) {
 // @Success 201 {object} UserResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
 // @Router /auth/register [post]
 // @Security BearerAuth
 func (h *AuthHandler) Register(c *gin.Context) {
@@ -89,6 +511,11 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		return
 	}
 
+	role := strings.ToLower(strings.TrimSpace(req.Role))
+	if !checkRoleGrant(c, h.roleRepo, role) {
+		return
+	}
+
 	passwordHash, err := utils.HashPassword(req.Password)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to hash password"})
@@ -98,7 +525,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 	user := &models.User{
 		Email:        req.Email,
 		PasswordHash: passwordHash,
-		Role:         req.Role,
+		Role:         role,
 		Status:       "active",
 	}
 
@@ -114,22 +541,184 @@ func (h *AuthHandler) Register(c *gin.Context) {
 	})
 }
 
+// checkLogin responds with 429 and returns false while logins for the email
+// or from the IP address have to wait after failed attempts.
+func (h *AuthHandler) checkLogin(c *gin.Context, userID *uint, email string, client services.SessionClient, now time.Time) bool {
+	err := services.CheckLogin(h.lockoutRepo, loginPolicy(), email, client.IPAddress, now)
+	var throttled *services.LoginThrottledError
+	if errors.As(err, &throttled) {
+		reason := "throttled"
+		if throttled.Locked {
+			reason = "locked"
+		}
+		h.recordLogin(userID, email, client, false, reason)
+		respondLoginThrottled(c, throttled)
+		return false
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check login attempts"})
+		return false
+	}
+	return true
+}
+
+// loginFailed records a failed login and counts it towards a lockout
+func (h *AuthHandler) loginFailed(userID *uint, email string, client services.SessionClient, now time.Time, reason string) {
+	h.recordLogin(userID, email, client, false, reason)
+	if err := services.RecordLoginFailure(h.lockoutRepo, loginPolicy(), email, client.IPAddress, now); err != nil {
+		log.Printf("Failed to record login failure: %v", err)
+	}
+}
+
+// completeLogin records a successful login and starts a session for it
+func (h *AuthHandler) completeLogin(c *gin.Context, user *models.User, email string, client services.SessionClient) (LoginResponse, bool) {
+	h.recordLogin(&user.ID, email, client, true, "")
+	if err := services.RecordLoginSuccess(h.lockoutRepo, email); err != nil {
+		log.Printf("Failed to clear login failures: %v", err)
+	}
+
+	tokens, err := services.StartSession(h.sessionRepo, user, client, refreshTokenExpiry())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
+		return LoginResponse{}, false
+	}
+
+	return LoginResponse{
+		TokenResponse: newTokenResponse(tokens),
+		User: UserResponse{
+			ID:    user.ID,
+			Email: user.Email,
+			Role:  user.Role,
+		},
+	}, true
+}
+
+// challengeUser returns the user a two-factor challenge token of the given
+// type was issued to, responding with 401 when it is invalid or the account
+// is no longer active.
+func (h *AuthHandler) challengeUser(c *gin.Context, challengeToken, tokenType string) (*models.User, bool) {
+	claims, err := jwt.ValidateToken(challengeToken)
+	if err != nil || claims.Type != tokenType {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired challenge token, please log in again"})
+		return nil, false
+	}
+
+	user, err := h.userRepo.FindByID(claims.UserID)
+	if err != nil || user.Status != "active" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired challenge token, please log in again"})
+		return nil, false
+	}
+	return user, true
+}
+
+// recordLogin keeps a login attempt for security review. A failure to write
+// it is logged rather than failing the login.
+func (h *AuthHandler) recordLogin(userID *uint, email string, client services.SessionClient, success bool, reason string) {
+	err := h.loginEventRepo.Create(&models.LoginEvent{
+		UserID:    userID,
+		Email:     email,
+		IPAddress: client.IPAddress,
+		UserAgent: client.UserAgent,
+		Success:   success,
+		Reason:    reason,
+	})
+	if err != nil {
+		log.Printf("Failed to record login event: %v", err)
+	}
+}
+
+func loginPolicy() services.LoginPolicy {
+	return services.LoginPolicy{
+		MaxFailures:      config.AppConfig.LoginMaxFailures,
+		MaxFailuresPerIP: config.AppConfig.LoginMaxFailuresPerIP,
+		LockoutDuration:  time.Duration(config.AppConfig.LoginLockoutMinutes) * time.Minute,
+	}
+}
+
+func respondLoginThrottled(c *gin.Context, throttled *services.LoginThrottledError) {
+	c.Header("Retry-After", strconv.Itoa(int(math.Ceil(throttled.RetryAfter.Seconds()))))
+	c.JSON(http.StatusTooManyRequests, gin.H{"error": "Too many failed login attempts. Try again later"})
+}
+
+func refreshTokenExpiry() time.Duration {
+	return time.Duration(config.AppConfig.RefreshTokenExpiry) * time.Hour
+}
+
+func sessionClient(c *gin.Context) services.SessionClient {
+	return services.SessionClient{
+		UserAgent: c.Request.UserAgent(),
+		IPAddress: c.ClientIP(),
+	}
+}
+
+func newTokenResponse(tokens *services.TokenPair) TokenResponse {
+	return TokenResponse{
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresIn:    config.AppConfig.AccessTokenExpiry * 60,
+	}
+}
+
 // Request Types
 type LoginRequest struct {
 	Email    string `json:"email" binding:"required,email"`
 	Password string `json:"password" binding:"required"`
 }
 
+type TwoFactorChallengeRequest struct {
+	ChallengeToken string `json:"challenge_token" binding:"required"`
+}
+
+type TwoFactorVerifyRequest struct {
+	ChallengeToken string `json:"challenge_token" binding:"required"`
+	Code           string `json:"code" binding:"required"` // TOTP code or recovery code
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required,min=6"`
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type ResetPasswordRequest struct {
+	Token       string `json:"token" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required,min=6"`
+}
+
 type RegisterRequest struct {
 	Email    string `json:"email" binding:"required,email"`
 	Password string `json:"password" binding:"required,min=6"`
-	Role     string `json:"role" binding:"required,oneof=admin teacher student"`
+	Role     string `json:"role" binding:"required"`
 }
 
 // Response Types
 type LoginResponse struct {
-	Token string      `json:"token"`
-	User  UserResponse `json:"user"`
+	TokenResponse
+	User UserResponse `json:"user"`
+}
+
+type TwoFactorChallengeResponse struct {
+	ChallengeToken     string `json:"challenge_token"`
+	EnrollmentRequired bool   `json:"enrollment_required"` // two-factor authentication has to be set up before logging in
+	ExpiresIn          int    `json:"expires_in"`          // seconds until the challenge token expires
+}
+
+type TwoFactorEnrollmentResponse struct {
+	LoginResponse
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type TokenResponse struct {
+	Token        string `json:"token"` // access token
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"` // seconds until the access token expires
 }
 
 type UserResponse struct {
@@ -139,6 +728,12 @@ type UserResponse struct {
 	Status string `json:"status,omitempty"`
 }
 
+type MeResponse struct {
+	UserResponse
+	Roles       []string `json:"roles"` // primary role first
+	Permissions []string `json:"permissions"`
+}
+
 type ErrorResponse struct {
 	Error string `json:"error"`
 }
@@ -0,0 +1,481 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"school-erp-backend/config"
+	"school-erp-backend/internal/models"
+	"school-erp-backend/internal/repository"
+	"school-erp-backend/internal/services"
+	"school-erp-backend/pkg/database"
+	"school-erp-backend/pkg/utils"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// maxCalendarRangeDays bounds the ranges the calendar is expanded over
+const maxCalendarRangeDays = 366
+
+type CalendarHandler struct {
+	eventRepo *repository.CalendarEventRepository
+	feedRepo  *repository.CalendarFeedTokenRepository
+	userRepo  *repository.UserRepository
+	roleRepo  *repository.RoleRepository
+	school    *services.SchoolCalendar
+}
+
+func NewCalendarHandler() *CalendarHandler {
+	return &CalendarHandler{
+		eventRepo: repository.NewCalendarEventRepository(database.DB),
+		feedRepo:  repository.NewCalendarFeedTokenRepository(database.DB),
+		userRepo:  repository.NewUserRepository(database.DB),
+		roleRepo:  repository.NewRoleRepository(database.DB),
+		school:    services.NewSchoolCalendar(database.DB, config.AppConfig.WeeklyOffDays),
+	}
+}
+
+// GetEvents godoc
+// @Summary Get calendar events
+// @Description Get calendar events of every visibility as stored, repeating events once. from and to keep the events with an occurrence in that range.
+// @Tags Admin - Calendar
+// @Produce json
+// @Param event_type query string false "Filter by event type (holiday, exam, event, meeting)"
+// @Param visibility query string false "Filter by visibility (all, admin, teacher, student, parent)"
+// @Param from query string false "Start date (YYYY-MM-DD)"
+// @Param to query string false "End date (YYYY-MM-DD)"
+// @Success 200 {array} models.CalendarEvent
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/calendar/events [get]
+// @Security BearerAuth
+func (h *CalendarHandler) GetEvents(c *gin.Context) {
+	filter := models.CalendarEvent{
+		EventType:  c.Query("event_type"),
+		Visibility: c.Query("visibility"),
+	}
+
+	var from, to time.Time
+	if value := c.Query("from"); value != "" {
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid from date. Use YYYY-MM-DD"})
+			return
+		}
+		from = parsed
+	}
+	if value := c.Query("to"); value != "" {
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid to date. Use YYYY-MM-DD"})
+			return
+		}
+		to = parsed.AddDate(0, 0, 1)
+	}
+
+	events, err := h.eventRepo.FindAll(filter, nil, from, to)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, events)
+}
+
+// GetEvent godoc
+// @Summary Get calendar event by ID
+// @Description Get a calendar event
+// @Tags Admin - Calendar
+// @Produce json
+// @Param id path int true "Event ID"
+// @Success 200 {object} models.CalendarEvent
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/calendar/events/{id} [get]
+// @Security BearerAuth
+func (h *CalendarHandler) GetEvent(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	event, err := h.eventRepo.FindByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Event not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, event)
+}
+
+// CreateEvent godoc
+// @Summary Create a calendar event
+// @Description Create an all-day event (dates as YYYY-MM-DD) or a timed one (RFC 3339 times), optionally repeating by an RRULE such as FREQ=WEEKLY;BYDAY=MO,WE;UNTIL=20270331. Holidays close the school on their days.
+// @Tags Admin - Calendar
+// @Accept json
+// @Produce json
+// @Param request body CalendarEventRequest true "Event details"
+// @Success 201 {object} models.CalendarEvent
+// @Failure 400 {object} ErrorResponse
+// @Router /admin/calendar/events [post]
+// @Security BearerAuth
+func (h *CalendarHandler) CreateEvent(c *gin.Context) {
+	var req CalendarEventRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	event := models.CalendarEvent{CreatedBy: c.GetUint("user_id")}
+	if err := req.apply(&event); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.eventRepo.Create(&event); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, event)
+}
+
+// UpdateEvent godoc
+// @Summary Update a calendar event
+// @Description Replace the details, dates and recurrence of a calendar event
+// @Tags Admin - Calendar
+// @Accept json
+// @Produce json
+// @Param id path int true "Event ID"
+// @Param request body CalendarEventRequest true "Event details"
+// @Success 200 {object} models.CalendarEvent
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/calendar/events/{id} [put]
+// @Security BearerAuth
+func (h *CalendarHandler) UpdateEvent(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	event, err := h.eventRepo.FindByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Event not found"})
+		return
+	}
+
+	var req CalendarEventRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := req.apply(event); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.eventRepo.Update(event); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, event)
+}
+
+// DeleteEvent godoc
+// @Summary Delete a calendar event
+// @Description Delete a calendar event with all its occurrences
+// @Tags Admin - Calendar
+// @Produce json
+// @Param id path int true "Event ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/calendar/events/{id} [delete]
+// @Security BearerAuth
+func (h *CalendarHandler) DeleteEvent(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	if _, err := h.eventRepo.FindByID(uint(id)); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Event not found"})
+		return
+	}
+
+	if err := h.eventRepo.Delete(uint(id)); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Event deleted successfully"})
+}
+
+// GetCalendar godoc
+// @Summary Get own calendar
+// @Description Get the occurrences of the events shown to the roles of the logged in user between two dates, e.g. a month or a week, with repeating events expanded
+// @Tags Calendar
+// @Produce json
+// @Param from query string true "Start date (YYYY-MM-DD)"
+// @Param to query string true "End date (YYYY-MM-DD), at most a year after from"
+// @Param event_type query string false "Filter by event type (holiday, exam, event, meeting)"
+// @Success 200 {array} services.CalendarOccurrence
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /calendar [get]
+// @Security BearerAuth
+func (h *CalendarHandler) GetCalendar(c *gin.Context) {
+	from, to, ok := parseCalendarRange(c)
+	if !ok {
+		return
+	}
+
+	visibilities, err := h.visibilities(c.GetUint("user_id"), c.GetString("user_role"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	filter := models.CalendarEvent{EventType: c.Query("event_type")}
+	events, err := h.eventRepo.FindAll(filter, visibilities, from, to.AddDate(0, 0, 1))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, services.EventOccurrences(events, from, to))
+}
+
+// GetSchoolDays godoc
+// @Summary Get school days
+// @Description Tell for every date between two dates whether the school is open, or closed for the weekly off day or a holiday
+// @Tags Calendar
+// @Produce json
+// @Param from query string true "Start date (YYYY-MM-DD)"
+// @Param to query string true "End date (YYYY-MM-DD), at most a year after from"
+// @Success 200 {array} services.SchoolDay
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /calendar/school-days [get]
+// @Security BearerAuth
+func (h *CalendarHandler) GetSchoolDays(c *gin.Context) {
+	from, to, ok := parseCalendarRange(c)
+	if !ok {
+		return
+	}
+
+	days, err := h.school.Days(from, to)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, days)
+}
+
+// GetFeed godoc
+// @Summary Get own calendar feed status
+// @Description Tell whether the logged in user has an iCalendar feed URL. The URL itself is only shown when it is created.
+// @Tags Calendar
+// @Produce json
+// @Success 200 {object} CalendarFeedResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /calendar/feed [get]
+// @Security BearerAuth
+func (h *CalendarHandler) GetFeed(c *gin.Context) {
+	token, err := h.feedRepo.FindByUser(c.GetUint("user_id"))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusOK, CalendarFeedResponse{})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, CalendarFeedResponse{Active: true, CreatedAt: &token.CreatedAt, LastUsedAt: token.LastUsedAt})
+}
+
+// CreateFeed godoc
+// @Summary Create own calendar feed URL
+// @Description Create a secret iCalendar feed URL of the calendar of the logged in user, for subscribing from calendar apps. A URL created before stops working.
+// @Tags Calendar
+// @Produce json
+// @Success 201 {object} CalendarFeedResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /calendar/feed [post]
+// @Security BearerAuth
+func (h *CalendarHandler) CreateFeed(c *gin.Context) {
+	secret, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	token := models.CalendarFeedToken{UserID: c.GetUint("user_id"), Token: utils.HashToken(secret)}
+	if err := h.feedRepo.Replace(&token); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, CalendarFeedResponse{
+		Active:    true,
+		URL:       strings.TrimRight(config.AppConfig.PublicURL, "/") + "/api/calendar/ics/" + secret + ".ics",
+		CreatedAt: &token.CreatedAt,
+	})
+}
+
+// DeleteFeed godoc
+// @Summary Delete own calendar feed URL
+// @Description Stop the iCalendar feed URL of the logged in user from working
+// @Tags Calendar
+// @Produce json
+// @Success 200 {object} SuccessResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /calendar/feed [delete]
+// @Security BearerAuth
+func (h *CalendarHandler) DeleteFeed(c *gin.Context) {
+	if err := h.feedRepo.DeleteByUser(c.GetUint("user_id")); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Calendar feed deleted successfully"})
+}
+
+// GetICS godoc
+// @Summary Get iCalendar feed
+// @Description Get the calendar of the owner of a feed token as an iCalendar file, from a year ago on. The token in the URL is the only authentication.
+// @Tags Calendar
+// @Produce text/calendar
+// @Param token path string true "Feed token, optionally followed by .ics"
+// @Success 200 {string} string "iCalendar file"
+// @Failure 404 {object} ErrorResponse
+// @Router /calendar/ics/{token} [get]
+func (h *CalendarHandler) GetICS(c *gin.Context) {
+	secret := strings.TrimSuffix(c.Param("token"), ".ics")
+	token, err := h.feedRepo.FindByToken(utils.HashToken(secret))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Calendar feed not found"})
+		return
+	}
+	user, err := h.userRepo.FindByID(token.UserID)
+	if err != nil || user.Status != "active" {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Calendar feed not found"})
+		return
+	}
+
+	visibilities, err := h.visibilities(user.ID, user.Role)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	now := time.Now()
+	events, err := h.eventRepo.FindAll(models.CalendarEvent{}, visibilities, now.AddDate(-1, 0, 0), time.Time{})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if err := h.feedRepo.MarkUsed(token.ID, now); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Content-Disposition", `inline; filename="calendar.ics"`)
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", services.CalendarICS(config.AppConfig.SchoolName, events, now))
+}
+
+// visibilities returns the event visibilities shown to a user through any of
+// their roles, nil meaning all.
+func (h *CalendarHandler) visibilities(userID uint, primaryRole string) ([]string, error) {
+	roles, err := services.UserRoleNames(h.roleRepo, userID, primaryRole)
+	if err != nil {
+		return nil, err
+	}
+	return services.VisibleCalendar(roles), nil
+}
+
+// parseCalendarRange reads the required from/to dates, at most a year apart
+func parseCalendarRange(c *gin.Context) (time.Time, time.Time, bool) {
+	from, to, ok := parseDateRange(c)
+	if !ok {
+		return from, to, false
+	}
+	if to.Sub(from) > maxCalendarRangeDays*24*time.Hour {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Date range must not be longer than a year"})
+		return from, to, false
+	}
+	return from, to, true
+}
+
+// parseEventTime reads a date of an all-day event or an RFC 3339 time of a
+// timed one, and reports which it was.
+func parseEventTime(value string) (time.Time, bool, error) {
+	if date, err := time.Parse("2006-01-02", value); err == nil {
+		return date, true, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false, errors.New("Invalid event date. Use YYYY-MM-DD or RFC 3339")
+	}
+	return t, false, nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Request Types
+type CalendarEventRequest struct {
+	Title       string `json:"title" binding:"required"`
+	Description string `json:"description"`
+	StartDate   string `json:"start_date" binding:"required"` // YYYY-MM-DD for all-day events, RFC 3339 otherwise
+	EndDate     string `json:"end_date"`                      // same form as start_date, defaults to it
+	EventType   string `json:"event_type" binding:"required"` // holiday, exam, event, meeting
+	Visibility  string `json:"visibility"`                    // all (default), admin, teacher, student, parent
+	Recurrence  string `json:"recurrence"`                    // RRULE, e.g. FREQ=YEARLY or FREQ=WEEKLY;BYDAY=FR;COUNT=10
+}
+
+func (req *CalendarEventRequest) apply(event *models.CalendarEvent) error {
+	if !containsString(services.CalendarEventTypes, req.EventType) {
+		return errors.New("event_type must be one of " + strings.Join(services.CalendarEventTypes, ", "))
+	}
+	visibility := req.Visibility
+	if visibility == "" {
+		visibility = "all"
+	}
+	if !containsString(services.CalendarVisibilities, visibility) {
+		return errors.New("visibility must be one of " + strings.Join(services.CalendarVisibilities, ", "))
+	}
+
+	start, allDay, err := parseEventTime(req.StartDate)
+	if err != nil {
+		return err
+	}
+	end := start
+	if req.EndDate != "" {
+		var endAllDay bool
+		end, endAllDay, err = parseEventTime(req.EndDate)
+		if err != nil {
+			return err
+		}
+		if endAllDay != allDay {
+			return errors.New("start_date and end_date must both be dates or both be times")
+		}
+	}
+
+	event.Title = req.Title
+	event.Description = req.Description
+	event.StartDate = start
+	event.EndDate = end
+	event.AllDay = allDay
+	event.EventType = req.EventType
+	event.Visibility = visibility
+	event.Recurrence = req.Recurrence
+	return services.PrepareCalendarEvent(event)
+}
+
+// Response Types
+type CalendarFeedResponse struct {
+	Active     bool       `json:"active"`
+	URL        string     `json:"url,omitempty"` // only when created
+	CreatedAt  *time.Time `json:"created_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+}
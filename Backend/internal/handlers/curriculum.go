@@ -0,0 +1,352 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"school-erp-backend/config"
+	"school-erp-backend/internal/models"
+	"school-erp-backend/internal/repository"
+	"school-erp-backend/internal/services"
+	"school-erp-backend/pkg/database"
+	"github.com/gin-gonic/gin"
+)
+
+type CurriculumHandler struct {
+	curriculumRepo *repository.CurriculumRepository
+	classRepo      *repository.ClassRepository
+	subjectRepo    *repository.SubjectRepository
+	studentRepo    *repository.StudentRepository
+	electiveRepo   *repository.ElectiveRepository
+}
+
+func NewCurriculumHandler() *CurriculumHandler {
+	return &CurriculumHandler{
+		curriculumRepo: repository.NewCurriculumRepository(database.DB),
+		classRepo:      repository.NewClassRepository(database.DB),
+		subjectRepo:    repository.NewSubjectRepository(database.DB),
+		studentRepo:    repository.NewStudentRepository(database.DB),
+		electiveRepo:   repository.NewElectiveRepository(database.DB),
+	}
+}
+
+// GetCurriculum godoc
+// @Summary Get the curriculum of a class
+// @Description Get the subjects of a class for an academic year (defaults to the current one), core subjects before electives
+// @Tags Curriculum
+// @Accept json
+// @Produce json
+// @Param class_id query int true "Class ID"
+// @Param academic_year query string false "Academic year (e.g. 2024-2025)"
+// @Success 200 {object} CurriculumResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/curriculum [get]
+// @Router /teacher/curriculum [get]
+// @Security BearerAuth
+func (h *CurriculumHandler) GetCurriculum(c *gin.Context) {
+	classID, err := strconv.ParseUint(c.Query("class_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid class ID"})
+		return
+	}
+
+	h.curriculum(c, uint(classID), academicYearOrCurrent(c), nil)
+}
+
+// GetMySubjects godoc
+// @Summary Get own subjects
+// @Description Get the core subjects of the logged in student's class and the electives the student is enrolled in, for an academic year (defaults to the current one)
+// @Tags Curriculum
+// @Accept json
+// @Produce json
+// @Param academic_year query string false "Academic year (e.g. 2024-2025)"
+// @Success 200 {object} CurriculumResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /student/subjects [get]
+// @Security BearerAuth
+func (h *CurriculumHandler) GetMySubjects(c *gin.Context) {
+	student, err := h.studentRepo.FindByUserID(c.GetUint("user_id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Student profile not found"})
+		return
+	}
+
+	academicYear := academicYearOrCurrent(c)
+	electives, err := h.electiveRepo.SubjectIDs(student.ID, academicYear)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	enrolled := make(map[uint]bool, len(electives))
+	for _, subjectID := range electives {
+		enrolled[subjectID] = true
+	}
+
+	h.curriculum(c, student.ClassID, academicYear, func(entry models.CurriculumSubject) bool {
+		return !entry.IsElective || enrolled[entry.SubjectID]
+	})
+}
+
+// GetCurriculumSubject godoc
+// @Summary Get curriculum entry by ID
+// @Description Get a specific curriculum entry by ID
+// @Tags Admin - Curriculum
+// @Accept json
+// @Produce json
+// @Param id path int true "Curriculum entry ID"
+// @Success 200 {object} models.CurriculumSubject
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/curriculum/{id} [get]
+// @Security BearerAuth
+func (h *CurriculumHandler) GetCurriculumSubject(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	entry, err := h.curriculumRepo.FindByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Curriculum entry not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
+
+// CreateCurriculumSubject godoc
+// @Summary Add a subject to a curriculum
+// @Description Add a subject to the curriculum of a class for an academic year
+// @Tags Admin - Curriculum
+// @Accept json
+// @Produce json
+// @Param entry body CurriculumSubjectRequest true "Curriculum entry data"
+// @Success 201 {object} models.CurriculumSubject
+// @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /admin/curriculum [post]
+// @Security BearerAuth
+func (h *CurriculumHandler) CreateCurriculumSubject(c *gin.Context) {
+	var req CurriculumSubjectRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	entry := &models.CurriculumSubject{}
+	req.apply(entry)
+
+	if !h.checkEntry(c, entry) {
+		return
+	}
+
+	if err := h.curriculumRepo.Create(entry); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, entry)
+}
+
+// UpdateCurriculumSubject godoc
+// @Summary Update curriculum entry
+// @Description Replace a curriculum entry
+// @Tags Admin - Curriculum
+// @Accept json
+// @Produce json
+// @Param id path int true "Curriculum entry ID"
+// @Param entry body CurriculumSubjectRequest true "Curriculum entry data"
+// @Success 200 {object} models.CurriculumSubject
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /admin/curriculum/{id} [put]
+// @Security BearerAuth
+func (h *CurriculumHandler) UpdateCurriculumSubject(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	var req CurriculumSubjectRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	entry, err := h.curriculumRepo.FindByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Curriculum entry not found"})
+		return
+	}
+	req.apply(entry)
+
+	if !h.checkEntry(c, entry) {
+		return
+	}
+
+	if err := h.curriculumRepo.Update(entry); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
+
+// DeleteCurriculumSubject godoc
+// @Summary Remove a subject from a curriculum
+// @Description Delete a curriculum entry
+// @Tags Admin - Curriculum
+// @Accept json
+// @Produce json
+// @Param id path int true "Curriculum entry ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/curriculum/{id} [delete]
+// @Security BearerAuth
+func (h *CurriculumHandler) DeleteCurriculumSubject(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	if _, err := h.curriculumRepo.FindByID(uint(id)); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Curriculum entry not found"})
+		return
+	}
+
+	if err := h.curriculumRepo.Delete(uint(id)); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Curriculum entry deleted successfully"})
+}
+
+// CopyCurriculum godoc
+// @Summary Copy a curriculum to another academic year
+// @Description Copy the curriculum of every class, or of one class, from one academic year to another. Subjects already in the target year are left unchanged.
+// @Tags Admin - Curriculum
+// @Accept json
+// @Produce json
+// @Param copy body CopyCurriculumRequest true "Copy options"
+// @Success 200 {object} CurriculumCopyResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/curriculum/copy [post]
+// @Security BearerAuth
+func (h *CurriculumHandler) CopyCurriculum(c *gin.Context) {
+	var req CopyCurriculumRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	for _, academicYear := range []string{req.FromAcademicYear, req.ToAcademicYear} {
+		if _, _, err := services.AcademicYearRange(academicYear, config.AppConfig.AcademicYearStartMonth); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid academic year. Use e.g. 2024-2025"})
+			return
+		}
+	}
+	if req.FromAcademicYear == req.ToAcademicYear {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Source and target academic years must differ"})
+		return
+	}
+
+	copied, skipped, err := services.CopyCurriculum(h.curriculumRepo, req.FromAcademicYear, req.ToAcademicYear, req.ClassID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, CurriculumCopyResponse{
+		FromAcademicYear: req.FromAcademicYear,
+		ToAcademicYear:   req.ToAcademicYear,
+		Copied:           copied,
+		Skipped:          skipped,
+	})
+}
+
+// curriculum responds with the curriculum of a class, keeping only the
+// entries accepted by include unless it is nil.
+func (h *CurriculumHandler) curriculum(c *gin.Context, classID uint, academicYear string, include func(models.CurriculumSubject) bool) {
+	entries, err := h.curriculumRepo.FindByClass(classID, academicYear)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	response := CurriculumResponse{
+		ClassID:      classID,
+		AcademicYear: academicYear,
+		Subjects:     make([]models.CurriculumSubject, 0, len(entries)),
+	}
+	for _, entry := range entries {
+		if include != nil && !include(entry) {
+			continue
+		}
+		response.Subjects = append(response.Subjects, entry)
+		if !entry.IsElective {
+			response.CorePeriods += entry.WeeklyPeriods
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// checkEntry validates the references of an entry and rejects a subject
+// that is already in the curriculum.
+func (h *CurriculumHandler) checkEntry(c *gin.Context, entry *models.CurriculumSubject) bool {
+	if _, _, err := services.AcademicYearRange(entry.AcademicYear, config.AppConfig.AcademicYearStartMonth); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid academic year. Use e.g. 2024-2025"})
+		return false
+	}
+	if _, err := h.classRepo.FindByID(entry.ClassID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Class not found"})
+		return false
+	}
+	if _, err := h.subjectRepo.FindByID(entry.SubjectID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Subject not found"})
+		return false
+	}
+
+	existing, err := h.curriculumRepo.FindEntry(entry.ClassID, entry.SubjectID, entry.AcademicYear)
+	if err == nil && existing.ID != entry.ID {
+		c.JSON(http.StatusConflict, gin.H{"error": "The subject is already in the curriculum of this class"})
+		return false
+	}
+	return true
+}
+
+// Request Types
+type CurriculumSubjectRequest struct {
+	ClassID       uint    `json:"class_id" binding:"required"`
+	SubjectID     uint    `json:"subject_id" binding:"required"`
+	AcademicYear  string  `json:"academic_year" binding:"required"`
+	IsElective    bool    `json:"is_elective"`
+	WeeklyPeriods int     `json:"weekly_periods" binding:"gte=0"`
+	MaxMarks      float64 `json:"max_marks" binding:"gte=0"`
+	Capacity      int     `json:"capacity" binding:"gte=0"` // seats of an elective, 0 for no limit
+}
+
+func (req *CurriculumSubjectRequest) apply(entry *models.CurriculumSubject) {
+	entry.ClassID = req.ClassID
+	entry.SubjectID = req.SubjectID
+	entry.AcademicYear = req.AcademicYear
+	entry.IsElective = req.IsElective
+	entry.WeeklyPeriods = req.WeeklyPeriods
+	entry.MaxMarks = req.MaxMarks
+	entry.Capacity = req.Capacity
+}
+
+type CopyCurriculumRequest struct {
+	FromAcademicYear string `json:"from_academic_year" binding:"required"`
+	ToAcademicYear   string `json:"to_academic_year" binding:"required"`
+	ClassID          uint   `json:"class_id"` // optional, copies every class when omitted
+}
+
+// Response Types
+type CurriculumResponse struct {
+	ClassID      uint                       `json:"class_id"`
+	AcademicYear string                     `json:"academic_year"`
+	CorePeriods  int                        `json:"core_periods"` // weekly periods of the core subjects
+	Subjects     []models.CurriculumSubject `json:"subjects"`
+}
+
+type CurriculumCopyResponse struct {
+	FromAcademicYear string `json:"from_academic_year"`
+	ToAcademicYear   string `json:"to_academic_year"`
+	Copied           int    `json:"copied"`
+	Skipped          int    `json:"skipped"`
+}
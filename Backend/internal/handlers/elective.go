@@ -0,0 +1,303 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"school-erp-backend/internal/models"
+	"school-erp-backend/internal/repository"
+	"school-erp-backend/internal/services"
+	"school-erp-backend/pkg/database"
+	"github.com/gin-gonic/gin"
+)
+
+type ElectiveHandler struct {
+	electiveRepo   *repository.ElectiveRepository
+	curriculumRepo *repository.CurriculumRepository
+	studentRepo    *repository.StudentRepository
+	access         teachingAccess
+}
+
+func NewElectiveHandler() *ElectiveHandler {
+	return &ElectiveHandler{
+		electiveRepo:   repository.NewElectiveRepository(database.DB),
+		curriculumRepo: repository.NewCurriculumRepository(database.DB),
+		studentRepo:    repository.NewStudentRepository(database.DB),
+		access:         newTeachingAccess(),
+	}
+}
+
+// GetEnrollments godoc
+// @Summary Get elective enrollments
+// @Description Get elective enrollments with optional filters
+// @Tags Admin - Electives
+// @Accept json
+// @Produce json
+// @Param student_id query int false "Filter by student ID"
+// @Param class_id query int false "Filter by class ID"
+// @Param subject_id query int false "Filter by subject ID"
+// @Param academic_year query string false "Filter by academic year"
+// @Success 200 {array} models.ElectiveEnrollment
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/electives [get]
+// @Security BearerAuth
+func (h *ElectiveHandler) GetEnrollments(c *gin.Context) {
+	filter := models.ElectiveEnrollment{AcademicYear: c.Query("academic_year")}
+	if value, err := strconv.ParseUint(c.Query("student_id"), 10, 32); err == nil {
+		filter.StudentID = uint(value)
+	}
+	if value, err := strconv.ParseUint(c.Query("class_id"), 10, 32); err == nil {
+		filter.ClassID = uint(value)
+	}
+	if value, err := strconv.ParseUint(c.Query("subject_id"), 10, 32); err == nil {
+		filter.SubjectID = uint(value)
+	}
+
+	enrollments, err := h.electiveRepo.FindAll(filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, enrollments)
+}
+
+// EnrollStudent godoc
+// @Summary Enroll a student in an elective
+// @Description Enroll a student in an elective subject of the student's class for an academic year
+// @Tags Admin - Electives
+// @Accept json
+// @Produce json
+// @Param enrollment body ElectiveEnrollmentRequest true "Enrollment data"
+// @Success 201 {object} models.ElectiveEnrollment
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /admin/electives [post]
+// @Security BearerAuth
+func (h *ElectiveHandler) EnrollStudent(c *gin.Context) {
+	var req ElectiveEnrollmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	student, err := h.studentRepo.FindByID(req.StudentID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Student not found"})
+		return
+	}
+
+	elective, ok := h.elective(c, student.ClassID, req.SubjectID, req.AcademicYear)
+	if !ok {
+		return
+	}
+
+	enrolled, skipped, ok := h.enroll(c, elective, []uint{student.ID})
+	if !ok {
+		return
+	}
+	if skipped > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "Student is already enrolled in this elective"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, enrolled[0])
+}
+
+// BulkEnrollStudents godoc
+// @Summary Enroll several students in an elective
+// @Description Enroll students of a class in one of its electives for an academic year. Students already enrolled are skipped. The batch is rejected as a whole when it exceeds the seats left.
+// @Tags Admin - Electives
+// @Accept json
+// @Produce json
+// @Param enrollment body BulkElectiveEnrollmentRequest true "Bulk enrollment data"
+// @Success 200 {object} BulkElectiveEnrollmentResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /admin/electives/bulk [post]
+// @Security BearerAuth
+func (h *ElectiveHandler) BulkEnrollStudents(c *gin.Context) {
+	var req BulkElectiveEnrollmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	elective, ok := h.elective(c, req.ClassID, req.SubjectID, req.AcademicYear)
+	if !ok {
+		return
+	}
+
+	students, err := h.studentRepo.FindByClass(req.ClassID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	inClass := make(map[uint]bool, len(students))
+	for _, student := range students {
+		inClass[student.ID] = true
+	}
+	for _, studentID := range req.StudentIDs {
+		if !inClass[studentID] {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Student " + strconv.FormatUint(uint64(studentID), 10) + " does not belong to this class"})
+			return
+		}
+	}
+
+	enrolled, skipped, ok := h.enroll(c, elective, req.StudentIDs)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, BulkElectiveEnrollmentResponse{
+		Enrolled: enrolled,
+		Skipped:  skipped,
+	})
+}
+
+// DeleteEnrollment godoc
+// @Summary Withdraw a student from an elective
+// @Description Delete an elective enrollment
+// @Tags Admin - Electives
+// @Accept json
+// @Produce json
+// @Param id path int true "Enrollment ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/electives/{id} [delete]
+// @Security BearerAuth
+func (h *ElectiveHandler) DeleteEnrollment(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	if _, err := h.electiveRepo.FindByID(uint(id)); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Enrollment not found"})
+		return
+	}
+
+	if err := h.electiveRepo.Delete(uint(id)); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Enrollment deleted successfully"})
+}
+
+// GetElectiveRoster godoc
+// @Summary Get the roster of an elective
+// @Description Get the students of a class (optionally one section) enrolled in an elective for an academic year (defaults to the current one). Teachers must give a section in which they teach the subject.
+// @Tags Electives
+// @Accept json
+// @Produce json
+// @Param class_id query int true "Class ID"
+// @Param subject_id query int true "Subject ID"
+// @Param section_id query int false "Section ID"
+// @Param academic_year query string false "Academic year (e.g. 2024-2025)"
+// @Success 200 {object} ElectiveRosterResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /admin/electives/roster [get]
+// @Router /teacher/electives/roster [get]
+// @Security BearerAuth
+func (h *ElectiveHandler) GetElectiveRoster(c *gin.Context) {
+	classID, err := strconv.ParseUint(c.Query("class_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid class ID"})
+		return
+	}
+	subjectID, err := strconv.ParseUint(c.Query("subject_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid subject ID"})
+		return
+	}
+	var sectionID uint64
+	if value := c.Query("section_id"); value != "" {
+		sectionID, err = strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid section ID"})
+			return
+		}
+	} else if c.GetString("user_role") == "teacher" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "section_id is required"})
+		return
+	}
+	academicYear := academicYearOrCurrent(c)
+
+	if !h.access.allow(c, uint(classID), uint(sectionID), uint(subjectID), academicYear) {
+		return
+	}
+
+	elective, ok := h.elective(c, uint(classID), uint(subjectID), academicYear)
+	if !ok {
+		return
+	}
+
+	students, err := h.electiveRepo.EnrolledStudents(uint(classID), uint(sectionID), uint(subjectID), academicYear)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, ElectiveRosterResponse{
+		ClassID:      uint(classID),
+		SectionID:    uint(sectionID),
+		SubjectID:    uint(subjectID),
+		AcademicYear: academicYear,
+		Capacity:     elective.Capacity,
+		Students:     students,
+	})
+}
+
+// elective loads the curriculum entry of a subject that must be an elective
+// of the class in the academic year.
+func (h *ElectiveHandler) elective(c *gin.Context, classID, subjectID uint, academicYear string) (*models.CurriculumSubject, bool) {
+	entry, err := h.curriculumRepo.FindEntry(classID, subjectID, academicYear)
+	if err != nil || !entry.IsElective {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Subject is not an elective of this class for the academic year"})
+		return nil, false
+	}
+	return entry, true
+}
+
+func (h *ElectiveHandler) enroll(c *gin.Context, elective *models.CurriculumSubject, studentIDs []uint) ([]models.ElectiveEnrollment, int, bool) {
+	enrolled, skipped, err := services.EnrollStudents(database.DB, elective, studentIDs, c.GetUint("user_id"))
+	var fullErr *services.ElectiveFullError
+	if errors.As(err, &fullErr) {
+		c.JSON(http.StatusConflict, gin.H{"error": "Elective is full: " + fullErr.Error()})
+		return nil, 0, false
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return nil, 0, false
+	}
+	return enrolled, skipped, true
+}
+
+// Request Types
+type ElectiveEnrollmentRequest struct {
+	StudentID    uint   `json:"student_id" binding:"required"`
+	SubjectID    uint   `json:"subject_id" binding:"required"`
+	AcademicYear string `json:"academic_year" binding:"required"`
+}
+
+type BulkElectiveEnrollmentRequest struct {
+	ClassID      uint   `json:"class_id" binding:"required"`
+	SubjectID    uint   `json:"subject_id" binding:"required"`
+	AcademicYear string `json:"academic_year" binding:"required"`
+	StudentIDs   []uint `json:"student_ids" binding:"required,min=1"`
+}
+
+// Response Types
+type BulkElectiveEnrollmentResponse struct {
+	Enrolled []models.ElectiveEnrollment `json:"enrolled"`
+	Skipped  int                         `json:"skipped"` // students already enrolled
+}
+
+type ElectiveRosterResponse struct {
+	ClassID      uint             `json:"class_id"`
+	SectionID    uint             `json:"section_id,omitempty"`
+	SubjectID    uint             `json:"subject_id"`
+	AcademicYear string           `json:"academic_year"`
+	Capacity     int              `json:"capacity"` // 0 for no limit
+	Students     []models.Student `json:"students"`
+}
@@ -0,0 +1,413 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+	"school-erp-backend/internal/models"
+	"school-erp-backend/internal/repository"
+	"school-erp-backend/internal/services"
+	"school-erp-backend/pkg/database"
+	"github.com/gin-gonic/gin"
+)
+
+type ExamHandler struct {
+	examRepo       *repository.ExamRepository
+	markRepo       *repository.MarkRepository
+	curriculumRepo *repository.CurriculumRepository
+}
+
+func NewExamHandler() *ExamHandler {
+	return &ExamHandler{
+		examRepo:       repository.NewExamRepository(database.DB),
+		markRepo:       repository.NewMarkRepository(database.DB),
+		curriculumRepo: repository.NewCurriculumRepository(database.DB),
+	}
+}
+
+// GetExams godoc
+// @Summary Get all exams
+// @Description Get list of exams with optional filters
+// @Tags Exams
+// @Accept json
+// @Produce json
+// @Param class_id query int false "Filter by class ID"
+// @Param subject_id query int false "Filter by subject ID"
+// @Param academic_year query string false "Filter by academic year"
+// @Param exam_type query string false "Filter by exam type"
+// @Param status query string false "Filter by status (draft, published)"
+// @Success 200 {array} models.Exam
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/exams [get]
+// @Router /teacher/exams [get]
+// @Security BearerAuth
+func (h *ExamHandler) GetExams(c *gin.Context) {
+	filter := models.Exam{
+		AcademicYear: c.Query("academic_year"),
+		ExamType:     c.Query("exam_type"),
+		Status:       c.Query("status"),
+	}
+	if value, err := strconv.ParseUint(c.Query("class_id"), 10, 32); err == nil {
+		filter.ClassID = uint(value)
+	}
+	if value, err := strconv.ParseUint(c.Query("subject_id"), 10, 32); err == nil {
+		filter.SubjectID = uint(value)
+	}
+
+	scope, ok := requestScope(c)
+	if !ok {
+		return
+	}
+
+	exams, err := h.examRepo.Scoped(scope).FindAll(filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, exams)
+}
+
+// GetExam godoc
+// @Summary Get exam by ID
+// @Description Get a specific exam by ID
+// @Tags Exams
+// @Accept json
+// @Produce json
+// @Param id path int true "Exam ID"
+// @Success 200 {object} models.Exam
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/exams/{id} [get]
+// @Security BearerAuth
+func (h *ExamHandler) GetExam(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	scope, ok := requestScope(c)
+	if !ok {
+		return
+	}
+
+	exam, err := h.examRepo.Scoped(scope).FindByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Exam not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, exam)
+}
+
+// CreateExam godoc
+// @Summary Create a new exam
+// @Description Schedule an exam for a class and subject in an academic year. When the class has a curriculum for the year the subject must be part of it, and total_marks defaults to the subject's max marks.
+// @Tags Exams
+// @Accept json
+// @Produce json
+// @Param exam body CreateExamRequest true "Exam data"
+// @Success 201 {object} models.Exam
+// @Failure 400 {object} ErrorResponse
+// @Router /admin/exams [post]
+// @Security BearerAuth
+func (h *ExamHandler) CreateExam(c *gin.Context) {
+	var req CreateExamRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	entry, ok := h.curriculumEntry(c, req.ClassID, req.SubjectID, req.AcademicYear)
+	if !ok {
+		return
+	}
+	if req.TotalMarks == 0 && entry != nil {
+		req.TotalMarks = entry.MaxMarks
+	}
+	if req.TotalMarks == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "total_marks is required"})
+		return
+	}
+
+	if req.PassingMarks > req.TotalMarks {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Passing marks cannot exceed total marks"})
+		return
+	}
+
+	exam := &models.Exam{
+		Name:         req.Name,
+		ExamType:     req.ExamType,
+		ClassID:      req.ClassID,
+		SubjectID:    req.SubjectID,
+		TotalMarks:   req.TotalMarks,
+		PassingMarks: req.PassingMarks,
+		AcademicYear: req.AcademicYear,
+		Status:       "draft",
+	}
+
+	if req.ExamDate != "" {
+		examDate, err := time.Parse("2006-01-02", req.ExamDate)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date format. Use YYYY-MM-DD"})
+			return
+		}
+		exam.ExamDate = examDate
+	}
+
+	if err := h.examRepo.Create(exam); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, exam)
+}
+
+// UpdateExam godoc
+// @Summary Update exam
+// @Description Update an exam that has not been published yet
+// @Tags Exams
+// @Accept json
+// @Produce json
+// @Param id path int true "Exam ID"
+// @Param exam body UpdateExamRequest true "Exam data"
+// @Success 200 {object} models.Exam
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /admin/exams/{id} [put]
+// @Security BearerAuth
+func (h *ExamHandler) UpdateExam(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	var req UpdateExamRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	exam, err := h.examRepo.FindByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Exam not found"})
+		return
+	}
+
+	if exam.Status == "published" {
+		c.JSON(http.StatusConflict, gin.H{"error": "Exam results are published and locked"})
+		return
+	}
+
+	// Marks copy the type, class, subject, year and total of their exam, so
+	// those cannot change once marks exist
+	if (req.ExamType != "" && req.ExamType != exam.ExamType) ||
+		(req.ClassID != 0 && req.ClassID != exam.ClassID) ||
+		(req.SubjectID != 0 && req.SubjectID != exam.SubjectID) ||
+		(req.TotalMarks != 0 && req.TotalMarks != exam.TotalMarks) ||
+		(req.AcademicYear != "" && req.AcademicYear != exam.AcademicYear) {
+		count, err := h.markRepo.CountByExam(exam.ID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if count > 0 {
+			c.JSON(http.StatusConflict, gin.H{"error": "Exam type, class, subject, academic year and total marks cannot be changed after marks have been entered"})
+			return
+		}
+	}
+
+	// Update fields
+	if req.Name != "" {
+		exam.Name = req.Name
+	}
+	if req.ExamType != "" {
+		exam.ExamType = req.ExamType
+	}
+	if req.ClassID != 0 {
+		exam.ClassID = req.ClassID
+	}
+	if req.SubjectID != 0 {
+		exam.SubjectID = req.SubjectID
+	}
+	if req.ExamDate != "" {
+		examDate, err := time.Parse("2006-01-02", req.ExamDate)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date format. Use YYYY-MM-DD"})
+			return
+		}
+		exam.ExamDate = examDate
+	}
+	if req.TotalMarks != 0 {
+		exam.TotalMarks = req.TotalMarks
+	}
+	if req.PassingMarks != 0 {
+		exam.PassingMarks = req.PassingMarks
+	}
+	if req.AcademicYear != "" {
+		exam.AcademicYear = req.AcademicYear
+	}
+
+	if req.ClassID != 0 || req.SubjectID != 0 || req.AcademicYear != "" {
+		if _, ok := h.curriculumEntry(c, exam.ClassID, exam.SubjectID, exam.AcademicYear); !ok {
+			return
+		}
+	}
+
+	if exam.PassingMarks > exam.TotalMarks {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Passing marks cannot exceed total marks"})
+		return
+	}
+
+	if err := h.examRepo.Update(exam); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, exam)
+}
+
+// DeleteExam godoc
+// @Summary Delete exam
+// @Description Delete an exam that has not been published yet
+// @Tags Exams
+// @Accept json
+// @Produce json
+// @Param id path int true "Exam ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /admin/exams/{id} [delete]
+// @Security BearerAuth
+func (h *ExamHandler) DeleteExam(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	exam, err := h.examRepo.FindByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Exam not found"})
+		return
+	}
+
+	if exam.Status == "published" {
+		c.JSON(http.StatusConflict, gin.H{"error": "Exam results are published and locked"})
+		return
+	}
+
+	if err := h.examRepo.Delete(exam.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete exam"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Exam deleted successfully"})
+}
+
+// PublishExam godoc
+// @Summary Publish exam results
+// @Description Publish the results of an exam. Marks of a published exam can no longer be changed.
+// @Tags Exams
+// @Accept json
+// @Produce json
+// @Param id path int true "Exam ID"
+// @Success 200 {object} models.Exam
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /admin/exams/{id}/publish [post]
+// @Security BearerAuth
+func (h *ExamHandler) PublishExam(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	exam, err := h.examRepo.FindByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Exam not found"})
+		return
+	}
+
+	if exam.Status == "published" {
+		c.JSON(http.StatusConflict, gin.H{"error": "Exam results are already published"})
+		return
+	}
+
+	now := time.Now()
+	publishedBy := c.GetUint("user_id")
+	exam.Status = "published"
+	exam.PublishedAt = &now
+	exam.PublishedBy = &publishedBy
+
+	if err := h.examRepo.Update(exam); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to publish exam"})
+		return
+	}
+
+	c.JSON(http.StatusOK, exam)
+}
+
+// UnpublishExam godoc
+// @Summary Unpublish exam results
+// @Description Withdraw published results so that marks can be corrected
+// @Tags Exams
+// @Accept json
+// @Produce json
+// @Param id path int true "Exam ID"
+// @Success 200 {object} models.Exam
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /admin/exams/{id}/unpublish [post]
+// @Security BearerAuth
+func (h *ExamHandler) UnpublishExam(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	exam, err := h.examRepo.FindByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Exam not found"})
+		return
+	}
+
+	if exam.Status != "published" {
+		c.JSON(http.StatusConflict, gin.H{"error": "Exam results are not published"})
+		return
+	}
+
+	exam.Status = "draft"
+	exam.PublishedAt = nil
+	exam.PublishedBy = nil
+
+	if err := h.examRepo.Update(exam); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to unpublish exam"})
+		return
+	}
+
+	c.JSON(http.StatusOK, exam)
+}
+
+// curriculumEntry checks that a subject may be examined in a class in an
+// academic year and returns its curriculum entry, if the class has one.
+func (h *ExamHandler) curriculumEntry(c *gin.Context, classID, subjectID uint, academicYear string) (*models.CurriculumSubject, bool) {
+	entry, err := services.CurriculumEntry(h.curriculumRepo, classID, subjectID, academicYear)
+	if errors.Is(err, services.ErrNotInCurriculum) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Subject is not in the curriculum of the class for this academic year"})
+		return nil, false
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return nil, false
+	}
+	return entry, true
+}
+
+// Request Types
+type CreateExamRequest struct {
+	Name         string  `json:"name" binding:"required"`
+	ExamType     string  `json:"exam_type" binding:"required"`
+	ClassID      uint    `json:"class_id" binding:"required"`
+	SubjectID    uint    `json:"subject_id" binding:"required"`
+	ExamDate     string  `json:"exam_date"`
+	TotalMarks   float64 `json:"total_marks" binding:"gte=0"` // defaults to the curriculum's max marks
+	PassingMarks float64 `json:"passing_marks" binding:"gte=0"`
+	AcademicYear string  `json:"academic_year" binding:"required"`
+}
+
+type UpdateExamRequest struct {
+	Name         string  `json:"name"`
+	ExamType     string  `json:"exam_type"`
+	ClassID      uint    `json:"class_id"`
+	SubjectID    uint    `json:"subject_id"`
+	ExamDate     string  `json:"exam_date"`
+	TotalMarks   float64 `json:"total_marks" binding:"gte=0"`
+	PassingMarks float64 `json:"passing_marks" binding:"gte=0"`
+	AcademicYear string  `json:"academic_year"`
+}
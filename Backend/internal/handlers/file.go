@@ -0,0 +1,279 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"time"
+	"school-erp-backend/internal/middleware"
+	"school-erp-backend/internal/models"
+	"school-erp-backend/internal/repository"
+	"school-erp-backend/internal/services"
+	"school-erp-backend/pkg/database"
+	"school-erp-backend/pkg/storage"
+	"github.com/gin-gonic/gin"
+)
+
+// signedURLExpiry is how long a URL from GetSubmissionFileURL stays valid
+const signedURLExpiry = 15 * time.Minute
+
+type FileHandler struct {
+	submissionRepo   *repository.AssignmentSubmissionRepository
+	studentLeaveRepo *repository.StudentLeaveRequestRepository
+	studentRepo      *repository.StudentRepository
+	teacherRepo      *repository.TeacherRepository
+	assignmentRepo   *repository.TeachingAssignmentRepository
+}
+
+func NewFileHandler() *FileHandler {
+	return &FileHandler{
+		submissionRepo:   repository.NewAssignmentSubmissionRepository(database.DB),
+		studentLeaveRepo: repository.NewStudentLeaveRequestRepository(database.DB),
+		studentRepo:      repository.NewStudentRepository(database.DB),
+		teacherRepo:      repository.NewTeacherRepository(database.DB),
+		assignmentRepo:   repository.NewTeachingAssignmentRepository(database.DB),
+	}
+}
+
+// DownloadSubmission godoc
+// @Summary Download a submission file
+// @Description Stream the file of an assignment submission. Teachers may download the submissions to their own assignments, students their own submissions, guardians those of their children and staff who may view assignments any submission.
+// @Tags Files
+// @Produce octet-stream
+// @Param id path int true "Submission ID"
+// @Success 200 {file} binary
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /files/submissions/{id} [get]
+// @Security BearerAuth
+func (h *FileHandler) DownloadSubmission(c *gin.Context) {
+	submission, ok := h.accessibleSubmission(c)
+	if !ok {
+		return
+	}
+
+	object, err := storage.Files.Get(c.Request.Context(), submission.FilePath)
+	if errors.Is(err, storage.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "File not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer object.Body.Close()
+
+	contentType := submission.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	c.DataFromReader(http.StatusOK, object.Size, contentType, object.Body, map[string]string{
+		"Content-Disposition": fmt.Sprintf(`attachment; filename="%s"`, safeFileName(submission.FileName)),
+	})
+}
+
+// DownloadStudentLeaveAttachment godoc
+// @Summary Download a student leave attachment
+// @Description Stream the supporting document of a student leave request. The student, their guardians, the class teacher of the section and staff who may view student leave may download it.
+// @Tags Files
+// @Produce octet-stream
+// @Param id path int true "Leave request ID"
+// @Success 200 {file} binary
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /files/student-leave/{id} [get]
+// @Security BearerAuth
+func (h *FileHandler) DownloadStudentLeaveAttachment(c *gin.Context) {
+	request, ok := h.accessibleLeaveAttachment(c)
+	if !ok {
+		return
+	}
+
+	object, err := storage.Files.Get(c.Request.Context(), request.AttachmentPath)
+	if errors.Is(err, storage.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "File not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer object.Body.Close()
+
+	contentType := request.AttachmentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	c.DataFromReader(http.StatusOK, object.Size, contentType, object.Body, map[string]string{
+		"Content-Disposition": fmt.Sprintf(`attachment; filename="%s"`, safeFileName(request.AttachmentName)),
+	})
+}
+
+// GetSubmissionFileURL godoc
+// @Summary Get a temporary download link for a submission file
+// @Description Get a signed URL that downloads the file of an assignment submission without authentication for 15 minutes. Access rules are the same as for downloading the file.
+// @Tags Files
+// @Produce json
+// @Param id path int true "Submission ID"
+// @Success 200 {object} SignedURLResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /files/submissions/{id}/url [get]
+// @Security BearerAuth
+func (h *FileHandler) GetSubmissionFileURL(c *gin.Context) {
+	submission, ok := h.accessibleSubmission(c)
+	if !ok {
+		return
+	}
+
+	url, err := storage.Files.SignedURL(c.Request.Context(), submission.FilePath, signedURLExpiry)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, SignedURLResponse{
+		URL:       url,
+		ExpiresAt: time.Now().Add(signedURLExpiry),
+	})
+}
+
+// DownloadSigned godoc
+// @Summary Download a file through a signed URL
+// @Description Serve a file of the local storage backend to a URL returned by a signed URL endpoint. Not used with S3 storage, whose signed URLs point at the object store.
+// @Tags Files
+// @Produce octet-stream
+// @Param key query string true "Storage key"
+// @Param expires query int true "Expiry (Unix time)"
+// @Param signature query string true "Signature"
+// @Success 200 {file} binary
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /files/signed [get]
+func (h *FileHandler) DownloadSigned(c *gin.Context) {
+	local, ok := storage.Files.(*storage.LocalStorage)
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Signed downloads are served by the storage backend"})
+		return
+	}
+
+	key := c.Query("key")
+	if err := local.VerifySignature(key, c.Query("expires"), c.Query("signature")); err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+
+	object, err := local.Get(c.Request.Context(), key)
+	if errors.Is(err, storage.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "File not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer object.Body.Close()
+
+	contentType := mime.TypeByExtension(path.Ext(key))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	c.DataFromReader(http.StatusOK, object.Size, contentType, object.Body, map[string]string{
+		"Content-Disposition": fmt.Sprintf(`attachment; filename="%s"`, safeFileName(path.Base(key))),
+	})
+}
+
+// accessibleSubmission loads the submission in the id path parameter and
+// checks that the logged in user may see it.
+func (h *FileHandler) accessibleSubmission(c *gin.Context) (*models.AssignmentSubmission, bool) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	submission, err := h.submissionRepo.FindByID(uint(id))
+	if err != nil || submission.FilePath == "" {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Submission not found"})
+		return nil, false
+	}
+
+	userID := c.GetUint("user_id")
+	allowed := false
+	switch c.GetString("user_role") {
+	case "teacher":
+		teacher, err := h.teacherRepo.FindByUserID(userID)
+		allowed = err == nil && submission.Assignment.TeacherID == teacher.ID
+	case "student":
+		student, err := h.studentRepo.FindByUserID(userID)
+		allowed = err == nil && submission.StudentID == student.ID
+	case services.RoleParent:
+		scope, ok := requestScope(c)
+		if !ok {
+			return nil, false
+		}
+		_, err := h.submissionRepo.Scoped(scope).FindByID(submission.ID)
+		allowed = err == nil
+	default:
+		held, ok := middleware.Permissions(c)
+		if !ok {
+			return nil, false
+		}
+		allowed = services.HasPermission(held, "assignments.read")
+	}
+	if !allowed {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+		return nil, false
+	}
+	return submission, true
+}
+
+// accessibleLeaveAttachment loads the student leave request in the id path
+// parameter and checks that the logged in user may see its attachment.
+func (h *FileHandler) accessibleLeaveAttachment(c *gin.Context) (*models.StudentLeaveRequest, bool) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	request, err := h.studentLeaveRepo.FindByID(uint(id))
+	if err != nil || request.AttachmentPath == "" {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Attachment not found"})
+		return nil, false
+	}
+
+	userID := c.GetUint("user_id")
+	allowed := false
+	switch c.GetString("user_role") {
+	case "teacher":
+		teacher, err := h.teacherRepo.FindByUserID(userID)
+		if err == nil {
+			allowed, err = h.assignmentRepo.Teaches(teacher.ID, request.ClassID, request.SectionID, 0, request.AcademicYear, true)
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return nil, false
+		}
+	case "student":
+		student, err := h.studentRepo.FindByUserID(userID)
+		allowed = err == nil && request.StudentID == student.ID
+	case services.RoleParent:
+		scope, ok := requestScope(c)
+		if !ok {
+			return nil, false
+		}
+		_, err := h.studentRepo.Scoped(scope).FindByID(request.StudentID)
+		allowed = err == nil
+	default:
+		held, ok := middleware.Permissions(c)
+		if !ok {
+			return nil, false
+		}
+		allowed = services.HasPermission(held, "student_leave.read")
+	}
+	if !allowed {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+		return nil, false
+	}
+	return request, true
+}
+
+// Response Types
+type SignedURLResponse struct {
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
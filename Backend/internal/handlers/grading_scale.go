@@ -0,0 +1,365 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"school-erp-backend/internal/models"
+	"school-erp-backend/internal/repository"
+	"school-erp-backend/internal/services"
+	"school-erp-backend/pkg/database"
+	"github.com/gin-gonic/gin"
+)
+
+type GradingScaleHandler struct {
+	scaleRepo *repository.GradingScaleRepository
+	jobRepo   *repository.JobRepository
+}
+
+func NewGradingScaleHandler() *GradingScaleHandler {
+	return &GradingScaleHandler{
+		scaleRepo: repository.NewGradingScaleRepository(database.DB),
+		jobRepo:   repository.NewJobRepository(database.DB),
+	}
+}
+
+// GetGradingScales godoc
+// @Summary Get all grading scales
+// @Description Get list of all grading scales with their grade bands
+// @Tags Admin - Grading Scales
+// @Accept json
+// @Produce json
+// @Success 200 {array} models.GradingScale
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/grading-scales [get]
+// @Security BearerAuth
+func (h *GradingScaleHandler) GetGradingScales(c *gin.Context) {
+	scales, err := h.scaleRepo.FindAll()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, scales)
+}
+
+// GetGradingScale godoc
+// @Summary Get grading scale by ID
+// @Description Get a specific grading scale with its grade bands
+// @Tags Admin - Grading Scales
+// @Accept json
+// @Produce json
+// @Param id path int true "Grading scale ID"
+// @Success 200 {object} models.GradingScale
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/grading-scales/{id} [get]
+// @Security BearerAuth
+func (h *GradingScaleHandler) GetGradingScale(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	scale, err := h.scaleRepo.FindByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Grading scale not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, scale)
+}
+
+// CreateGradingScale godoc
+// @Summary Create a new grading scale
+// @Description Define a named grading scale as percentage bands mapped to letter grades and grade points
+// @Tags Admin - Grading Scales
+// @Accept json
+// @Produce json
+// @Param scale body CreateGradingScaleRequest true "Grading scale data"
+// @Success 201 {object} models.GradingScale
+// @Failure 400 {object} ErrorResponse
+// @Router /admin/grading-scales [post]
+// @Security BearerAuth
+func (h *GradingScaleHandler) CreateGradingScale(c *gin.Context) {
+	var req CreateGradingScaleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	bands := gradeBandsFromRequest(req.Bands)
+	if err := services.ValidateGradeBands(bands); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	scale := &models.GradingScale{
+		Name:        req.Name,
+		Description: req.Description,
+		Status:      "active",
+		Bands:       bands,
+	}
+
+	if err := h.scaleRepo.Create(scale); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, scale)
+}
+
+// UpdateGradingScale godoc
+// @Summary Update grading scale
+// @Description Update a grading scale. Bands, when given, replace the existing ones. Existing marks keep their grades until a recompute is run.
+// @Tags Admin - Grading Scales
+// @Accept json
+// @Produce json
+// @Param id path int true "Grading scale ID"
+// @Param scale body UpdateGradingScaleRequest true "Grading scale data"
+// @Success 200 {object} models.GradingScale
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/grading-scales/{id} [put]
+// @Security BearerAuth
+func (h *GradingScaleHandler) UpdateGradingScale(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	var req UpdateGradingScaleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	scale, err := h.scaleRepo.FindByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Grading scale not found"})
+		return
+	}
+
+	// Update fields
+	if req.Name != "" {
+		scale.Name = req.Name
+	}
+	if req.Description != "" {
+		scale.Description = req.Description
+	}
+	if req.Status != "" {
+		scale.Status = req.Status
+	}
+
+	var bands []models.GradeBand
+	if len(req.Bands) > 0 {
+		bands = gradeBandsFromRequest(req.Bands)
+		if err := services.ValidateGradeBands(bands); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	if err := h.scaleRepo.Update(scale, bands); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, scale)
+}
+
+// DeleteGradingScale godoc
+// @Summary Delete grading scale
+// @Description Delete a grading scale that is not assigned to any class
+// @Tags Admin - Grading Scales
+// @Accept json
+// @Produce json
+// @Param id path int true "Grading scale ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /admin/grading-scales/{id} [delete]
+// @Security BearerAuth
+func (h *GradingScaleHandler) DeleteGradingScale(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	if _, err := h.scaleRepo.FindByID(uint(id)); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Grading scale not found"})
+		return
+	}
+
+	count, err := h.scaleRepo.CountAssignments(uint(id))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if count > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "Grading scale is assigned to classes"})
+		return
+	}
+
+	if err := h.scaleRepo.Delete(uint(id)); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete grading scale"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Grading scale deleted successfully"})
+}
+
+// GetAssignments godoc
+// @Summary Get grading scale assignments
+// @Description Get which grading scale each class uses per academic year
+// @Tags Admin - Grading Scales
+// @Accept json
+// @Produce json
+// @Param class_id query int false "Filter by class ID"
+// @Param academic_year query string false "Filter by academic year"
+// @Success 200 {array} models.ClassGradingScale
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/grading-scales/assignments [get]
+// @Security BearerAuth
+func (h *GradingScaleHandler) GetAssignments(c *gin.Context) {
+	classID, _ := strconv.ParseUint(c.Query("class_id"), 10, 32)
+
+	assignments, err := h.scaleRepo.FindAssignments(uint(classID), c.Query("academic_year"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, assignments)
+}
+
+// AssignGradingScale godoc
+// @Summary Assign grading scale to class
+// @Description Set the grading scale used for a class in an academic year, replacing any previous assignment. The response reports how many existing marks a recompute would regrade.
+// @Tags Admin - Grading Scales
+// @Accept json
+// @Produce json
+// @Param assignment body AssignGradingScaleRequest true "Assignment data"
+// @Success 200 {object} GradingScaleAssignmentResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/grading-scales/assignments [post]
+// @Security BearerAuth
+func (h *GradingScaleHandler) AssignGradingScale(c *gin.Context) {
+	var req AssignGradingScaleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if _, err := h.scaleRepo.FindByID(req.GradingScaleID); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Grading scale not found"})
+		return
+	}
+
+	assignment, err := h.scaleRepo.FindAssignment(req.ClassID, req.AcademicYear)
+	if err != nil {
+		assignment = &models.ClassGradingScale{
+			ClassID:      req.ClassID,
+			AcademicYear: req.AcademicYear,
+		}
+	}
+	assignment.GradingScaleID = req.GradingScaleID
+
+	if err := h.scaleRepo.SaveAssignment(assignment); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	affected, err := h.scaleRepo.CountMarks(req.ClassID, req.AcademicYear)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	assignment, _ = h.scaleRepo.FindAssignment(req.ClassID, req.AcademicYear)
+	c.JSON(http.StatusOK, GradingScaleAssignmentResponse{
+		Assignment:    *assignment,
+		AffectedMarks: affected,
+	})
+}
+
+// RecomputeGrades godoc
+// @Summary Recompute grades
+// @Description Start a background job that regrades the existing marks of a class and academic year with its current grading scale. Marks of published exams are skipped unless include_published is set.
+// @Tags Admin - Grading Scales
+// @Accept json
+// @Produce json
+// @Param recompute body RecomputeGradesRequest true "Recompute options"
+// @Success 202 {object} models.Job
+// @Failure 400 {object} ErrorResponse
+// @Router /admin/grading-scales/recompute [post]
+// @Security BearerAuth
+func (h *GradingScaleHandler) RecomputeGrades(c *gin.Context) {
+	var req RecomputeGradesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	payload, _ := json.Marshal(req)
+	job := &models.Job{
+		Type:      "grade_recompute",
+		Payload:   string(payload),
+		CreatedBy: c.GetUint("user_id"),
+	}
+
+	err := services.StartJob(h.jobRepo, job, func() (interface{}, error) {
+		return services.RecomputeGrades(database.DB, req.ClassID, req.AcademicYear, req.IncludePublished)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start recompute job"})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, job)
+}
+
+func gradeBandsFromRequest(input []GradeBandRequest) []models.GradeBand {
+	bands := make([]models.GradeBand, 0, len(input))
+	for _, band := range input {
+		bands = append(bands, models.GradeBand{
+			MinPercentage: band.MinPercentage,
+			MaxPercentage: band.MaxPercentage,
+			Grade:         band.Grade,
+			GradePoint:    band.GradePoint,
+			Description:   band.Description,
+		})
+	}
+	return bands
+}
+
+// Request Types
+type GradeBandRequest struct {
+	MinPercentage float64 `json:"min_percentage" binding:"gte=0,lte=100"`
+	MaxPercentage float64 `json:"max_percentage" binding:"gte=0,lte=100"`
+	Grade         string  `json:"grade" binding:"required"`
+	GradePoint    float64 `json:"grade_point"`
+	Description   string  `json:"description"`
+}
+
+type CreateGradingScaleRequest struct {
+	Name        string             `json:"name" binding:"required"`
+	Description string             `json:"description"`
+	Bands       []GradeBandRequest `json:"bands" binding:"required,min=1,dive"`
+}
+
+type UpdateGradingScaleRequest struct {
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	Status      string             `json:"status"`
+	Bands       []GradeBandRequest `json:"bands" binding:"dive"`
+}
+
+type AssignGradingScaleRequest struct {
+	ClassID        uint   `json:"class_id" binding:"required"`
+	AcademicYear   string `json:"academic_year" binding:"required"`
+	GradingScaleID uint   `json:"grading_scale_id" binding:"required"`
+}
+
+type RecomputeGradesRequest struct {
+	ClassID          uint   `json:"class_id" binding:"required"`
+	AcademicYear     string `json:"academic_year" binding:"required"`
+	IncludePublished bool   `json:"include_published"`
+}
+
+// Response Types
+type GradingScaleAssignmentResponse struct {
+	Assignment    models.ClassGradingScale `json:"assignment"`
+	AffectedMarks int64                    `json:"affected_marks"`
+}
@@ -0,0 +1,405 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"school-erp-backend/internal/models"
+	"school-erp-backend/internal/repository"
+	"school-erp-backend/internal/services"
+	"school-erp-backend/pkg/database"
+	"github.com/gin-gonic/gin"
+)
+
+type GuardianHandler struct {
+	guardianRepo *repository.GuardianRepository
+	studentRepo  *repository.StudentRepository
+	userRepo     *repository.UserRepository
+}
+
+func NewGuardianHandler() *GuardianHandler {
+	return &GuardianHandler{
+		guardianRepo: repository.NewGuardianRepository(database.DB),
+		studentRepo:  repository.NewStudentRepository(database.DB),
+		userRepo:     repository.NewUserRepository(database.DB),
+	}
+}
+
+// GetGuardians godoc
+// @Summary Get guardians
+// @Description Get guardians with the students they are linked to
+// @Tags Admin - Guardians
+// @Produce json
+// @Param phone query string false "Filter by phone number"
+// @Param student_id query int false "Filter by linked student"
+// @Success 200 {array} GuardianResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/guardians [get]
+// @Security BearerAuth
+func (h *GuardianHandler) GetGuardians(c *gin.Context) {
+	filter := models.Guardian{Phone: services.NormalizePhone(c.Query("phone"))}
+	studentID, _ := strconv.ParseUint(c.Query("student_id"), 10, 32)
+
+	guardians, err := h.guardianRepo.FindAll(filter, uint(studentID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	guardianIDs := make([]uint, len(guardians))
+	for i, guardian := range guardians {
+		guardianIDs[i] = guardian.ID
+	}
+	links, err := h.guardianRepo.FindLinks(guardianIDs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	children := make(map[uint][]models.StudentGuardian, len(guardians))
+	for _, link := range links {
+		children[link.GuardianID] = append(children[link.GuardianID], link)
+	}
+	response := make([]GuardianResponse, 0, len(guardians))
+	for _, guardian := range guardians {
+		response = append(response, newGuardianResponse(guardian, children[guardian.ID]))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetGuardian godoc
+// @Summary Get guardian by ID
+// @Description Get a guardian with the students they are linked to
+// @Tags Admin - Guardians
+// @Produce json
+// @Param id path int true "Guardian ID"
+// @Success 200 {object} GuardianResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/guardians/{id} [get]
+// @Security BearerAuth
+func (h *GuardianHandler) GetGuardian(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	guardian, err := h.guardianRepo.FindByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Guardian not found"})
+		return
+	}
+
+	h.respondGuardian(c, http.StatusOK, guardian)
+}
+
+// CreateGuardian godoc
+// @Summary Create a guardian
+// @Description Create the guardian profile of an existing user with the parent role
+// @Tags Admin - Guardians
+// @Accept json
+// @Produce json
+// @Param guardian body CreateGuardianRequest true "Guardian data"
+// @Success 201 {object} GuardianResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /admin/guardians [post]
+// @Security BearerAuth
+func (h *GuardianHandler) CreateGuardian(c *gin.Context) {
+	var req CreateGuardianRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := h.userRepo.FindByID(req.UserID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "User not found"})
+		return
+	}
+	if user.Role != services.RoleParent {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "User must have the parent role"})
+		return
+	}
+	if _, err := h.guardianRepo.FindByUserID(user.ID); err == nil {
+		c.JSON(http.StatusConflict, gin.H{"error": "User already has a guardian profile"})
+		return
+	}
+
+	guardian := &models.Guardian{
+		UserID:  user.ID,
+		Name:    strings.TrimSpace(req.Name),
+		Address: req.Address,
+	}
+	if !applyGuardianPhone(c, guardian, req.Phone) {
+		return
+	}
+
+	if err := h.guardianRepo.Create(guardian); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	guardian.User = *user
+
+	h.respondGuardian(c, http.StatusCreated, guardian)
+}
+
+// UpdateGuardian godoc
+// @Summary Update a guardian
+// @Description Update the name, phone number or address of a guardian
+// @Tags Admin - Guardians
+// @Accept json
+// @Produce json
+// @Param id path int true "Guardian ID"
+// @Param guardian body UpdateGuardianRequest true "Guardian data"
+// @Success 200 {object} GuardianResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/guardians/{id} [put]
+// @Security BearerAuth
+func (h *GuardianHandler) UpdateGuardian(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	guardian, err := h.guardianRepo.FindByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Guardian not found"})
+		return
+	}
+
+	var req UpdateGuardianRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if name := strings.TrimSpace(req.Name); name != "" {
+		guardian.Name = name
+	}
+	if req.Phone != "" && !applyGuardianPhone(c, guardian, req.Phone) {
+		return
+	}
+	if req.Address != "" {
+		guardian.Address = req.Address
+	}
+
+	if err := h.guardianRepo.Update(guardian); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.respondGuardian(c, http.StatusOK, guardian)
+}
+
+// DeleteGuardian godoc
+// @Summary Delete a guardian
+// @Description Delete a guardian and unlink their children. The user account is kept and can be removed under /admin/users.
+// @Tags Admin - Guardians
+// @Produce json
+// @Param id path int true "Guardian ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/guardians/{id} [delete]
+// @Security BearerAuth
+func (h *GuardianHandler) DeleteGuardian(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	if _, err := h.guardianRepo.FindByID(uint(id)); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Guardian not found"})
+		return
+	}
+
+	if err := h.guardianRepo.Delete(uint(id)); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Guardian deleted successfully"})
+}
+
+// LinkStudent godoc
+// @Summary Link a student to a guardian
+// @Description Link a student to a guardian, who can then follow the student in the parent portal. A student may have several guardians.
+// @Tags Admin - Guardians
+// @Accept json
+// @Produce json
+// @Param id path int true "Guardian ID"
+// @Param link body LinkStudentRequest true "Student to link"
+// @Success 201 {object} GuardianResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /admin/guardians/{id}/students [post]
+// @Security BearerAuth
+func (h *GuardianHandler) LinkStudent(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	var req LinkStudentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	guardian, err := h.guardianRepo.FindByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Guardian not found"})
+		return
+	}
+	if _, err := h.studentRepo.FindByID(req.StudentID); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Student not found"})
+		return
+	}
+	if _, err := h.guardianRepo.FindLink(guardian.ID, req.StudentID); err == nil {
+		c.JSON(http.StatusConflict, gin.H{"error": "Student is already linked to this guardian"})
+		return
+	}
+
+	link := &models.StudentGuardian{
+		GuardianID:   guardian.ID,
+		StudentID:    req.StudentID,
+		Relationship: strings.ToLower(strings.TrimSpace(req.Relationship)),
+	}
+	if err := h.guardianRepo.Link(link); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.respondGuardian(c, http.StatusCreated, guardian)
+}
+
+// UnlinkStudent godoc
+// @Summary Unlink a student from a guardian
+// @Description Remove a student from the children of a guardian
+// @Tags Admin - Guardians
+// @Produce json
+// @Param id path int true "Guardian ID"
+// @Param student_id path int true "Student ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/guardians/{id}/students/{student_id} [delete]
+// @Security BearerAuth
+func (h *GuardianHandler) UnlinkStudent(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	studentID, _ := strconv.ParseUint(c.Param("student_id"), 10, 32)
+
+	if _, err := h.guardianRepo.FindLink(uint(id), uint(studentID)); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Student is not linked to this guardian"})
+		return
+	}
+
+	if err := h.guardianRepo.Unlink(uint(id), uint(studentID)); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Student unlinked successfully"})
+}
+
+// ImportGuardians godoc
+// @Summary Create guardian accounts from student records
+// @Description Create a parent user and guardian for every distinct parent phone of the selected students (all students when none are selected), linking siblings to the same guardian. Phone numbers that already belong to a guardian only get the students linked. Login emails come from emails, keyed by phone number, or are made up as parent.<phone>@<email_domain>. The temporary passwords are returned once and are not stored.
+// @Tags Admin - Guardians
+// @Accept json
+// @Produce json
+// @Param import body ImportGuardiansRequest true "Students to import guardians from"
+// @Success 200 {object} services.GuardianImport
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/guardians/bulk [post]
+// @Security BearerAuth
+func (h *GuardianHandler) ImportGuardians(c *gin.Context) {
+	var req ImportGuardiansRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	emailDomain := strings.ToLower(strings.TrimSpace(req.EmailDomain))
+	if emailDomain != "" && (strings.Contains(emailDomain, "@") || !strings.Contains(emailDomain, ".")) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid email domain"})
+		return
+	}
+
+	var students []models.Student
+	var err error
+	if len(req.StudentIDs) > 0 {
+		students, err = h.studentRepo.FindByIDs(req.StudentIDs)
+	} else {
+		students, err = h.studentRepo.FindAll(models.Student{ClassID: req.ClassID, SectionID: req.SectionID, Status: "active"})
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := services.ImportGuardians(database.DB, students, req.Emails, emailDomain)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// respondGuardian writes a guardian with their children
+func (h *GuardianHandler) respondGuardian(c *gin.Context, status int, guardian *models.Guardian) {
+	links, err := h.guardianRepo.FindLinks([]uint{guardian.ID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(status, newGuardianResponse(*guardian, links))
+}
+
+// applyGuardianPhone sets the normalized phone number of a guardian. An
+// invalid number is answered with 400.
+func applyGuardianPhone(c *gin.Context, guardian *models.Guardian, phone string) bool {
+	if strings.TrimSpace(phone) == "" {
+		guardian.Phone = ""
+		return true
+	}
+	normalized := services.NormalizePhone(phone)
+	if normalized == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid phone number"})
+		return false
+	}
+	guardian.Phone = normalized
+	return true
+}
+
+func newGuardianResponse(guardian models.Guardian, children []models.StudentGuardian) GuardianResponse {
+	if children == nil {
+		children = []models.StudentGuardian{}
+	}
+	return GuardianResponse{Guardian: guardian, Children: children}
+}
+
+// Request Types
+type CreateGuardianRequest struct {
+	UserID  uint   `json:"user_id" binding:"required"`
+	Name    string `json:"name" binding:"required"`
+	Phone   string `json:"phone"`
+	Address string `json:"address"`
+}
+
+type UpdateGuardianRequest struct {
+	Name    string `json:"name"`
+	Phone   string `json:"phone"`
+	Address string `json:"address"`
+}
+
+type LinkStudentRequest struct {
+	StudentID    uint   `json:"student_id" binding:"required"`
+	Relationship string `json:"relationship"` // father, mother, guardian, ...
+}
+
+type ImportGuardiansRequest struct {
+	StudentIDs  []uint            `json:"student_ids"`
+	ClassID     uint              `json:"class_id"`   // used when student_ids is empty
+	SectionID   uint              `json:"section_id"` // used when student_ids is empty
+	EmailDomain string            `json:"email_domain"`
+	Emails      map[string]string `json:"emails"` // login email by parent phone number
+}
+
+// Response Types
+type GuardianResponse struct {
+	models.Guardian
+	Children []models.StudentGuardian `json:"children"`
+}
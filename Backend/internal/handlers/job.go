@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"school-erp-backend/internal/repository"
+	"school-erp-backend/pkg/database"
+	"github.com/gin-gonic/gin"
+)
+
+type JobHandler struct {
+	jobRepo *repository.JobRepository
+}
+
+func NewJobHandler() *JobHandler {
+	return &JobHandler{
+		jobRepo: repository.NewJobRepository(database.DB),
+	}
+}
+
+// GetJobs godoc
+// @Summary Get background jobs
+// @Description Get the 100 most recent background jobs with optional filters
+// @Tags Admin - Jobs
+// @Accept json
+// @Produce json
+// @Param type query string false "Filter by job type"
+// @Param status query string false "Filter by status (queued, running, completed, failed)"
+// @Success 200 {array} models.Job
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/jobs [get]
+// @Security BearerAuth
+func (h *JobHandler) GetJobs(c *gin.Context) {
+	jobs, err := h.jobRepo.FindAll(c.Query("type"), c.Query("status"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, jobs)
+}
+
+// GetJob godoc
+// @Summary Get background job by ID
+// @Description Get the status and result of a background job
+// @Tags Admin - Jobs
+// @Accept json
+// @Produce json
+// @Param id path int true "Job ID"
+// @Success 200 {object} models.Job
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/jobs/{id} [get]
+// @Security BearerAuth
+func (h *JobHandler) GetJob(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	job, err := h.jobRepo.FindByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Job not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, job)
+}
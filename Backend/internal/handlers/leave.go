@@ -0,0 +1,479 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"school-erp-backend/config"
+	"school-erp-backend/internal/models"
+	"school-erp-backend/internal/repository"
+	"school-erp-backend/internal/services"
+	"school-erp-backend/pkg/database"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// LeaveHandler serves teacher leave: teachers apply and follow their
+// requests and balances, staff with leave permissions decide the requests
+// and configure the annual entitlements.
+type LeaveHandler struct {
+	requestRepo     *repository.LeaveRequestRepository
+	entitlementRepo *repository.LeaveEntitlementRepository
+	teacherRepo     *repository.TeacherRepository
+	policy          *services.LeavePolicy
+}
+
+func NewLeaveHandler() *LeaveHandler {
+	school := services.NewSchoolCalendar(database.DB, config.AppConfig.WeeklyOffDays)
+	return &LeaveHandler{
+		requestRepo:     repository.NewLeaveRequestRepository(database.DB),
+		entitlementRepo: repository.NewLeaveEntitlementRepository(database.DB),
+		teacherRepo:     repository.NewTeacherRepository(database.DB),
+		policy:          services.NewLeavePolicy(database.DB, school, config.AppConfig.AcademicYearStartMonth),
+	}
+}
+
+// GetMyLeaveRequests godoc
+// @Summary Get own leave requests
+// @Description Get the leave requests of the logged in teacher, latest first
+// @Tags Teacher - Leave
+// @Produce json
+// @Param status query string false "Filter by status (pending, approved, rejected, cancelled)"
+// @Param academic_year query string false "Filter by academic year (e.g. 2024-2025)"
+// @Success 200 {array} models.LeaveRequest
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /teacher/leave [get]
+// @Security BearerAuth
+func (h *LeaveHandler) GetMyLeaveRequests(c *gin.Context) {
+	teacher, ok := h.teacher(c)
+	if !ok {
+		return
+	}
+
+	filter := models.LeaveRequest{
+		TeacherID:    teacher.ID,
+		Status:       c.Query("status"),
+		AcademicYear: c.Query("academic_year"),
+	}
+	requests, err := h.requestRepo.FindAll(filter, time.Time{}, time.Time{})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, requests)
+}
+
+// ApplyForLeave godoc
+// @Summary Apply for leave
+// @Description Apply for leave of an entitled type. Only the working days of the school calendar count against the balance, which must cover them after pending requests. Requests may not overlap other pending or approved ones.
+// @Tags Teacher - Leave
+// @Accept json
+// @Produce json
+// @Param request body LeaveApplicationRequest true "Leave details"
+// @Success 201 {object} models.LeaveRequest
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /teacher/leave [post]
+// @Security BearerAuth
+func (h *LeaveHandler) ApplyForLeave(c *gin.Context) {
+	teacher, ok := h.teacher(c)
+	if !ok {
+		return
+	}
+
+	var req LeaveApplicationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	startDate, err := time.Parse("2006-01-02", req.StartDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid start date. Use YYYY-MM-DD"})
+		return
+	}
+	endDate, err := time.Parse("2006-01-02", req.EndDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid end date. Use YYYY-MM-DD"})
+		return
+	}
+	if endDate.Before(startDate) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "end_date must not be before start_date"})
+		return
+	}
+
+	request := models.LeaveRequest{
+		TeacherID: teacher.ID,
+		LeaveType: strings.ToLower(strings.TrimSpace(req.LeaveType)),
+		StartDate: startDate,
+		EndDate:   endDate,
+		Reason:    req.Reason,
+	}
+	if err := h.policy.Apply(&request); err != nil {
+		respondLeaveError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, request)
+}
+
+// CancelLeaveRequest godoc
+// @Summary Cancel own leave request
+// @Description Withdraw a pending leave request of the logged in teacher
+// @Tags Teacher - Leave
+// @Produce json
+// @Param id path int true "Leave request ID"
+// @Success 200 {object} models.LeaveRequest
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /teacher/leave/{id}/cancel [post]
+// @Security BearerAuth
+func (h *LeaveHandler) CancelLeaveRequest(c *gin.Context) {
+	teacher, ok := h.teacher(c)
+	if !ok {
+		return
+	}
+
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	request, err := h.requestRepo.FindByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Leave request not found"})
+		return
+	}
+	if request.TeacherID != teacher.ID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Leave request belongs to another teacher"})
+		return
+	}
+
+	if err := h.policy.Cancel(request, time.Now()); err != nil {
+		respondLeaveError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, request)
+}
+
+// GetMyLeaveBalance godoc
+// @Summary Get own leave balance
+// @Description Get the entitled, taken, pending and remaining working days of every leave type of the logged in teacher in an academic year
+// @Tags Teacher - Leave
+// @Produce json
+// @Param academic_year query string false "Academic year (e.g. 2024-2025), defaults to the current one"
+// @Success 200 {object} LeaveBalanceResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /teacher/leave/balance [get]
+// @Security BearerAuth
+func (h *LeaveHandler) GetMyLeaveBalance(c *gin.Context) {
+	teacher, ok := h.teacher(c)
+	if !ok {
+		return
+	}
+
+	h.respondBalance(c, teacher.ID)
+}
+
+// GetLeaveRequests godoc
+// @Summary Get leave requests
+// @Description Get the leave requests of teachers, latest first. from and to keep the requests with a day in that range.
+// @Tags Admin - Leave
+// @Produce json
+// @Param teacher_id query int false "Filter by teacher ID"
+// @Param status query string false "Filter by status (pending, approved, rejected, cancelled)"
+// @Param leave_type query string false "Filter by leave type"
+// @Param academic_year query string false "Filter by academic year (e.g. 2024-2025)"
+// @Param from query string false "Start date (YYYY-MM-DD)"
+// @Param to query string false "End date (YYYY-MM-DD)"
+// @Success 200 {array} models.LeaveRequest
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/leave/requests [get]
+// @Security BearerAuth
+func (h *LeaveHandler) GetLeaveRequests(c *gin.Context) {
+	teacherID, _ := strconv.ParseUint(c.Query("teacher_id"), 10, 32)
+	filter := models.LeaveRequest{
+		TeacherID:    uint(teacherID),
+		Status:       c.Query("status"),
+		LeaveType:    c.Query("leave_type"),
+		AcademicYear: c.Query("academic_year"),
+	}
+
+	var from, to time.Time
+	if value := c.Query("from"); value != "" {
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid from date. Use YYYY-MM-DD"})
+			return
+		}
+		from = parsed
+	}
+	if value := c.Query("to"); value != "" {
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid to date. Use YYYY-MM-DD"})
+			return
+		}
+		to = parsed
+	}
+
+	requests, err := h.requestRepo.FindAll(filter, from, to)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, requests)
+}
+
+// GetLeaveRequest godoc
+// @Summary Get leave request by ID
+// @Description Get a leave request with its teacher and the user who decided it
+// @Tags Admin - Leave
+// @Produce json
+// @Param id path int true "Leave request ID"
+// @Success 200 {object} models.LeaveRequest
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/leave/requests/{id} [get]
+// @Security BearerAuth
+func (h *LeaveHandler) GetLeaveRequest(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	request, err := h.requestRepo.FindByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Leave request not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, request)
+}
+
+// ApproveLeaveRequest godoc
+// @Summary Approve a leave request
+// @Description Approve a pending leave request. Its working days are counted again and must fit in the teacher's remaining balance.
+// @Tags Admin - Leave
+// @Accept json
+// @Produce json
+// @Param id path int true "Leave request ID"
+// @Param request body LeaveDecisionRequest false "Comment"
+// @Success 200 {object} models.LeaveRequest
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /admin/leave/requests/{id}/approve [post]
+// @Security BearerAuth
+func (h *LeaveHandler) ApproveLeaveRequest(c *gin.Context) {
+	h.decide(c, true)
+}
+
+// RejectLeaveRequest godoc
+// @Summary Reject a leave request
+// @Description Reject a pending leave request, giving the reason in the comment
+// @Tags Admin - Leave
+// @Accept json
+// @Produce json
+// @Param id path int true "Leave request ID"
+// @Param request body LeaveDecisionRequest true "Comment"
+// @Success 200 {object} models.LeaveRequest
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /admin/leave/requests/{id}/reject [post]
+// @Security BearerAuth
+func (h *LeaveHandler) RejectLeaveRequest(c *gin.Context) {
+	h.decide(c, false)
+}
+
+// GetTeacherLeaveBalance godoc
+// @Summary Get leave balance of a teacher
+// @Description Get the entitled, taken, pending and remaining working days of every leave type of a teacher in an academic year
+// @Tags Admin - Leave
+// @Produce json
+// @Param id path int true "Teacher ID"
+// @Param academic_year query string false "Academic year (e.g. 2024-2025), defaults to the current one"
+// @Success 200 {object} LeaveBalanceResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/leave/balances/{id} [get]
+// @Security BearerAuth
+func (h *LeaveHandler) GetTeacherLeaveBalance(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	teacher, err := h.teacherRepo.FindByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Teacher not found"})
+		return
+	}
+
+	h.respondBalance(c, teacher.ID)
+}
+
+// GetLeaveEntitlements godoc
+// @Summary Get leave entitlements
+// @Description Get the working days of every leave type each teacher may take per academic year
+// @Tags Admin - Leave
+// @Produce json
+// @Success 200 {array} models.LeaveEntitlement
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/leave/entitlements [get]
+// @Security BearerAuth
+func (h *LeaveHandler) GetLeaveEntitlements(c *gin.Context) {
+	entitlements, err := h.entitlementRepo.FindAll()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, entitlements)
+}
+
+// SetLeaveEntitlement godoc
+// @Summary Set a leave entitlement
+// @Description Create or change the working days of a leave type each teacher may take per academic year. Requests already approved are kept.
+// @Tags Admin - Leave
+// @Accept json
+// @Produce json
+// @Param leave_type path string true "Leave type, e.g. sick"
+// @Param request body LeaveEntitlementRequest true "Days per year"
+// @Success 200 {object} models.LeaveEntitlement
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/leave/entitlements/{leave_type} [put]
+// @Security BearerAuth
+func (h *LeaveHandler) SetLeaveEntitlement(c *gin.Context) {
+	var req LeaveEntitlementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	leaveType := strings.ToLower(strings.TrimSpace(c.Param("leave_type")))
+	entitlement, err := h.entitlementRepo.FindByType(leaveType)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	entitlement.LeaveType = leaveType
+	entitlement.DaysPerYear = *req.DaysPerYear
+
+	if err := h.entitlementRepo.Save(entitlement); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, entitlement)
+}
+
+// DeleteLeaveEntitlement godoc
+// @Summary Delete a leave entitlement
+// @Description Stop teachers from applying for a leave type. Existing requests of the type are kept.
+// @Tags Admin - Leave
+// @Produce json
+// @Param leave_type path string true "Leave type"
+// @Success 200 {object} SuccessResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/leave/entitlements/{leave_type} [delete]
+// @Security BearerAuth
+func (h *LeaveHandler) DeleteLeaveEntitlement(c *gin.Context) {
+	entitlement, err := h.entitlementRepo.FindByType(strings.ToLower(c.Param("leave_type")))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Leave entitlement not found"})
+		return
+	}
+
+	if err := h.entitlementRepo.Delete(entitlement.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Leave entitlement deleted successfully"})
+}
+
+func (h *LeaveHandler) decide(c *gin.Context, approve bool) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	request, err := h.requestRepo.FindByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Leave request not found"})
+		return
+	}
+
+	var req LeaveDecisionRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	if !approve && strings.TrimSpace(req.Comment) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "A comment is required to reject a leave request"})
+		return
+	}
+
+	if err := h.policy.Decide(request, approve, c.GetUint("user_id"), req.Comment, time.Now()); err != nil {
+		respondLeaveError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, request)
+}
+
+func (h *LeaveHandler) respondBalance(c *gin.Context, teacherID uint) {
+	academicYear := academicYearOrCurrent(c)
+	balances, err := h.policy.Balances(teacherID, academicYear)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, LeaveBalanceResponse{TeacherID: teacherID, AcademicYear: academicYear, Balances: balances})
+}
+
+// teacher loads the logged in teacher. On failure it has already responded.
+func (h *LeaveHandler) teacher(c *gin.Context) (*models.Teacher, bool) {
+	teacher, err := h.teacherRepo.FindByUserID(c.GetUint("user_id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Teacher profile not found"})
+		return nil, false
+	}
+	return teacher, true
+}
+
+// respondLeaveError maps the errors of the leave policy to responses
+func respondLeaveError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrLeaveOverlap), errors.Is(err, services.ErrLeaveNotPending),
+		errors.Is(err, services.ErrInsufficientBalance):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrNoEntitlement), errors.Is(err, services.ErrNoWorkingDays),
+		errors.Is(err, services.ErrLeaveSpansYears):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// Request Types
+type LeaveApplicationRequest struct {
+	LeaveType string `json:"leave_type" binding:"required"`
+	StartDate string `json:"start_date" binding:"required"` // YYYY-MM-DD
+	EndDate   string `json:"end_date" binding:"required"`   // YYYY-MM-DD, the last day of leave
+	Reason    string `json:"reason"`
+}
+
+type LeaveDecisionRequest struct {
+	Comment string `json:"comment"` // required when rejecting
+}
+
+type LeaveEntitlementRequest struct {
+	DaysPerYear *int `json:"days_per_year" binding:"required,gte=0"`
+}
+
+// Response Types
+type LeaveBalanceResponse struct {
+	TeacherID    uint                    `json:"teacher_id"`
+	AcademicYear string                  `json:"academic_year"`
+	Balances     []services.LeaveBalance `json:"balances"`
+}
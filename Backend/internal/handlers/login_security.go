@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"school-erp-backend/internal/models"
+	"school-erp-backend/internal/repository"
+	"school-erp-backend/internal/services"
+	"school-erp-backend/pkg/database"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultLoginEventLimit = 100
+	maxLoginEventLimit     = 1000
+)
+
+type LoginSecurityHandler struct {
+	lockoutRepo    *repository.AccountLockoutRepository
+	loginEventRepo *repository.LoginEventRepository
+}
+
+func NewLoginSecurityHandler() *LoginSecurityHandler {
+	return &LoginSecurityHandler{
+		lockoutRepo:    repository.NewAccountLockoutRepository(database.DB),
+		loginEventRepo: repository.NewLoginEventRepository(database.DB),
+	}
+}
+
+// GetLockouts godoc
+// @Summary Get login lockouts
+// @Description Get the email and IP addresses with recent failed logins or password reset requests, or only those currently locked out
+// @Tags Admin - Security
+// @Produce json
+// @Param locked query bool false "Only current lockouts"
+// @Success 200 {array} LockoutResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/security/lockouts [get]
+// @Security BearerAuth
+func (h *LoginSecurityHandler) GetLockouts(c *gin.Context) {
+	lockedOnly, _ := strconv.ParseBool(c.Query("locked"))
+	now := time.Now()
+
+	lockouts, err := h.lockoutRepo.FindAll(lockedOnly, now)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	response := make([]LockoutResponse, 0, len(lockouts))
+	for _, lockout := range lockouts {
+		response = append(response, LockoutResponse{
+			AccountLockout: lockout,
+			Locked:         services.IsLocked(&lockout, now),
+		})
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ClearLockout godoc
+// @Summary Clear a login lockout
+// @Description Forget the failed logins of an email or IP address, lifting its lockout
+// @Tags Admin - Security
+// @Produce json
+// @Param id path int true "Lockout ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/security/lockouts/{id} [delete]
+// @Security BearerAuth
+func (h *LoginSecurityHandler) ClearLockout(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	if _, err := h.lockoutRepo.FindByID(uint(id)); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Lockout not found"})
+		return
+	}
+
+	if err := h.lockoutRepo.Delete(uint(id)); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Lockout cleared successfully"})
+}
+
+// GetLoginEvents godoc
+// @Summary Get login events
+// @Description Get recorded login attempts with optional filters, newest first
+// @Tags Admin - Security
+// @Produce json
+// @Param user_id query int false "Filter by user ID"
+// @Param email query string false "Filter by email"
+// @Param ip_address query string false "Filter by IP address"
+// @Param success query bool false "Filter by outcome"
+// @Param from query string false "From date (YYYY-MM-DD)"
+// @Param to query string false "To date, inclusive (YYYY-MM-DD)"
+// @Param limit query int false "Maximum number of events (default 100, at most 1000)"
+// @Success 200 {array} models.LoginEvent
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/security/login-events [get]
+// @Security BearerAuth
+func (h *LoginSecurityHandler) GetLoginEvents(c *gin.Context) {
+	filter := models.LoginEvent{
+		Email:     strings.ToLower(strings.TrimSpace(c.Query("email"))),
+		IPAddress: c.Query("ip_address"),
+	}
+	if value, err := strconv.ParseUint(c.Query("user_id"), 10, 32); err == nil {
+		userID := uint(value)
+		filter.UserID = &userID
+	}
+
+	var success *bool
+	if value, err := strconv.ParseBool(c.Query("success")); err == nil {
+		success = &value
+	}
+
+	var from, to time.Time
+	if value := c.Query("from"); value != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", value, time.Local)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid from date. Use YYYY-MM-DD"})
+			return
+		}
+		from = parsed
+	}
+	if value := c.Query("to"); value != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", value, time.Local)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid to date. Use YYYY-MM-DD"})
+			return
+		}
+		to = parsed.AddDate(0, 0, 1)
+	}
+
+	limit := defaultLoginEventLimit
+	if value, err := strconv.Atoi(c.Query("limit")); err == nil && value > 0 {
+		limit = value
+	}
+	if limit > maxLoginEventLimit {
+		limit = maxLoginEventLimit
+	}
+
+	events, err := h.loginEventRepo.FindAll(filter, success, from, to, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, events)
+}
+
+// Response Types
+type LockoutResponse struct {
+	models.AccountLockout
+	Locked bool `json:"locked"` // whether the lockout is in force now
+}
@@ -0,0 +1,259 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"school-erp-backend/internal/models"
+	"school-erp-backend/internal/repository"
+	"school-erp-backend/internal/services"
+	"school-erp-backend/pkg/database"
+	"github.com/gin-gonic/gin"
+)
+
+type MarkHandler struct {
+	markRepo    *repository.MarkRepository
+	examRepo    *repository.ExamRepository
+	studentRepo *repository.StudentRepository
+	scaleRepo   *repository.GradingScaleRepository
+	access      teachingAccess
+}
+
+func NewMarkHandler() *MarkHandler {
+	return &MarkHandler{
+		markRepo:    repository.NewMarkRepository(database.DB),
+		examRepo:    repository.NewExamRepository(database.DB),
+		studentRepo: repository.NewStudentRepository(database.DB),
+		scaleRepo:   repository.NewGradingScaleRepository(database.DB),
+		access:      newTeachingAccess(),
+	}
+}
+
+// GetExamMarks godoc
+// @Summary Get marks of an exam
+// @Description Get the students of the exam's class (optionally one section) with the marks entered so far. Exams of an elective list the enrolled students only. Teachers must give a section in which they teach the exam's subject.
+// @Tags Marks
+// @Accept json
+// @Produce json
+// @Param id path int true "Exam ID"
+// @Param section_id query int false "Section ID"
+// @Success 200 {object} ExamMarksResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /teacher/exams/{id}/marks [get]
+// @Router /admin/exams/{id}/marks [get]
+// @Security BearerAuth
+func (h *MarkHandler) GetExamMarks(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	scope, ok := requestScope(c)
+	if !ok {
+		return
+	}
+
+	exam, err := h.examRepo.Scoped(scope).FindByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Exam not found"})
+		return
+	}
+
+	var sectionID uint
+	if value := c.Query("section_id"); value != "" {
+		parsed, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid section ID"})
+			return
+		}
+		sectionID = uint(parsed)
+		if !h.access.allow(c, exam.ClassID, sectionID, exam.SubjectID, exam.AcademicYear) {
+			return
+		}
+	} else if c.GetString("user_role") == "teacher" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "section_id is required"})
+		return
+	}
+
+	students, err := services.SubjectRoster(database.DB, exam.ClassID, sectionID, exam.SubjectID, exam.AcademicYear)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	marks, err := h.markRepo.Scoped(scope).FindByExam(exam.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	markByStudent := make(map[uint]models.Mark, len(marks))
+	for _, mark := range marks {
+		markByStudent[mark.StudentID] = mark
+	}
+
+	entries := make([]StudentMarkEntry, 0, len(students))
+	for _, student := range students {
+		entry := StudentMarkEntry{
+			StudentID:       student.ID,
+			AdmissionNumber: student.AdmissionNumber,
+			FirstName:       student.FirstName,
+			LastName:        student.LastName,
+			SectionID:       student.SectionID,
+		}
+		if mark, ok := markByStudent[student.ID]; ok {
+			marksObtained := mark.MarksObtained
+			entry.MarksObtained = &marksObtained
+			entry.Percentage = mark.Percentage
+			entry.Grade = mark.Grade
+			entry.GradePoint = mark.GradePoint
+		}
+		entries = append(entries, entry)
+	}
+
+	c.JSON(http.StatusOK, ExamMarksResponse{
+		Exam:      *exam,
+		SectionID: sectionID,
+		Students:  entries,
+	})
+}
+
+// EnterMarks godoc
+// @Summary Enter marks for a section
+// @Description Enter or correct marks for students of a section in one batch. Percentage and grade are computed from the grading scale assigned to the class for the exam's academic year. Rejected once the exam results are published, and for sections in which the teacher does not teach the exam's subject. Marks of an elective can only be entered for the enrolled students.
+// @Tags Marks
+// @Accept json
+// @Produce json
+// @Param id path int true "Exam ID"
+// @Param marks body EnterMarksRequest true "Marks data"
+// @Success 200 {array} models.Mark
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /teacher/exams/{id}/marks [post]
+// @Security BearerAuth
+func (h *MarkHandler) EnterMarks(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	var req EnterMarksRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	scope, ok := requestScope(c)
+	if !ok {
+		return
+	}
+
+	exam, err := h.examRepo.Scoped(scope).FindByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Exam not found"})
+		return
+	}
+
+	if exam.Status == "published" {
+		c.JSON(http.StatusConflict, gin.H{"error": "Exam results are published and locked"})
+		return
+	}
+
+	if !h.access.allow(c, exam.ClassID, req.SectionID, exam.SubjectID, exam.AcademicYear) {
+		return
+	}
+
+	students, err := services.SubjectRoster(database.DB, exam.ClassID, req.SectionID, exam.SubjectID, exam.AcademicYear)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	inSection := make(map[uint]bool, len(students))
+	for _, student := range students {
+		inSection[student.ID] = true
+	}
+
+	bands, err := services.GradeBandsFor(h.scaleRepo, exam.ClassID, exam.AcademicYear)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	createdBy := c.GetUint("user_id")
+	seen := make(map[uint]bool, len(req.Marks))
+	marks := make([]models.Mark, 0, len(req.Marks))
+	for _, entry := range req.Marks {
+		studentID := strconv.FormatUint(uint64(entry.StudentID), 10)
+		if !inSection[entry.StudentID] {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Student " + studentID + " does not belong to this class and section or does not take the subject"})
+			return
+		}
+		if seen[entry.StudentID] {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Duplicate marks entry for student " + studentID})
+			return
+		}
+		seen[entry.StudentID] = true
+
+		if *entry.MarksObtained > exam.TotalMarks {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Marks for student " + studentID + " exceed the total marks of the exam"})
+			return
+		}
+
+		percentage := services.Percentage(*entry.MarksObtained, exam.TotalMarks)
+		grade, gradePoint := services.GradeFor(bands, percentage)
+		marks = append(marks, models.Mark{
+			StudentID:     entry.StudentID,
+			SubjectID:     exam.SubjectID,
+			ExamID:        exam.ID,
+			ExamType:      exam.ExamType,
+			MarksObtained: *entry.MarksObtained,
+			TotalMarks:    exam.TotalMarks,
+			Percentage:    percentage,
+			Grade:         grade,
+			GradePoint:    gradePoint,
+			AcademicYear:  exam.AcademicYear,
+			CreatedBy:     createdBy,
+		})
+	}
+
+	err = services.SaveExamMarks(database.DB, exam.ID, marks)
+	if errors.Is(err, services.ErrExamPublished) {
+		c.JSON(http.StatusConflict, gin.H{"error": "Exam results are published and locked"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, marks)
+}
+
+// Request Types
+type EnterMarksRequest struct {
+	SectionID uint               `json:"section_id" binding:"required"`
+	Marks     []StudentMarkInput `json:"marks" binding:"required,min=1,dive"`
+}
+
+type StudentMarkInput struct {
+	StudentID     uint     `json:"student_id" binding:"required"`
+	MarksObtained *float64 `json:"marks_obtained" binding:"required,gte=0"`
+}
+
+// Response Types
+type ExamMarksResponse struct {
+	Exam      models.Exam        `json:"exam"`
+	SectionID uint               `json:"section_id,omitempty"`
+	Students  []StudentMarkEntry `json:"students"`
+}
+
+type StudentMarkEntry struct {
+	StudentID       uint     `json:"student_id"`
+	AdmissionNumber string   `json:"admission_number"`
+	FirstName       string   `json:"first_name"`
+	LastName        string   `json:"last_name"`
+	SectionID       uint     `json:"section_id"`
+	MarksObtained   *float64 `json:"marks_obtained"`
+	Percentage      float64  `json:"percentage,omitempty"`
+	Grade           string   `json:"grade,omitempty"`
+	GradePoint      float64  `json:"grade_point,omitempty"`
+}
@@ -0,0 +1,467 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"school-erp-backend/internal/models"
+	"school-erp-backend/internal/repository"
+	"school-erp-backend/internal/services"
+	"school-erp-backend/pkg/database"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultNoticeFeedLimit = 50
+	maxNoticeFeedLimit     = 200
+)
+
+// NoticeHandler publishes notices and serves the notice feed of every user.
+// Staff with notices.write publish to anyone; teachers publish to the classes
+// and sections they teach and only manage their own notices.
+type NoticeHandler struct {
+	noticeRepo  *repository.NoticeRepository
+	sectionRepo *repository.SectionRepository
+	access      teachingAccess
+}
+
+func NewNoticeHandler() *NoticeHandler {
+	return &NoticeHandler{
+		noticeRepo:  repository.NewNoticeRepository(database.DB),
+		sectionRepo: repository.NewSectionRepository(database.DB),
+		access:      newTeachingAccess(),
+	}
+}
+
+// GetNotices godoc
+// @Summary Get notices
+// @Description Get published and scheduled notices, newest first. Teachers get the notices they published.
+// @Tags Notices
+// @Produce json
+// @Param created_by query int false "Filter by publishing user ID"
+// @Param priority query string false "Filter by priority (low, normal, high, urgent)"
+// @Param category query string false "Filter by category"
+// @Param status query string false "published or scheduled"
+// @Success 200 {array} models.Notice
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/notices [get]
+// @Security BearerAuth
+func (h *NoticeHandler) GetNotices(c *gin.Context) {
+	createdBy, _ := strconv.ParseUint(c.Query("created_by"), 10, 32)
+	filter := models.Notice{
+		CreatedBy: uint(createdBy),
+		Priority:  c.Query("priority"),
+		Category:  c.Query("category"),
+	}
+	if c.GetString("user_role") == "teacher" {
+		filter.CreatedBy = c.GetUint("user_id")
+	}
+
+	notices, err := h.noticeRepo.FindAll(filter, c.Query("status"), time.Now())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, notices)
+}
+
+// GetNotice godoc
+// @Summary Get notice by ID
+// @Description Get a notice with its targets
+// @Tags Notices
+// @Produce json
+// @Param id path int true "Notice ID"
+// @Success 200 {object} models.Notice
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/notices/{id} [get]
+// @Security BearerAuth
+func (h *NoticeHandler) GetNotice(c *gin.Context) {
+	notice, ok := h.ownNotice(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, notice)
+}
+
+// CreateNotice godoc
+// @Summary Publish a notice
+// @Description Publish a notice to everyone or to roles, classes, sections and users. A future published_at schedules the notice; feeds show it from then on.
+// @Tags Notices
+// @Accept json
+// @Produce json
+// @Param request body NoticeRequest true "Notice details"
+// @Success 201 {object} models.Notice
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /admin/notices [post]
+// @Security BearerAuth
+func (h *NoticeHandler) CreateNotice(c *gin.Context) {
+	var req NoticeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	notice := models.Notice{CreatedBy: c.GetUint("user_id")}
+	if !h.apply(c, &notice, req) {
+		return
+	}
+
+	if err := h.noticeRepo.Create(&notice); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, notice)
+}
+
+// UpdateNotice godoc
+// @Summary Update a notice
+// @Description Replace the content, priority, publishing time and audience of a notice. Reads already recorded are kept.
+// @Tags Notices
+// @Accept json
+// @Produce json
+// @Param id path int true "Notice ID"
+// @Param request body NoticeRequest true "Notice details"
+// @Success 200 {object} models.Notice
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/notices/{id} [put]
+// @Security BearerAuth
+func (h *NoticeHandler) UpdateNotice(c *gin.Context) {
+	notice, ok := h.ownNotice(c)
+	if !ok {
+		return
+	}
+
+	var req NoticeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !h.apply(c, notice, req) {
+		return
+	}
+
+	if err := h.noticeRepo.Update(notice); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, notice)
+}
+
+// DeleteNotice godoc
+// @Summary Delete a notice
+// @Description Delete a notice with its targets and reads
+// @Tags Notices
+// @Produce json
+// @Param id path int true "Notice ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/notices/{id} [delete]
+// @Security BearerAuth
+func (h *NoticeHandler) DeleteNotice(c *gin.Context) {
+	notice, ok := h.ownNotice(c)
+	if !ok {
+		return
+	}
+
+	if err := h.noticeRepo.Delete(notice.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Notice deleted successfully"})
+}
+
+// GetNoticeReads godoc
+// @Summary Get read receipts of a notice
+// @Description Count how many of the active users a notice is published to have read it, and list those who have not
+// @Tags Notices
+// @Produce json
+// @Param id path int true "Notice ID"
+// @Success 200 {object} NoticeReadReport
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/notices/{id}/reads [get]
+// @Security BearerAuth
+func (h *NoticeHandler) GetNoticeReads(c *gin.Context) {
+	notice, ok := h.ownNotice(c)
+	if !ok {
+		return
+	}
+
+	recipients, err := h.noticeRepo.Recipients(notice)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	readUserIDs, err := h.noticeRepo.ReadUserIDs(notice.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	read := make(map[uint]bool, len(readUserIDs))
+	for _, userID := range readUserIDs {
+		read[userID] = true
+	}
+	report := NoticeReadReport{
+		NoticeID:   notice.ID,
+		Recipients: len(recipients),
+		Unread:     []NoticeRecipient{},
+	}
+	for _, user := range recipients {
+		if read[user.ID] {
+			report.Read++
+			continue
+		}
+		report.Unread = append(report.Unread, NoticeRecipient{UserID: user.ID, Email: user.Email, Role: user.Role})
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// GetFeed godoc
+// @Summary Get own notice feed
+// @Description Get the notices published to the logged in user through any of their roles, their class and section, the classes and sections of their children, or to them directly. Urgent notices come first, then the newest.
+// @Tags Notices
+// @Produce json
+// @Param unread query bool false "Only notices not read yet"
+// @Param limit query int false "Maximum number of notices (default 50, at most 200)"
+// @Param offset query int false "Number of notices to skip"
+// @Success 200 {array} NoticeFeedEntry
+// @Failure 500 {object} ErrorResponse
+// @Router /notices [get]
+// @Security BearerAuth
+func (h *NoticeHandler) GetFeed(c *gin.Context) {
+	reader, ok := h.reader(c)
+	if !ok {
+		return
+	}
+
+	unreadOnly, _ := strconv.ParseBool(c.Query("unread"))
+	limit := defaultNoticeFeedLimit
+	if value, err := strconv.Atoi(c.Query("limit")); err == nil && value > 0 {
+		limit = value
+	}
+	if limit > maxNoticeFeedLimit {
+		limit = maxNoticeFeedLimit
+	}
+	offset, _ := strconv.Atoi(c.Query("offset"))
+	if offset < 0 {
+		offset = 0
+	}
+
+	notices, err := h.noticeRepo.Feed(reader, time.Now(), unreadOnly, limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	noticeIDs := make([]uint, len(notices))
+	for i, notice := range notices {
+		noticeIDs[i] = notice.ID
+	}
+	reads, err := h.noticeRepo.FindReads(reader.UserID, noticeIDs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	readAt := make(map[uint]time.Time, len(reads))
+	for _, read := range reads {
+		readAt[read.NoticeID] = read.ReadAt
+	}
+	entries := make([]NoticeFeedEntry, 0, len(notices))
+	for _, notice := range notices {
+		entry := NoticeFeedEntry{Notice: notice}
+		if at, ok := readAt[notice.ID]; ok {
+			entry.Read = true
+			entry.ReadAt = &at
+		}
+		entries = append(entries, entry)
+	}
+
+	c.JSON(http.StatusOK, entries)
+}
+
+// GetUnreadCount godoc
+// @Summary Count unread notices
+// @Description Count the notices in the feed of the logged in user that they have not read, in total and of urgent priority
+// @Tags Notices
+// @Produce json
+// @Success 200 {object} UnreadNoticeCount
+// @Failure 500 {object} ErrorResponse
+// @Router /notices/unread-count [get]
+// @Security BearerAuth
+func (h *NoticeHandler) GetUnreadCount(c *gin.Context) {
+	reader, ok := h.reader(c)
+	if !ok {
+		return
+	}
+
+	unread, urgent, err := h.noticeRepo.CountUnread(reader, time.Now())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, UnreadNoticeCount{Unread: unread, Urgent: urgent})
+}
+
+// MarkNoticeRead godoc
+// @Summary Mark a notice as read
+// @Description Record that the logged in user read a notice in their feed. Marking it again keeps the first read time.
+// @Tags Notices
+// @Produce json
+// @Param id path int true "Notice ID"
+// @Success 200 {object} models.NoticeRead
+// @Failure 404 {object} ErrorResponse
+// @Router /notices/{id}/read [post]
+// @Security BearerAuth
+func (h *NoticeHandler) MarkNoticeRead(c *gin.Context) {
+	reader, ok := h.reader(c)
+	if !ok {
+		return
+	}
+
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	now := time.Now()
+	notice, err := h.noticeRepo.FindVisible(reader, uint(id), now)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Notice not found"})
+		return
+	}
+
+	read, err := h.noticeRepo.MarkRead(notice.ID, reader.UserID, now)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, read)
+}
+
+// apply copies a notice request onto a notice. Teachers may only publish to
+// the classes and sections they teach this academic year. On failure it has
+// already responded.
+func (h *NoticeHandler) apply(c *gin.Context, notice *models.Notice, req NoticeRequest) bool {
+	priority := strings.ToLower(req.Priority)
+	if priority == "" {
+		priority = "normal"
+	}
+	if !services.IsNoticePriority(priority) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Priority must be one of " + strings.Join(services.NoticePriorities, ", ")})
+		return false
+	}
+
+	if c.GetString("user_role") == "teacher" {
+		audience := req.Audience
+		if audience.Everyone || len(audience.Roles) > 0 || len(audience.UserIDs) > 0 {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Teachers can only publish notices to the classes and sections they teach"})
+			return false
+		}
+		academicYear := academicYearOf(time.Now())
+		for _, classID := range audience.ClassIDs {
+			if !h.access.allow(c, classID, 0, 0, academicYear) {
+				return false
+			}
+		}
+		for _, sectionID := range audience.SectionIDs {
+			section, err := h.sectionRepo.FindByID(sectionID)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "unknown section in audience"})
+				return false
+			}
+			if !h.access.allow(c, section.ClassID, section.ID, 0, academicYear) {
+				return false
+			}
+		}
+	}
+
+	if err := services.SetNoticeAudience(database.DB, notice, req.Audience); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return false
+	}
+
+	notice.Title = req.Title
+	notice.Content = req.Content
+	notice.Category = req.Category
+	notice.Priority = priority
+	notice.PublishedAt = time.Now()
+	if req.PublishedAt != nil {
+		notice.PublishedAt = *req.PublishedAt
+	}
+	return true
+}
+
+// ownNotice loads the notice in the id path parameter. Teachers may only
+// manage the notices they published.
+func (h *NoticeHandler) ownNotice(c *gin.Context) (*models.Notice, bool) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	notice, err := h.noticeRepo.FindByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Notice not found"})
+		return nil, false
+	}
+	if c.GetString("user_role") == "teacher" && notice.CreatedBy != c.GetUint("user_id") {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Notice was published by someone else"})
+		return nil, false
+	}
+	return notice, true
+}
+
+// reader returns who the logged in user reads notices as. On failure it has
+// already responded.
+func (h *NoticeHandler) reader(c *gin.Context) (repository.NoticeReader, bool) {
+	reader, err := services.NoticeReaderOf(database.DB, c.GetUint("user_id"), c.GetString("user_role"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return reader, false
+	}
+	return reader, true
+}
+
+// Request Types
+type NoticeRequest struct {
+	Title       string                  `json:"title" binding:"required"`
+	Content     string                  `json:"content"`
+	Category    string                  `json:"category"`
+	Priority    string                  `json:"priority"`     // low, normal (default), high, urgent
+	PublishedAt *time.Time              `json:"published_at"` // defaults to now; a future time schedules the notice
+	Audience    services.NoticeAudience `json:"audience"`
+}
+
+// Response Types
+type NoticeFeedEntry struct {
+	models.Notice
+	Read   bool       `json:"read"`
+	ReadAt *time.Time `json:"read_at,omitempty"`
+}
+
+type UnreadNoticeCount struct {
+	Unread int64 `json:"unread"`
+	Urgent int64 `json:"urgent"`
+}
+
+type NoticeReadReport struct {
+	NoticeID   uint              `json:"notice_id"`
+	Recipients int               `json:"recipients"` // active users the notice is published to
+	Read       int               `json:"read"`
+	Unread     []NoticeRecipient `json:"unread"`
+}
+
+type NoticeRecipient struct {
+	UserID uint   `json:"user_id"`
+	Email  string `json:"email"`
+	Role   string `json:"role"`
+}
@@ -0,0 +1,218 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"school-erp-backend/internal/models"
+	"school-erp-backend/internal/repository"
+	"school-erp-backend/internal/services"
+	"school-erp-backend/pkg/database"
+	"github.com/gin-gonic/gin"
+)
+
+// ParentHandler serves the read-only parent portal. Every read goes through
+// repositories scoped to the children of the logged in guardian.
+type ParentHandler struct {
+	guardianRepo   *repository.GuardianRepository
+	studentRepo    *repository.StudentRepository
+	attendanceRepo *repository.AttendanceRepository
+	markRepo       *repository.MarkRepository
+	assignmentRepo *repository.AssignmentRepository
+	submissionRepo *repository.AssignmentSubmissionRepository
+	timetableRepo  *repository.TimetableRepository
+}
+
+func NewParentHandler() *ParentHandler {
+	return &ParentHandler{
+		guardianRepo:   repository.NewGuardianRepository(database.DB),
+		studentRepo:    repository.NewStudentRepository(database.DB),
+		attendanceRepo: repository.NewAttendanceRepository(database.DB),
+		markRepo:       repository.NewMarkRepository(database.DB),
+		assignmentRepo: repository.NewAssignmentRepository(database.DB),
+		submissionRepo: repository.NewAssignmentSubmissionRepository(database.DB),
+		timetableRepo:  repository.NewTimetableRepository(database.DB),
+	}
+}
+
+// GetChildren godoc
+// @Summary Get own children
+// @Description Get the students linked to the logged in guardian
+// @Tags Parent
+// @Produce json
+// @Success 200 {array} models.Student
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /parent/children [get]
+// @Security BearerAuth
+func (h *ParentHandler) GetChildren(c *gin.Context) {
+	scope, ok := requestScope(c)
+	if !ok {
+		return
+	}
+
+	children, err := h.guardianRepo.Children(scope.GuardianID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, children)
+}
+
+// GetChildAttendance godoc
+// @Summary Get attendance of a child
+// @Description Get the attendance summary and monthly breakdown of one of the guardian's children over a date range
+// @Tags Parent
+// @Produce json
+// @Param id path int true "Student ID"
+// @Param from query string true "Start date (YYYY-MM-DD)"
+// @Param to query string true "End date (YYYY-MM-DD)"
+// @Success 200 {object} StudentAttendanceReport
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /parent/children/{id}/attendance [get]
+// @Security BearerAuth
+func (h *ParentHandler) GetChildAttendance(c *gin.Context) {
+	child, scope, ok := h.child(c)
+	if !ok {
+		return
+	}
+
+	from, to, ok := parseDateRange(c)
+	if !ok {
+		return
+	}
+
+	records, err := h.attendanceRepo.Scoped(scope).FindByStudent(child.ID, from, to)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, StudentAttendanceReport{
+		StudentAttendanceRow: StudentAttendanceRow{
+			StudentID:         child.ID,
+			AdmissionNumber:   child.AdmissionNumber,
+			FirstName:         child.FirstName,
+			LastName:          child.LastName,
+			AttendanceSummary: services.SummarizeAttendance(records),
+		},
+		From:    from.Format("2006-01-02"),
+		To:      to.Format("2006-01-02"),
+		Monthly: services.MonthlyAttendanceBreakdown(records),
+	})
+}
+
+// GetChildMarks godoc
+// @Summary Get marks of a child
+// @Description Get the marks of one of the guardian's children in exams whose results are published
+// @Tags Parent
+// @Produce json
+// @Param id path int true "Student ID"
+// @Param academic_year query string false "Academic year (e.g. 2024-2025), defaults to the current one"
+// @Success 200 {array} models.Mark
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /parent/children/{id}/marks [get]
+// @Security BearerAuth
+func (h *ParentHandler) GetChildMarks(c *gin.Context) {
+	child, scope, ok := h.child(c)
+	if !ok {
+		return
+	}
+
+	marks, err := h.markRepo.Scoped(scope).FindPublishedByStudent(child.ID, academicYearOrCurrent(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, marks)
+}
+
+// GetChildAssignments godoc
+// @Summary Get assignments of a child
+// @Description Get the assignments of the class of one of the guardian's children with the child's submissions
+// @Tags Parent
+// @Produce json
+// @Param id path int true "Student ID"
+// @Param subject_id query int false "Filter by subject ID"
+// @Success 200 {array} StudentAssignmentEntry
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /parent/children/{id}/assignments [get]
+// @Security BearerAuth
+func (h *ParentHandler) GetChildAssignments(c *gin.Context) {
+	child, scope, ok := h.child(c)
+	if !ok {
+		return
+	}
+
+	subjectID, _ := strconv.ParseUint(c.Query("subject_id"), 10, 32)
+	assignments, err := h.assignmentRepo.Scoped(scope).FindByClass(child.ClassID, uint(subjectID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	assignmentIDs := make([]uint, len(assignments))
+	for i, assignment := range assignments {
+		assignmentIDs[i] = assignment.ID
+	}
+	submissions, err := h.submissionRepo.Scoped(scope).FindByStudent(child.ID, assignmentIDs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, studentAssignmentEntries(assignments, submissions))
+}
+
+// GetChildTimetable godoc
+// @Summary Get week timetable of a child
+// @Description Get the week grid of the section of one of the guardian's children for an academic year (defaults to the current one)
+// @Tags Parent
+// @Produce json
+// @Param id path int true "Student ID"
+// @Param academic_year query string false "Academic year (e.g. 2024-2025)"
+// @Success 200 {object} WeekTimetableResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /parent/children/{id}/timetable [get]
+// @Security BearerAuth
+func (h *ParentHandler) GetChildTimetable(c *gin.Context) {
+	child, scope, ok := h.child(c)
+	if !ok {
+		return
+	}
+
+	academicYear := academicYearOrCurrent(c)
+	slots, err := h.timetableRepo.Scoped(scope).FindBySection(child.SectionID, academicYear)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, WeekTimetableResponse{
+		AcademicYear: academicYear,
+		SectionID:    child.SectionID,
+		Days:         services.WeekGrid(slots),
+	})
+}
+
+// child loads the student in the id path parameter through the scope of the
+// guardian, so students who are not their children are not found.
+func (h *ParentHandler) child(c *gin.Context) (*models.Student, repository.Scope, bool) {
+	scope, ok := requestScope(c)
+	if !ok {
+		return nil, scope, false
+	}
+
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	child, err := h.studentRepo.Scoped(scope).FindByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Student not found"})
+		return nil, scope, false
+	}
+	return child, scope, true
+}
@@ -0,0 +1,366 @@
+package handlers
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"school-erp-backend/config"
+	"school-erp-backend/internal/models"
+	"school-erp-backend/internal/repository"
+	"school-erp-backend/internal/services"
+	"school-erp-backend/pkg/database"
+	"github.com/gin-gonic/gin"
+)
+
+type ReportCardHandler struct {
+	studentRepo *repository.StudentRepository
+	sectionRepo *repository.SectionRepository
+	remarkRepo  *repository.ReportCardRemarkRepository
+	access      teachingAccess
+}
+
+func NewReportCardHandler() *ReportCardHandler {
+	return &ReportCardHandler{
+		studentRepo: repository.NewStudentRepository(database.DB),
+		sectionRepo: repository.NewSectionRepository(database.DB),
+		remarkRepo:  repository.NewReportCardRemarkRepository(database.DB),
+		access:      newTeachingAccess(),
+	}
+}
+
+// GetStudentReportCard godoc
+// @Summary Generate the report card of a student
+// @Description Generate the report card of a student for an academic year and exam type from the marks of published exams, the attendance of the academic year and the class teacher's remarks. Returned as a PDF unless format=json.
+// @Tags Report Cards
+// @Accept json
+// @Produce application/pdf
+// @Produce json
+// @Param id path int true "Student ID"
+// @Param academic_year query string true "Academic year (e.g. 2024-2025)"
+// @Param exam_type query string true "Exam type (e.g. midterm, final)"
+// @Param format query string false "pdf (default) or json"
+// @Success 200 {file} binary
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/report-cards/student/{id} [get]
+// @Security BearerAuth
+func (h *ReportCardHandler) GetStudentReportCard(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	academicYear, examType, ok := parseReportCardTerm(c)
+	if !ok {
+		return
+	}
+	scope, ok := requestScope(c)
+	if !ok {
+		return
+	}
+
+	student, err := h.studentRepo.Scoped(scope).FindByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Student not found"})
+		return
+	}
+
+	card, err := services.BuildReportCard(database.DB, *student, academicYear, examType, config.AppConfig.AcademicYearStartMonth)
+	if errors.Is(err, services.ErrNoResults) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "No published results for this student, academic year and exam type"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if c.Query("format") == "json" {
+		c.JSON(http.StatusOK, card)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, reportCardFileName(card)))
+	c.Data(http.StatusOK, "application/pdf", services.RenderReportCardPDF(card, reportCardSchool()))
+}
+
+// GetSectionReportCards godoc
+// @Summary Generate report cards of a section
+// @Description Generate the report cards of every student of a section as PDFs in a ZIP archive. Students without published results are listed in skipped.txt inside the archive.
+// @Tags Report Cards
+// @Accept json
+// @Produce application/zip
+// @Param class_id query int true "Class ID"
+// @Param section_id query int true "Section ID"
+// @Param academic_year query string true "Academic year (e.g. 2024-2025)"
+// @Param exam_type query string true "Exam type (e.g. midterm, final)"
+// @Success 200 {file} binary
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/report-cards/section [get]
+// @Security BearerAuth
+func (h *ReportCardHandler) GetSectionReportCards(c *gin.Context) {
+	classID, sectionID, ok := parseClassSection(c)
+	if !ok {
+		return
+	}
+
+	academicYear, examType, ok := parseReportCardTerm(c)
+	if !ok {
+		return
+	}
+	scope, ok := requestScope(c)
+	if !ok {
+		return
+	}
+
+	section, err := h.sectionRepo.Scoped(scope).FindByID(sectionID)
+	if err != nil || section.ClassID != classID {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Section not found in this class"})
+		return
+	}
+
+	students, err := h.studentRepo.Scoped(scope).FindByClassAndSection(classID, sectionID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	school := reportCardSchool()
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	var generated int
+	var skipped []string
+	for _, student := range students {
+		student.Class = section.Class
+		student.Section = *section
+
+		card, err := services.BuildReportCard(database.DB, student, academicYear, examType, config.AppConfig.AcademicYearStartMonth)
+		if errors.Is(err, services.ErrNoResults) {
+			skipped = append(skipped, student.AdmissionNumber+" "+student.FirstName+" "+student.LastName)
+			continue
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		file, err := archive.Create(reportCardFileName(card))
+		if err == nil {
+			_, err = file.Write(services.RenderReportCardPDF(card, school))
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		generated++
+	}
+
+	if generated == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "No published results for this section, academic year and exam type"})
+		return
+	}
+
+	if len(skipped) > 0 {
+		file, err := archive.Create("skipped.txt")
+		if err == nil {
+			_, err = file.Write([]byte("No published results:\n" + strings.Join(skipped, "\n") + "\n"))
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	name := fmt.Sprintf("report-cards-%s-%s-%s-%s.zip", section.Class.Name, section.Name, academicYear, examType)
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, safeFileName(name)))
+	c.Data(http.StatusOK, "application/zip", buf.Bytes())
+}
+
+// GetRemarks godoc
+// @Summary Get report card remarks of a section
+// @Description Get the students of a section with their report card remarks for an academic year and exam type. Teachers may only open sections they teach.
+// @Tags Report Cards
+// @Accept json
+// @Produce json
+// @Param class_id query int true "Class ID"
+// @Param section_id query int true "Section ID"
+// @Param academic_year query string true "Academic year (e.g. 2024-2025)"
+// @Param exam_type query string true "Exam type (e.g. midterm, final)"
+// @Success 200 {array} ReportCardRemarkEntry
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /teacher/report-cards/remarks [get]
+// @Router /admin/report-cards/remarks [get]
+// @Security BearerAuth
+func (h *ReportCardHandler) GetRemarks(c *gin.Context) {
+	classID, sectionID, ok := parseClassSection(c)
+	if !ok {
+		return
+	}
+
+	academicYear, examType, ok := parseReportCardTerm(c)
+	if !ok {
+		return
+	}
+
+	if !h.access.allow(c, classID, sectionID, 0, academicYear) {
+		return
+	}
+	scope, ok := requestScope(c)
+	if !ok {
+		return
+	}
+
+	students, err := h.studentRepo.Scoped(scope).FindByClassAndSection(classID, sectionID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	studentIDs := make([]uint, len(students))
+	for i, student := range students {
+		studentIDs[i] = student.ID
+	}
+
+	remarks, err := h.remarkRepo.Scoped(scope).FindByStudents(studentIDs, academicYear, examType)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	remarkByStudent := make(map[uint]string, len(remarks))
+	for _, remark := range remarks {
+		remarkByStudent[remark.StudentID] = remark.Remarks
+	}
+
+	entries := make([]ReportCardRemarkEntry, 0, len(students))
+	for _, student := range students {
+		entries = append(entries, ReportCardRemarkEntry{
+			StudentID:       student.ID,
+			AdmissionNumber: student.AdmissionNumber,
+			FirstName:       student.FirstName,
+			LastName:        student.LastName,
+			Remarks:         remarkByStudent[student.ID],
+		})
+	}
+
+	c.JSON(http.StatusOK, entries)
+}
+
+// SaveRemark godoc
+// @Summary Save report card remarks
+// @Description Create or replace the class teacher's remarks printed on a student's report card. Teachers may only save remarks for sections they are the class teacher of.
+// @Tags Report Cards
+// @Accept json
+// @Produce json
+// @Param remark body SaveRemarkRequest true "Remark data"
+// @Success 200 {object} models.ReportCardRemark
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /teacher/report-cards/remarks [put]
+// @Router /admin/report-cards/remarks [put]
+// @Security BearerAuth
+func (h *ReportCardHandler) SaveRemark(c *gin.Context) {
+	var req SaveRemarkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if _, _, err := services.AcademicYearRange(req.AcademicYear, config.AppConfig.AcademicYearStartMonth); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid academic year. Use e.g. 2024-2025"})
+		return
+	}
+	scope, ok := requestScope(c)
+	if !ok {
+		return
+	}
+
+	student, err := h.studentRepo.Scoped(scope).FindByID(req.StudentID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Student not found"})
+		return
+	}
+
+	if !h.access.allowClassTeacher(c, student.ClassID, student.SectionID, req.AcademicYear) {
+		return
+	}
+
+	remark := models.ReportCardRemark{
+		StudentID:    req.StudentID,
+		AcademicYear: req.AcademicYear,
+		ExamType:     req.ExamType,
+		Remarks:      strings.TrimSpace(req.Remarks),
+		CreatedBy:    c.GetUint("user_id"),
+	}
+
+	if err := h.remarkRepo.Upsert(&remark); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, remark)
+}
+
+// parseReportCardTerm reads the required academic_year/exam_type query parameters.
+func parseReportCardTerm(c *gin.Context) (string, string, bool) {
+	academicYear := c.Query("academic_year")
+	if _, _, err := services.AcademicYearRange(academicYear, config.AppConfig.AcademicYearStartMonth); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid academic year. Use e.g. 2024-2025"})
+		return "", "", false
+	}
+	examType := c.Query("exam_type")
+	if examType == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "exam_type is required"})
+		return "", "", false
+	}
+	return academicYear, examType, true
+}
+
+func reportCardSchool() services.School {
+	return services.School{
+		Name:    config.AppConfig.SchoolName,
+		Address: config.AppConfig.SchoolAddress,
+	}
+}
+
+func reportCardFileName(card *services.ReportCard) string {
+	return safeFileName(fmt.Sprintf("report-card-%s-%s-%s.pdf", card.Student.AdmissionNumber, card.AcademicYear, card.ExamType))
+}
+
+// safeFileName replaces characters that are not safe in file names and
+// Content-Disposition headers, such as the slashes of admission numbers.
+func safeFileName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '.' || r == '-' || r == '_' {
+			return r
+		}
+		return '-'
+	}, name)
+}
+
+// Request Types
+type SaveRemarkRequest struct {
+	StudentID    uint   `json:"student_id" binding:"required"`
+	AcademicYear string `json:"academic_year" binding:"required"`
+	ExamType     string `json:"exam_type" binding:"required"`
+	Remarks      string `json:"remarks" binding:"max=2000"`
+}
+
+// Response Types
+type ReportCardRemarkEntry struct {
+	StudentID       uint   `json:"student_id"`
+	AdmissionNumber string `json:"admission_number"`
+	FirstName       string `json:"first_name"`
+	LastName        string `json:"last_name"`
+	Remarks         string `json:"remarks"`
+}
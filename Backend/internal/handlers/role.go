@@ -0,0 +1,503 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"school-erp-backend/internal/middleware"
+	"school-erp-backend/internal/models"
+	"school-erp-backend/internal/repository"
+	"school-erp-backend/internal/services"
+	"school-erp-backend/pkg/database"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+var roleNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,49}$`)
+
+type RoleHandler struct {
+	roleRepo       *repository.RoleRepository
+	permissionRepo *repository.PermissionRepository
+	userRepo       *repository.UserRepository
+}
+
+func NewRoleHandler() *RoleHandler {
+	return &RoleHandler{
+		roleRepo:       repository.NewRoleRepository(database.DB),
+		permissionRepo: repository.NewPermissionRepository(database.DB),
+		userRepo:       repository.NewUserRepository(database.DB),
+	}
+}
+
+// GetPermissions godoc
+// @Summary Get permissions
+// @Description Get every permission that roles can grant
+// @Tags Admin - Roles
+// @Produce json
+// @Success 200 {array} models.Permission
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/permissions [get]
+// @Security BearerAuth
+func (h *RoleHandler) GetPermissions(c *gin.Context) {
+	permissions, err := h.permissionRepo.FindAll()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, permissions)
+}
+
+// GetRoles godoc
+// @Summary Get roles
+// @Description Get every role with the permissions it grants. The admin role has every permission without listing them.
+// @Tags Admin - Roles
+// @Produce json
+// @Success 200 {array} models.Role
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/roles [get]
+// @Security BearerAuth
+func (h *RoleHandler) GetRoles(c *gin.Context) {
+	roles, err := h.roleRepo.FindAll()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, roles)
+}
+
+// GetRole godoc
+// @Summary Get role by ID
+// @Description Get a role with the permissions it grants
+// @Tags Admin - Roles
+// @Produce json
+// @Param id path int true "Role ID"
+// @Success 200 {object} models.Role
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/roles/{id} [get]
+// @Security BearerAuth
+func (h *RoleHandler) GetRole(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	role, err := h.roleRepo.FindByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Role not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, role)
+}
+
+// CreateRole godoc
+// @Summary Create role
+// @Description Create a role granting a set of permissions. Only permissions the current user holds can be granted.
+// @Tags Admin - Roles
+// @Accept json
+// @Produce json
+// @Param role body CreateRoleRequest true "Role data"
+// @Success 201 {object} models.Role
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /admin/roles [post]
+// @Security BearerAuth
+func (h *RoleHandler) CreateRole(c *gin.Context) {
+	var req CreateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	name := strings.ToLower(strings.TrimSpace(req.Name))
+	if !roleNamePattern.MatchString(name) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid role name. Use lowercase letters, digits and underscores, starting with a letter"})
+		return
+	}
+	if _, err := h.roleRepo.FindByName(name); err == nil {
+		c.JSON(http.StatusConflict, gin.H{"error": "A role with this name already exists"})
+		return
+	}
+
+	permissions, ok := h.grantablePermissions(c, req.Permissions, nil)
+	if !ok {
+		return
+	}
+
+	role := &models.Role{Name: name, Description: req.Description}
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		roleRepo := repository.NewRoleRepository(tx)
+		if err := roleRepo.Create(role); err != nil {
+			return err
+		}
+		return roleRepo.SetPermissions(role, permissions)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create role"})
+		return
+	}
+
+	role, _ = h.roleRepo.FindByID(role.ID)
+	c.JSON(http.StatusCreated, role)
+}
+
+// UpdateRole godoc
+// @Summary Update role
+// @Description Change the description of a role or replace its permissions. Changes apply to the next request of every user with the role. The permissions of the admin role cannot be changed, and only permissions the current user holds can be added.
+// @Tags Admin - Roles
+// @Accept json
+// @Produce json
+// @Param id path int true "Role ID"
+// @Param role body UpdateRoleRequest true "Role data"
+// @Success 200 {object} models.Role
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/roles/{id} [put]
+// @Security BearerAuth
+func (h *RoleHandler) UpdateRole(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	var req UpdateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	role, err := h.roleRepo.FindByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Role not found"})
+		return
+	}
+
+	var permissions []models.Permission
+	if req.Permissions != nil {
+		if role.Name == services.RoleAdmin {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "The admin role has every permission and cannot be changed"})
+			return
+		}
+		var ok bool
+		if permissions, ok = h.grantablePermissions(c, *req.Permissions, role.Permissions); !ok {
+			return
+		}
+	}
+	if req.Description != nil {
+		role.Description = *req.Description
+	}
+
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		roleRepo := repository.NewRoleRepository(tx)
+		if err := roleRepo.Update(role); err != nil {
+			return err
+		}
+		if req.Permissions == nil {
+			return nil
+		}
+		return roleRepo.SetPermissions(role, permissions)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update role"})
+		return
+	}
+
+	role, _ = h.roleRepo.FindByID(role.ID)
+	c.JSON(http.StatusOK, role)
+}
+
+// DeleteRole godoc
+// @Summary Delete role
+// @Description Delete a role, taking it away from the users it was given to. System roles and roles that are the primary role of a user cannot be deleted.
+// @Tags Admin - Roles
+// @Produce json
+// @Param id path int true "Role ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /admin/roles/{id} [delete]
+// @Security BearerAuth
+func (h *RoleHandler) DeleteRole(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	role, err := h.roleRepo.FindByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Role not found"})
+		return
+	}
+	if role.IsSystem {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "System roles cannot be deleted"})
+		return
+	}
+
+	count, err := h.roleRepo.CountPrimaryUsers(role.Name)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if count > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "Role is the primary role of " + strconv.FormatInt(count, 10) + " user(s)"})
+		return
+	}
+
+	if err := h.roleRepo.Delete(role.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete role"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Role deleted successfully"})
+}
+
+// GetUserRoles godoc
+// @Summary Get roles of a user
+// @Description Get the primary role of a user, the roles they were given besides it, and the permissions these add up to
+// @Tags Admin - Users
+// @Produce json
+// @Param id path int true "User ID"
+// @Success 200 {object} UserRolesResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/users/{id}/roles [get]
+// @Security BearerAuth
+func (h *RoleHandler) GetUserRoles(c *gin.Context) {
+	user, ok := h.user(c)
+	if !ok {
+		return
+	}
+
+	h.respondUserRoles(c, user)
+}
+
+// SetUserRoles godoc
+// @Summary Set roles of a user
+// @Description Replace the roles a user has besides their primary role. Only users whose permissions the current user holds can be changed, and only roles whose permissions the current user holds can be given.
+// @Tags Admin - Users
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Param roles body SetUserRolesRequest true "Role names"
+// @Success 200 {object} UserRolesResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/users/{id}/roles [put]
+// @Security BearerAuth
+func (h *RoleHandler) SetUserRoles(c *gin.Context) {
+	var req SetUserRolesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, ok := h.user(c)
+	if !ok {
+		return
+	}
+	if !checkUserManageable(c, h.roleRepo, user) {
+		return
+	}
+
+	names := make([]string, 0, len(req.Roles))
+	for _, name := range req.Roles {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name != user.Role {
+			names = append(names, name)
+		}
+	}
+	roles := []models.Role{}
+	if len(names) > 0 {
+		var err error
+		if roles, err = h.roleRepo.FindByNames(names); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	roleIDs := make([]uint, 0, len(roles))
+	found := make(map[string]bool, len(roles))
+	for _, role := range roles {
+		roleIDs = append(roleIDs, role.ID)
+		found[role.Name] = true
+	}
+	for _, name := range names {
+		if !found[name] {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Unknown role: " + name})
+			return
+		}
+	}
+
+	current, err := h.roleRepo.UserRoles(user.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	held := make(map[string]bool, len(current))
+	for _, role := range current {
+		held[role.Name] = true
+	}
+	var added []string
+	for _, name := range names {
+		if !held[name] {
+			added = append(added, name)
+		}
+	}
+	if !checkRoleGrant(c, h.roleRepo, added...) {
+		return
+	}
+
+	if err := h.roleRepo.SetUserRoles(user.ID, roleIDs); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to set roles"})
+		return
+	}
+
+	h.respondUserRoles(c, user)
+}
+
+func (h *RoleHandler) user(c *gin.Context) (*models.User, bool) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	user, err := h.userRepo.FindByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return nil, false
+	}
+	return user, true
+}
+
+func (h *RoleHandler) respondUserRoles(c *gin.Context, user *models.User) {
+	roleNames, err := services.UserRoleNames(h.roleRepo, user.ID, user.Role)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	permissions, err := services.RolePermissions(h.roleRepo, roleNames)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, UserRolesResponse{
+		UserID:      user.ID,
+		PrimaryRole: user.Role,
+		Roles:       roleNames[1:],
+		Permissions: permissions,
+	})
+}
+
+// grantablePermissions looks up the named permissions, checking that the
+// current user holds every one that is not among the current ones.
+func (h *RoleHandler) grantablePermissions(c *gin.Context, names []string, current []models.Permission) ([]models.Permission, bool) {
+	permissions, err := services.FindPermissions(h.permissionRepo, names)
+	if errors.Is(err, services.ErrUnknownPermission) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, false
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return nil, false
+	}
+
+	existing := make(map[string]bool, len(current))
+	for _, permission := range current {
+		existing[permission.Name] = true
+	}
+	var added []string
+	for _, permission := range permissions {
+		if !existing[permission.Name] {
+			added = append(added, permission.Name)
+		}
+	}
+
+	held, ok := middleware.Permissions(c)
+	if !ok {
+		return nil, false
+	}
+	if !services.CanGrant(held, added) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You cannot grant permissions you do not have"})
+		return nil, false
+	}
+	return permissions, true
+}
+
+// checkRoleGrant responds with 403 and returns false unless the current user
+// holds every permission the named roles grant, so they cannot hand out more
+// than they have. Unknown roles respond with 400.
+func checkRoleGrant(c *gin.Context, roleRepo *repository.RoleRepository, roleNames ...string) bool {
+	if len(roleNames) == 0 {
+		return true
+	}
+
+	roles, err := roleRepo.FindByNames(roleNames)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return false
+	}
+	found := make(map[string]bool, len(roles))
+	for _, role := range roles {
+		found[role.Name] = true
+	}
+	for _, name := range roleNames {
+		if !found[name] {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Unknown role: " + name})
+			return false
+		}
+	}
+
+	granted, err := services.RolePermissions(roleRepo, roleNames)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return false
+	}
+	held, ok := middleware.Permissions(c)
+	if !ok {
+		return false
+	}
+	if !services.CanGrant(held, granted) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You cannot give a role with permissions you do not have"})
+		return false
+	}
+	return true
+}
+
+// checkUserManageable responds with 403 and returns false unless the current
+// user holds every permission of the given user, so accounts with more rights
+// cannot be taken over or removed.
+func checkUserManageable(c *gin.Context, roleRepo *repository.RoleRepository, user *models.User) bool {
+	permissions, err := services.EffectivePermissions(roleRepo, user.ID, user.Role)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return false
+	}
+	held, ok := middleware.Permissions(c)
+	if !ok {
+		return false
+	}
+	if !services.CanGrant(held, permissions) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You cannot change a user with permissions you do not have"})
+		return false
+	}
+	return true
+}
+
+// Request Types
+type CreateRoleRequest struct {
+	Name        string   `json:"name" binding:"required"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"`
+}
+
+type UpdateRoleRequest struct {
+	Description *string   `json:"description"`
+	Permissions *[]string `json:"permissions"` // replaces every permission of the role when given
+}
+
+type SetUserRolesRequest struct {
+	Roles []string `json:"roles"` // roles besides the primary role
+}
+
+// Response Types
+type UserRolesResponse struct {
+	UserID      uint     `json:"user_id"`
+	PrimaryRole string   `json:"primary_role"`
+	Roles       []string `json:"roles"` // roles besides the primary role
+	Permissions []string `json:"permissions"`
+}
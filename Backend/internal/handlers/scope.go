@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"school-erp-backend/internal/repository"
+	"school-erp-backend/pkg/database"
+	"github.com/gin-gonic/gin"
+)
+
+// requestScope returns the rows the logged in user may see, loading the
+// scope once per request. Handlers shared with teachers and students read
+// through repositories restricted to it. On failure it has already responded.
+func requestScope(c *gin.Context) (repository.Scope, bool) {
+	if scope, exists := c.Get("scope"); exists {
+		return scope.(repository.Scope), true
+	}
+
+	scope, err := repository.NewScope(database.DB, c.GetUint("user_id"), c.GetString("user_role"))
+	if errors.Is(err, repository.ErrNoProfile) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Teacher, student or guardian profile not found"})
+		return repository.Scope{}, false
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return repository.Scope{}, false
+	}
+
+	c.Set("scope", scope)
+	return scope, true
+}
@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+	"school-erp-backend/internal/models"
+	"school-erp-backend/internal/repository"
+	"school-erp-backend/internal/services"
+	"school-erp-backend/pkg/database"
+	"github.com/gin-gonic/gin"
+)
+
+type SessionHandler struct {
+	sessionRepo *repository.SessionRepository
+	userRepo    *repository.UserRepository
+}
+
+func NewSessionHandler() *SessionHandler {
+	return &SessionHandler{
+		sessionRepo: repository.NewSessionRepository(database.DB),
+		userRepo:    repository.NewUserRepository(database.DB),
+	}
+}
+
+// GetMySessions godoc
+// @Summary Get own sessions
+// @Description Get the devices the logged in user is signed in on, most recently used first
+// @Tags Sessions
+// @Produce json
+// @Success 200 {array} SessionResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /auth/sessions [get]
+// @Security BearerAuth
+func (h *SessionHandler) GetMySessions(c *gin.Context) {
+	h.sessions(c, c.GetUint("user_id"))
+}
+
+// RevokeMySession godoc
+// @Summary Sign out a session
+// @Description Sign the logged in user out of one of their sessions, for example one left open on a shared computer
+// @Tags Sessions
+// @Produce json
+// @Param id path int true "Session ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /auth/sessions/{id} [delete]
+// @Security BearerAuth
+func (h *SessionHandler) RevokeMySession(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	session, err := h.sessionRepo.FindByID(uint(id))
+	if err != nil || session.UserID != c.GetUint("user_id") || session.RevokedAt != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
+		return
+	}
+
+	if err := services.EndSession(h.sessionRepo, session.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to revoke session"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Session revoked successfully"})
+}
+
+// GetUserSessions godoc
+// @Summary Get sessions of a user
+// @Description Get the devices a user is signed in on, most recently used first
+// @Tags Admin - Users
+// @Produce json
+// @Param id path int true "User ID"
+// @Success 200 {array} SessionResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/users/{id}/sessions [get]
+// @Security BearerAuth
+func (h *SessionHandler) GetUserSessions(c *gin.Context) {
+	user, ok := h.user(c)
+	if !ok {
+		return
+	}
+
+	h.sessions(c, user.ID)
+}
+
+// RevokeUserSessions godoc
+// @Summary Sign out a user everywhere
+// @Description Revoke every session of a user. Their access tokens stop working at once and they have to log in again.
+// @Tags Admin - Users
+// @Produce json
+// @Param id path int true "User ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/users/{id}/sessions [delete]
+// @Security BearerAuth
+func (h *SessionHandler) RevokeUserSessions(c *gin.Context) {
+	user, ok := h.user(c)
+	if !ok {
+		return
+	}
+
+	if err := services.RevokeUserSessions(h.sessionRepo, user.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to revoke sessions"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Sessions revoked successfully"})
+}
+
+func (h *SessionHandler) user(c *gin.Context) (*models.User, bool) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	user, err := h.userRepo.FindByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return nil, false
+	}
+	return user, true
+}
+
+// sessions responds with the active sessions of a user, marking the one the
+// request was made with.
+func (h *SessionHandler) sessions(c *gin.Context, userID uint) {
+	sessions, err := h.sessionRepo.FindActiveByUser(userID, time.Now())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	var currentFamily string
+	if current, err := h.sessionRepo.FindByID(c.GetUint("session_id")); err == nil {
+		currentFamily = current.FamilyID
+	}
+
+	response := make([]SessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		response = append(response, SessionResponse{
+			ID:         session.ID,
+			UserAgent:  session.UserAgent,
+			IPAddress:  session.IPAddress,
+			StartedAt:  session.StartedAt,
+			LastSeenAt: session.LastSeenAt,
+			ExpiresAt:  session.ExpiresAt,
+			Current:    session.FamilyID == currentFamily,
+		})
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// Response Types
+type SessionResponse struct {
+	ID         uint      `json:"id"`
+	UserAgent  string    `json:"user_agent"`
+	IPAddress  string    `json:"ip_address"`
+	StartedAt  time.Time `json:"started_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	Current    bool      `json:"current"` // the session of this request
+}
@@ -0,0 +1,521 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"school-erp-backend/config"
+	"school-erp-backend/internal/models"
+	"school-erp-backend/internal/repository"
+	"school-erp-backend/internal/services"
+	"school-erp-backend/pkg/database"
+	"school-erp-backend/pkg/storage"
+	"github.com/gin-gonic/gin"
+)
+
+// StudentLeaveHandler serves student absences: students and their guardians
+// apply, class teachers and staff with student leave permissions decide.
+type StudentLeaveHandler struct {
+	requestRepo *repository.StudentLeaveRequestRepository
+	studentRepo *repository.StudentRepository
+	policy      *services.StudentLeavePolicy
+	access      teachingAccess
+}
+
+func NewStudentLeaveHandler() *StudentLeaveHandler {
+	school := services.NewSchoolCalendar(database.DB, config.AppConfig.WeeklyOffDays)
+	return &StudentLeaveHandler{
+		requestRepo: repository.NewStudentLeaveRequestRepository(database.DB),
+		studentRepo: repository.NewStudentRepository(database.DB),
+		policy:      services.NewStudentLeavePolicy(database.DB, school, config.AppConfig.AcademicYearStartMonth),
+		access:      newTeachingAccess(),
+	}
+}
+
+// GetMyLeaveRequests godoc
+// @Summary Get own leave requests
+// @Description Get the leave requests of the logged in student, latest first
+// @Tags Student - Leave
+// @Produce json
+// @Param status query string false "Filter by status (pending, approved, rejected, cancelled)"
+// @Success 200 {array} models.StudentLeaveRequest
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /student/leave [get]
+// @Security BearerAuth
+func (h *StudentLeaveHandler) GetMyLeaveRequests(c *gin.Context) {
+	student, ok := h.student(c)
+	if !ok {
+		return
+	}
+
+	h.respondRequests(c, models.StudentLeaveRequest{StudentID: student.ID, Status: c.Query("status")})
+}
+
+// ApplyForLeave godoc
+// @Summary Apply for leave
+// @Description Apply for an absence of the logged in student with a reason and an optional supporting document. The leave must cover school days and may not overlap other pending or approved requests. The class teacher decides it.
+// @Tags Student - Leave
+// @Accept multipart/form-data
+// @Produce json
+// @Param start_date formData string true "First day of leave (YYYY-MM-DD)"
+// @Param end_date formData string true "Last day of leave (YYYY-MM-DD)"
+// @Param reason formData string true "Reason"
+// @Param attachment formData file false "Supporting document (PDF, PNG, JPEG, plain text, ZIP or Office document)"
+// @Success 201 {object} models.StudentLeaveRequest
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 413 {object} ErrorResponse
+// @Failure 415 {object} ErrorResponse
+// @Router /student/leave [post]
+// @Security BearerAuth
+func (h *StudentLeaveHandler) ApplyForLeave(c *gin.Context) {
+	student, ok := h.student(c)
+	if !ok {
+		return
+	}
+
+	h.apply(c, student)
+}
+
+// CancelLeaveRequest godoc
+// @Summary Cancel own leave request
+// @Description Withdraw a pending leave request of the logged in student
+// @Tags Student - Leave
+// @Produce json
+// @Param id path int true "Leave request ID"
+// @Success 200 {object} models.StudentLeaveRequest
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /student/leave/{id}/cancel [post]
+// @Security BearerAuth
+func (h *StudentLeaveHandler) CancelLeaveRequest(c *gin.Context) {
+	student, ok := h.student(c)
+	if !ok {
+		return
+	}
+
+	h.cancel(c, student.ID, c.Param("id"))
+}
+
+// GetChildLeaveRequests godoc
+// @Summary Get leave requests of a child
+// @Description Get the leave requests of a student linked to the logged in guardian, latest first
+// @Tags Parent
+// @Produce json
+// @Param id path int true "Student ID"
+// @Param status query string false "Filter by status (pending, approved, rejected, cancelled)"
+// @Success 200 {array} models.StudentLeaveRequest
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /parent/children/{id}/leave [get]
+// @Security BearerAuth
+func (h *StudentLeaveHandler) GetChildLeaveRequests(c *gin.Context) {
+	child, ok := h.child(c)
+	if !ok {
+		return
+	}
+
+	h.respondRequests(c, models.StudentLeaveRequest{StudentID: child.ID, Status: c.Query("status")})
+}
+
+// ApplyForChildLeave godoc
+// @Summary Apply for leave of a child
+// @Description Apply for an absence of a student linked to the logged in guardian with a reason and an optional supporting document. The leave must cover school days and may not overlap other pending or approved requests. The class teacher decides it.
+// @Tags Parent
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path int true "Student ID"
+// @Param start_date formData string true "First day of leave (YYYY-MM-DD)"
+// @Param end_date formData string true "Last day of leave (YYYY-MM-DD)"
+// @Param reason formData string true "Reason"
+// @Param attachment formData file false "Supporting document (PDF, PNG, JPEG, plain text, ZIP or Office document)"
+// @Success 201 {object} models.StudentLeaveRequest
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 413 {object} ErrorResponse
+// @Failure 415 {object} ErrorResponse
+// @Router /parent/children/{id}/leave [post]
+// @Security BearerAuth
+func (h *StudentLeaveHandler) ApplyForChildLeave(c *gin.Context) {
+	child, ok := h.child(c)
+	if !ok {
+		return
+	}
+
+	h.apply(c, child)
+}
+
+// CancelChildLeaveRequest godoc
+// @Summary Cancel a leave request of a child
+// @Description Withdraw a pending leave request of a student linked to the logged in guardian
+// @Tags Parent
+// @Produce json
+// @Param id path int true "Student ID"
+// @Param leave_id path int true "Leave request ID"
+// @Success 200 {object} models.StudentLeaveRequest
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /parent/children/{id}/leave/{leave_id}/cancel [post]
+// @Security BearerAuth
+func (h *StudentLeaveHandler) CancelChildLeaveRequest(c *gin.Context) {
+	child, ok := h.child(c)
+	if !ok {
+		return
+	}
+
+	h.cancel(c, child.ID, c.Param("leave_id"))
+}
+
+// GetSectionLeaveRequests godoc
+// @Summary Get leave requests of a section
+// @Description Get the student leave requests of a class/section in an academic year, latest first. Teachers must be the class teacher of the section.
+// @Tags Student Leave
+// @Produce json
+// @Param class_id query int true "Class ID"
+// @Param section_id query int true "Section ID"
+// @Param status query string false "Filter by status (pending, approved, rejected, cancelled)"
+// @Param academic_year query string false "Academic year (e.g. 2024-2025), defaults to the current one"
+// @Success 200 {array} models.StudentLeaveRequest
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /teacher/student-leave [get]
+// @Security BearerAuth
+func (h *StudentLeaveHandler) GetSectionLeaveRequests(c *gin.Context) {
+	classID, sectionID, ok := parseClassSection(c)
+	if !ok {
+		return
+	}
+	academicYear := academicYearOrCurrent(c)
+	if !h.access.allowClassTeacher(c, classID, sectionID, academicYear) {
+		return
+	}
+
+	h.respondRequests(c, models.StudentLeaveRequest{
+		ClassID:      classID,
+		SectionID:    sectionID,
+		Status:       c.Query("status"),
+		AcademicYear: academicYear,
+	})
+}
+
+// GetLeaveRequests godoc
+// @Summary Get student leave requests
+// @Description Get the leave requests of students, latest first. from and to keep the requests with a day in that range.
+// @Tags Admin - Student Leave
+// @Produce json
+// @Param student_id query int false "Filter by student ID"
+// @Param class_id query int false "Filter by class ID"
+// @Param section_id query int false "Filter by section ID"
+// @Param status query string false "Filter by status (pending, approved, rejected, cancelled)"
+// @Param academic_year query string false "Filter by academic year (e.g. 2024-2025)"
+// @Param from query string false "Start date (YYYY-MM-DD)"
+// @Param to query string false "End date (YYYY-MM-DD)"
+// @Success 200 {array} models.StudentLeaveRequest
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/student-leave [get]
+// @Security BearerAuth
+func (h *StudentLeaveHandler) GetLeaveRequests(c *gin.Context) {
+	studentID, _ := strconv.ParseUint(c.Query("student_id"), 10, 32)
+	classID, _ := strconv.ParseUint(c.Query("class_id"), 10, 32)
+	sectionID, _ := strconv.ParseUint(c.Query("section_id"), 10, 32)
+	filter := models.StudentLeaveRequest{
+		StudentID:    uint(studentID),
+		ClassID:      uint(classID),
+		SectionID:    uint(sectionID),
+		Status:       c.Query("status"),
+		AcademicYear: c.Query("academic_year"),
+	}
+
+	var from, to time.Time
+	if value := c.Query("from"); value != "" {
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid from date. Use YYYY-MM-DD"})
+			return
+		}
+		from = parsed
+	}
+	if value := c.Query("to"); value != "" {
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid to date. Use YYYY-MM-DD"})
+			return
+		}
+		to = parsed
+	}
+
+	requests, err := h.requestRepo.FindAll(filter, from, to)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, requests)
+}
+
+// GetLeaveRequest godoc
+// @Summary Get student leave request by ID
+// @Description Get a student leave request with its student, applicant and the user who decided it
+// @Tags Admin - Student Leave
+// @Produce json
+// @Param id path int true "Leave request ID"
+// @Success 200 {object} models.StudentLeaveRequest
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/student-leave/{id} [get]
+// @Security BearerAuth
+func (h *StudentLeaveHandler) GetLeaveRequest(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	request, err := h.requestRepo.FindByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Leave request not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, request)
+}
+
+// ApproveLeaveRequest godoc
+// @Summary Approve a student leave request
+// @Description Approve a pending student leave request. Absences already marked on its days become excused, and students marked absent on them later are recorded as excused. Teachers must be the class teacher of the student's section.
+// @Tags Student Leave
+// @Accept json
+// @Produce json
+// @Param id path int true "Leave request ID"
+// @Param request body LeaveDecisionRequest false "Comment"
+// @Success 200 {object} StudentLeaveDecisionResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /teacher/student-leave/{id}/approve [post]
+// @Router /admin/student-leave/{id}/approve [post]
+// @Security BearerAuth
+func (h *StudentLeaveHandler) ApproveLeaveRequest(c *gin.Context) {
+	h.decide(c, true)
+}
+
+// RejectLeaveRequest godoc
+// @Summary Reject a student leave request
+// @Description Reject a pending student leave request, giving the reason in the comment. Teachers must be the class teacher of the student's section.
+// @Tags Student Leave
+// @Accept json
+// @Produce json
+// @Param id path int true "Leave request ID"
+// @Param request body LeaveDecisionRequest true "Comment"
+// @Success 200 {object} StudentLeaveDecisionResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /teacher/student-leave/{id}/reject [post]
+// @Router /admin/student-leave/{id}/reject [post]
+// @Security BearerAuth
+func (h *StudentLeaveHandler) RejectLeaveRequest(c *gin.Context) {
+	h.decide(c, false)
+}
+
+func (h *StudentLeaveHandler) decide(c *gin.Context, approve bool) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	request, err := h.requestRepo.FindByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Leave request not found"})
+		return
+	}
+	if !h.access.allowClassTeacher(c, request.ClassID, request.SectionID, request.AcademicYear) {
+		return
+	}
+
+	var req LeaveDecisionRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	if !approve && strings.TrimSpace(req.Comment) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "A comment is required to reject a leave request"})
+		return
+	}
+
+	excused, err := h.policy.Decide(request, approve, c.GetUint("user_id"), req.Comment, time.Now())
+	if err != nil {
+		respondStudentLeaveError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, StudentLeaveDecisionResponse{Request: *request, ExcusedAbsences: excused})
+}
+
+// apply reads a leave application for student, storing its attachment, and
+// responds with the created request.
+func (h *StudentLeaveHandler) apply(c *gin.Context, student *models.Student) {
+	maxBytes := int64(config.AppConfig.MaxUploadSizeMB) << 20
+	// Leave room for the multipart envelope around the file
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBytes+1<<20)
+
+	var req StudentLeaveApplicationRequest
+	if err := c.ShouldBind(&req); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": services.ErrFileTooLarge.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	startDate, err := time.Parse("2006-01-02", req.StartDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid start date. Use YYYY-MM-DD"})
+		return
+	}
+	endDate, err := time.Parse("2006-01-02", req.EndDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid end date. Use YYYY-MM-DD"})
+		return
+	}
+	if endDate.Before(startDate) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "end_date must not be before start_date"})
+		return
+	}
+	if strings.TrimSpace(req.Reason) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "reason is required"})
+		return
+	}
+
+	request := models.StudentLeaveRequest{
+		StudentID: student.ID,
+		ClassID:   student.ClassID,
+		SectionID: student.SectionID,
+		StartDate: startDate,
+		EndDate:   endDate,
+		Reason:    strings.TrimSpace(req.Reason),
+		AppliedBy: c.GetUint("user_id"),
+	}
+
+	if c.ContentType() == "multipart/form-data" {
+		header, err := c.FormFile("attachment")
+		if err == nil {
+			keyPrefix := fmt.Sprintf("student-leave/%d", student.ID)
+			upload, err := services.StoreUpload(c.Request.Context(), storage.Files, header, keyPrefix, maxBytes)
+			if errors.Is(err, services.ErrFileTooLarge) {
+				c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+				return
+			}
+			if errors.Is(err, services.ErrFileTypeNotAllowed) {
+				c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": err.Error()})
+				return
+			}
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+			request.AttachmentPath = upload.Key
+			request.AttachmentName = upload.FileName
+			request.AttachmentType = upload.ContentType
+			request.AttachmentSize = upload.Size
+		} else if !errors.Is(err, http.ErrMissingFile) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	if err := h.policy.Apply(&request); err != nil {
+		if request.AttachmentPath != "" {
+			storage.Files.Delete(c.Request.Context(), request.AttachmentPath)
+		}
+		respondStudentLeaveError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, request)
+}
+
+// cancel withdraws the request in idParam when it belongs to the student
+func (h *StudentLeaveHandler) cancel(c *gin.Context, studentID uint, idParam string) {
+	id, _ := strconv.ParseUint(idParam, 10, 32)
+	request, err := h.requestRepo.FindByID(uint(id))
+	if err != nil || request.StudentID != studentID {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Leave request not found"})
+		return
+	}
+
+	if err := h.policy.Cancel(request, time.Now()); err != nil {
+		respondStudentLeaveError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, request)
+}
+
+func (h *StudentLeaveHandler) respondRequests(c *gin.Context, filter models.StudentLeaveRequest) {
+	requests, err := h.requestRepo.FindAll(filter, time.Time{}, time.Time{})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, requests)
+}
+
+// student loads the logged in student. On failure it has already responded.
+func (h *StudentLeaveHandler) student(c *gin.Context) (*models.Student, bool) {
+	student, err := h.studentRepo.FindByUserID(c.GetUint("user_id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Student profile not found"})
+		return nil, false
+	}
+	return student, true
+}
+
+// child loads the student in the id path parameter through the scope of the
+// guardian, so students who are not their children are not found.
+func (h *StudentLeaveHandler) child(c *gin.Context) (*models.Student, bool) {
+	scope, ok := requestScope(c)
+	if !ok {
+		return nil, false
+	}
+
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	child, err := h.studentRepo.Scoped(scope).FindByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Student not found"})
+		return nil, false
+	}
+	return child, true
+}
+
+// respondStudentLeaveError maps the errors of the student leave policy to responses
+func respondStudentLeaveError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrStudentLeaveOverlap), errors.Is(err, services.ErrLeaveNotPending):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrNoWorkingDays), errors.Is(err, services.ErrLeaveSpansYears):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// Request Types
+type StudentLeaveApplicationRequest struct {
+	StartDate string `json:"start_date" form:"start_date" binding:"required"` // YYYY-MM-DD
+	EndDate   string `json:"end_date" form:"end_date" binding:"required"`     // YYYY-MM-DD, the last day of leave
+	Reason    string `json:"reason" form:"reason" binding:"required"`
+}
+
+// Response Types
+type StudentLeaveDecisionResponse struct {
+	Request         models.StudentLeaveRequest `json:"request"`
+	ExcusedAbsences int64                      `json:"excused_absences"` // absences already marked that the approval excused
+}
@@ -0,0 +1,319 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+	"school-erp-backend/config"
+	"school-erp-backend/internal/models"
+	"school-erp-backend/internal/repository"
+	"school-erp-backend/internal/services"
+	"school-erp-backend/pkg/database"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// maxSubstitutionRangeDays bounds the ranges affected periods are listed for
+const maxSubstitutionRangeDays = 31
+
+// SubstitutionHandler serves substitute planning: the periods teachers on
+// approved leave leave uncovered, the teachers free to take them, the
+// assignments and the schedules they produce.
+type SubstitutionHandler struct {
+	substitutionRepo *repository.SubstitutionRepository
+	teacherRepo      *repository.TeacherRepository
+	planner          *services.SubstitutePlanner
+}
+
+func NewSubstitutionHandler() *SubstitutionHandler {
+	school := services.NewSchoolCalendar(database.DB, config.AppConfig.WeeklyOffDays)
+	return &SubstitutionHandler{
+		substitutionRepo: repository.NewSubstitutionRepository(database.DB),
+		teacherRepo:      repository.NewTeacherRepository(database.DB),
+		planner:          services.NewSubstitutePlanner(database.DB, school, config.AppConfig.AcademicYearStartMonth),
+	}
+}
+
+// GetAffectedPeriods godoc
+// @Summary Get periods affected by leave
+// @Description Get the timetable periods that teachers on approved leave would have taught on the school days of a date range (at most 31 days), with their substitute when one is assigned
+// @Tags Admin - Substitutions
+// @Produce json
+// @Param from query string true "Start date (YYYY-MM-DD)"
+// @Param to query string true "End date (YYYY-MM-DD)"
+// @Param teacher_id query int false "Filter by the teacher on leave"
+// @Success 200 {array} services.AffectedPeriod
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/substitutions/affected [get]
+// @Security BearerAuth
+func (h *SubstitutionHandler) GetAffectedPeriods(c *gin.Context) {
+	from, to, ok := parseDateRange(c)
+	if !ok {
+		return
+	}
+	if to.Sub(from) >= maxSubstitutionRangeDays*24*time.Hour {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Date range must not exceed 31 days"})
+		return
+	}
+	teacherID, _ := strconv.ParseUint(c.Query("teacher_id"), 10, 32)
+
+	periods, err := h.planner.AffectedPeriods(from, to, uint(teacherID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, periods)
+}
+
+// GetSubstituteSuggestions godoc
+// @Summary Suggest substitutes for a period
+// @Description Get the active teachers free to take a period affected by leave on a date: not on leave and without a period or substitution at that time. Teachers specialized in the subject come first, then those with the fewest periods that day.
+// @Tags Admin - Substitutions
+// @Produce json
+// @Param date query string true "Date (YYYY-MM-DD)"
+// @Param timetable_id query int true "Timetable slot ID"
+// @Success 200 {array} services.SubstituteSuggestion
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/substitutions/suggestions [get]
+// @Security BearerAuth
+func (h *SubstitutionHandler) GetSubstituteSuggestions(c *gin.Context) {
+	date, err := time.Parse("2006-01-02", c.Query("date"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date. Use YYYY-MM-DD"})
+		return
+	}
+	timetableID, err := strconv.ParseUint(c.Query("timetable_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid timetable ID"})
+		return
+	}
+
+	suggestions, err := h.planner.Suggestions(date, uint(timetableID))
+	if err != nil {
+		respondSubstitutionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, suggestions)
+}
+
+// CreateSubstitution godoc
+// @Summary Assign a substitute
+// @Description Hand a period affected by leave on a date to a substitute, who must be free for it
+// @Tags Admin - Substitutions
+// @Accept json
+// @Produce json
+// @Param request body SubstitutionRequest true "Substitution details"
+// @Success 201 {object} models.Substitution
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /admin/substitutions [post]
+// @Security BearerAuth
+func (h *SubstitutionHandler) CreateSubstitution(c *gin.Context) {
+	var req SubstitutionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	date, err := time.Parse("2006-01-02", req.Date)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date. Use YYYY-MM-DD"})
+		return
+	}
+	if _, err := h.teacherRepo.FindByID(req.SubstituteTeacherID); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Substitute teacher not found"})
+		return
+	}
+
+	substitution := models.Substitution{
+		Date:                date,
+		TimetableID:         req.TimetableID,
+		SubstituteTeacherID: req.SubstituteTeacherID,
+		Note:                req.Note,
+		AssignedBy:          c.GetUint("user_id"),
+	}
+	if err := h.planner.Assign(&substitution); err != nil {
+		respondSubstitutionError(c, err)
+		return
+	}
+
+	created, err := h.substitutionRepo.FindByID(substitution.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, created)
+}
+
+// DeleteSubstitution godoc
+// @Summary Remove a substitute
+// @Description Remove a substitution, leaving the period uncovered again
+// @Tags Admin - Substitutions
+// @Produce json
+// @Param id path int true "Substitution ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/substitutions/{id} [delete]
+// @Security BearerAuth
+func (h *SubstitutionHandler) DeleteSubstitution(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	if _, err := h.substitutionRepo.FindByID(uint(id)); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Substitution not found"})
+		return
+	}
+
+	if err := h.substitutionRepo.Delete(uint(id)); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Substitution deleted successfully"})
+}
+
+// GetSubstitutionSheet godoc
+// @Summary Get the daily substitution sheet
+// @Description Get every period affected by leave on a date with its substitute, and how many are still uncovered
+// @Tags Admin - Substitutions
+// @Produce json
+// @Param date query string false "Date (YYYY-MM-DD), defaults to today"
+// @Success 200 {object} SubstitutionSheetResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/substitutions/sheet [get]
+// @Security BearerAuth
+func (h *SubstitutionHandler) GetSubstitutionSheet(c *gin.Context) {
+	date, ok := dateOrToday(c)
+	if !ok {
+		return
+	}
+
+	periods, err := h.planner.AffectedPeriods(date, date, 0)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	sheet := SubstitutionSheetResponse{Date: date.Format("2006-01-02"), Periods: periods}
+	for _, period := range periods {
+		if period.Substitution != nil {
+			sheet.Covered++
+		} else {
+			sheet.Uncovered++
+		}
+	}
+
+	c.JSON(http.StatusOK, sheet)
+}
+
+// GetTeacherDaySchedule godoc
+// @Summary Get the day schedule of a teacher
+// @Description Get what a teacher teaches on a date: their own periods, unless they are on leave, and the periods they substitute
+// @Tags Admin - Substitutions
+// @Produce json
+// @Param id path int true "Teacher ID"
+// @Param date query string false "Date (YYYY-MM-DD), defaults to today"
+// @Success 200 {object} services.TeacherDaySchedule
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/substitutions/teacher/{id}/day [get]
+// @Security BearerAuth
+func (h *SubstitutionHandler) GetTeacherDaySchedule(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	teacher, err := h.teacherRepo.FindByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Teacher not found"})
+		return
+	}
+
+	h.daySchedule(c, teacher.ID)
+}
+
+// GetMyDaySchedule godoc
+// @Summary Get own day schedule
+// @Description Get what the logged in teacher teaches on a date, including the periods they substitute for teachers on leave
+// @Tags Teacher - Substitutions
+// @Produce json
+// @Param date query string false "Date (YYYY-MM-DD), defaults to today"
+// @Success 200 {object} services.TeacherDaySchedule
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /teacher/substitutions/day [get]
+// @Security BearerAuth
+func (h *SubstitutionHandler) GetMyDaySchedule(c *gin.Context) {
+	teacher, err := h.teacherRepo.FindByUserID(c.GetUint("user_id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Teacher profile not found"})
+		return
+	}
+
+	h.daySchedule(c, teacher.ID)
+}
+
+func (h *SubstitutionHandler) daySchedule(c *gin.Context, teacherID uint) {
+	date, ok := dateOrToday(c)
+	if !ok {
+		return
+	}
+
+	schedule, err := h.planner.DaySchedule(teacherID, date)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, schedule)
+}
+
+// dateOrToday reads the optional date query parameter, defaulting to today
+func dateOrToday(c *gin.Context) (time.Time, bool) {
+	value := c.Query("date")
+	if value == "" {
+		return time.Now(), true
+	}
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date. Use YYYY-MM-DD"})
+		return time.Time{}, false
+	}
+	return date, true
+}
+
+// respondSubstitutionError maps the errors of the substitute planner to responses
+func respondSubstitutionError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Timetable slot not found"})
+	case errors.Is(err, services.ErrPeriodCovered), errors.Is(err, services.ErrSubstituteNotFree):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrPeriodNotAffected):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// Request Types
+type SubstitutionRequest struct {
+	Date                string `json:"date" binding:"required"` // YYYY-MM-DD
+	TimetableID         uint   `json:"timetable_id" binding:"required"`
+	SubstituteTeacherID uint   `json:"substitute_teacher_id" binding:"required"`
+	Note                string `json:"note"`
+}
+
+// Response Types
+type SubstitutionSheetResponse struct {
+	Date      string                    `json:"date"`
+	Covered   int                       `json:"covered"`
+	Uncovered int                       `json:"uncovered"`
+	Periods   []services.AffectedPeriod `json:"periods"`
+}
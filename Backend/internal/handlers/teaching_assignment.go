@@ -0,0 +1,345 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+	"school-erp-backend/config"
+	"school-erp-backend/internal/models"
+	"school-erp-backend/internal/repository"
+	"school-erp-backend/internal/services"
+	"school-erp-backend/pkg/database"
+	"github.com/gin-gonic/gin"
+)
+
+type TeachingAssignmentHandler struct {
+	assignmentRepo *repository.TeachingAssignmentRepository
+	teacherRepo    *repository.TeacherRepository
+	sectionRepo    *repository.SectionRepository
+	subjectRepo    *repository.SubjectRepository
+}
+
+func NewTeachingAssignmentHandler() *TeachingAssignmentHandler {
+	return &TeachingAssignmentHandler{
+		assignmentRepo: repository.NewTeachingAssignmentRepository(database.DB),
+		teacherRepo:    repository.NewTeacherRepository(database.DB),
+		sectionRepo:    repository.NewSectionRepository(database.DB),
+		subjectRepo:    repository.NewSubjectRepository(database.DB),
+	}
+}
+
+// GetTeachingAssignments godoc
+// @Summary Get teaching assignments
+// @Description Get teaching assignments with optional filters
+// @Tags Admin - Teaching Assignments
+// @Accept json
+// @Produce json
+// @Param teacher_id query int false "Filter by teacher ID"
+// @Param class_id query int false "Filter by class ID"
+// @Param section_id query int false "Filter by section ID"
+// @Param subject_id query int false "Filter by subject ID"
+// @Param academic_year query string false "Filter by academic year"
+// @Success 200 {array} models.TeachingAssignment
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/teaching-assignments [get]
+// @Security BearerAuth
+func (h *TeachingAssignmentHandler) GetTeachingAssignments(c *gin.Context) {
+	filter := models.TeachingAssignment{AcademicYear: c.Query("academic_year")}
+	if value, err := strconv.ParseUint(c.Query("teacher_id"), 10, 32); err == nil {
+		filter.TeacherID = uint(value)
+	}
+	if value, err := strconv.ParseUint(c.Query("class_id"), 10, 32); err == nil {
+		filter.ClassID = uint(value)
+	}
+	if value, err := strconv.ParseUint(c.Query("section_id"), 10, 32); err == nil {
+		filter.SectionID = uint(value)
+	}
+	if value, err := strconv.ParseUint(c.Query("subject_id"), 10, 32); err == nil {
+		filter.SubjectID = uint(value)
+	}
+
+	assignments, err := h.assignmentRepo.FindAll(filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, assignments)
+}
+
+// GetTeachingAssignment godoc
+// @Summary Get teaching assignment by ID
+// @Description Get a specific teaching assignment by ID
+// @Tags Admin - Teaching Assignments
+// @Accept json
+// @Produce json
+// @Param id path int true "Teaching assignment ID"
+// @Success 200 {object} models.TeachingAssignment
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/teaching-assignments/{id} [get]
+// @Security BearerAuth
+func (h *TeachingAssignmentHandler) GetTeachingAssignment(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	assignment, err := h.assignmentRepo.FindByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Teaching assignment not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, assignment)
+}
+
+// CreateTeachingAssignment godoc
+// @Summary Create teaching assignment
+// @Description Assign a teacher to teach a subject to a section for an academic year. A subject of a section has one teacher and a section has one class teacher per academic year.
+// @Tags Admin - Teaching Assignments
+// @Accept json
+// @Produce json
+// @Param assignment body TeachingAssignmentRequest true "Teaching assignment data"
+// @Success 201 {object} models.TeachingAssignment
+// @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /admin/teaching-assignments [post]
+// @Security BearerAuth
+func (h *TeachingAssignmentHandler) CreateTeachingAssignment(c *gin.Context) {
+	var req TeachingAssignmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	assignment := &models.TeachingAssignment{}
+	req.apply(assignment)
+
+	if !h.checkAssignment(c, assignment) {
+		return
+	}
+
+	if err := h.assignmentRepo.Create(assignment); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, assignment)
+}
+
+// UpdateTeachingAssignment godoc
+// @Summary Update teaching assignment
+// @Description Replace a teaching assignment. The same rules as for creating an assignment apply.
+// @Tags Admin - Teaching Assignments
+// @Accept json
+// @Produce json
+// @Param id path int true "Teaching assignment ID"
+// @Param assignment body TeachingAssignmentRequest true "Teaching assignment data"
+// @Success 200 {object} models.TeachingAssignment
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /admin/teaching-assignments/{id} [put]
+// @Security BearerAuth
+func (h *TeachingAssignmentHandler) UpdateTeachingAssignment(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	var req TeachingAssignmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	assignment, err := h.assignmentRepo.FindByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Teaching assignment not found"})
+		return
+	}
+	req.apply(assignment)
+
+	if !h.checkAssignment(c, assignment) {
+		return
+	}
+
+	if err := h.assignmentRepo.Update(assignment); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, assignment)
+}
+
+// DeleteTeachingAssignment godoc
+// @Summary Delete teaching assignment
+// @Description Delete a teaching assignment
+// @Tags Admin - Teaching Assignments
+// @Accept json
+// @Produce json
+// @Param id path int true "Teaching assignment ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/teaching-assignments/{id} [delete]
+// @Security BearerAuth
+func (h *TeachingAssignmentHandler) DeleteTeachingAssignment(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	if _, err := h.assignmentRepo.FindByID(uint(id)); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Teaching assignment not found"})
+		return
+	}
+
+	if err := h.assignmentRepo.Delete(uint(id)); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Teaching assignment deleted successfully"})
+}
+
+// GetMyTeachingAssignments godoc
+// @Summary Get own teaching assignments
+// @Description Get the classes, sections and subjects the logged in teacher teaches in an academic year (defaults to the current one)
+// @Tags Teaching Assignments
+// @Accept json
+// @Produce json
+// @Param academic_year query string false "Academic year (e.g. 2024-2025)"
+// @Success 200 {array} models.TeachingAssignment
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /teacher/teaching-assignments [get]
+// @Security BearerAuth
+func (h *TeachingAssignmentHandler) GetMyTeachingAssignments(c *gin.Context) {
+	teacher, err := h.teacherRepo.FindByUserID(c.GetUint("user_id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Teacher profile not found"})
+		return
+	}
+	scope, ok := requestScope(c)
+	if !ok {
+		return
+	}
+
+	assignments, err := h.assignmentRepo.Scoped(scope).FindAll(models.TeachingAssignment{
+		TeacherID:    teacher.ID,
+		AcademicYear: academicYearOrCurrent(c),
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, assignments)
+}
+
+// checkAssignment validates the references of an assignment and rejects a
+// second teacher for a subject of a section or a second class teacher.
+func (h *TeachingAssignmentHandler) checkAssignment(c *gin.Context, assignment *models.TeachingAssignment) bool {
+	if _, _, err := services.AcademicYearRange(assignment.AcademicYear, config.AppConfig.AcademicYearStartMonth); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid academic year. Use e.g. 2024-2025"})
+		return false
+	}
+	if _, err := h.teacherRepo.FindByID(assignment.TeacherID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Teacher not found"})
+		return false
+	}
+	if _, err := h.subjectRepo.FindByID(assignment.SubjectID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Subject not found"})
+		return false
+	}
+	section, err := h.sectionRepo.FindByID(assignment.SectionID)
+	if err != nil || section.ClassID != assignment.ClassID {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Section does not belong to the class"})
+		return false
+	}
+
+	existing, err := h.assignmentRepo.FindBySubject(assignment.SectionID, assignment.SubjectID, assignment.AcademicYear)
+	if err == nil && existing.ID != assignment.ID {
+		c.JSON(http.StatusConflict, gin.H{"error": "The subject already has a teacher in this section"})
+		return false
+	}
+
+	if assignment.IsClassTeacher {
+		classTeacher, err := h.assignmentRepo.FindClassTeacher(assignment.SectionID, assignment.AcademicYear)
+		if err == nil && classTeacher.ID != assignment.ID {
+			c.JSON(http.StatusConflict, gin.H{"error": "The section already has a class teacher"})
+			return false
+		}
+	}
+	return true
+}
+
+// teachingAccess limits teachers to the classes, sections and subjects they
+// are assigned to teach. Handlers shared by admins and teachers embed it.
+type teachingAccess struct {
+	teacherRepo    *repository.TeacherRepository
+	assignmentRepo *repository.TeachingAssignmentRepository
+}
+
+func newTeachingAccess() teachingAccess {
+	return teachingAccess{
+		teacherRepo:    repository.NewTeacherRepository(database.DB),
+		assignmentRepo: repository.NewTeachingAssignmentRepository(database.DB),
+	}
+}
+
+// allow reports whether the logged in user may work with a class, section
+// and subject in an academic year, and responds with 403 when not. Admins
+// always may; teachers need a matching teaching assignment. A zero sectionID
+// or subjectID matches any section or subject.
+func (a teachingAccess) allow(c *gin.Context, classID, sectionID, subjectID uint, academicYear string) bool {
+	return a.check(c, classID, sectionID, subjectID, academicYear, false)
+}
+
+// allowClassTeacher is like allow but requires teachers to be the class
+// teacher of the section.
+func (a teachingAccess) allowClassTeacher(c *gin.Context, classID, sectionID uint, academicYear string) bool {
+	return a.check(c, classID, sectionID, 0, academicYear, true)
+}
+
+func (a teachingAccess) check(c *gin.Context, classID, sectionID, subjectID uint, academicYear string, classTeacher bool) bool {
+	if c.GetString("user_role") != "teacher" {
+		return true
+	}
+
+	teacher, err := a.teacherRepo.FindByUserID(c.GetUint("user_id"))
+	if err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Teacher profile not found"})
+		return false
+	}
+
+	teaches, err := a.assignmentRepo.Teaches(teacher.ID, classID, sectionID, subjectID, academicYear, classTeacher)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return false
+	}
+	if !teaches {
+		message := "You are not assigned to teach this class"
+		if classTeacher {
+			message = "Only the class teacher of this section may do this"
+		}
+		c.JSON(http.StatusForbidden, gin.H{"error": message})
+		return false
+	}
+	return true
+}
+
+// academicYearOf returns the academic year containing a date
+func academicYearOf(date time.Time) string {
+	return services.CurrentAcademicYear(date, config.AppConfig.AcademicYearStartMonth)
+}
+
+// Request Types
+type TeachingAssignmentRequest struct {
+	TeacherID      uint   `json:"teacher_id" binding:"required"`
+	ClassID        uint   `json:"class_id" binding:"required"`
+	SectionID      uint   `json:"section_id" binding:"required"`
+	SubjectID      uint   `json:"subject_id" binding:"required"`
+	AcademicYear   string `json:"academic_year" binding:"required"`
+	IsClassTeacher bool   `json:"is_class_teacher"`
+}
+
+func (req *TeachingAssignmentRequest) apply(assignment *models.TeachingAssignment) {
+	assignment.TeacherID = req.TeacherID
+	assignment.ClassID = req.ClassID
+	assignment.SectionID = req.SectionID
+	assignment.SubjectID = req.SubjectID
+	assignment.AcademicYear = req.AcademicYear
+	assignment.IsClassTeacher = req.IsClassTeacher
+}
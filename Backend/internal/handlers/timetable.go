@@ -0,0 +1,428 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+	"school-erp-backend/config"
+	"school-erp-backend/internal/models"
+	"school-erp-backend/internal/repository"
+	"school-erp-backend/internal/services"
+	"school-erp-backend/pkg/database"
+	"github.com/gin-gonic/gin"
+)
+
+type TimetableHandler struct {
+	timetableRepo  *repository.TimetableRepository
+	sectionRepo    *repository.SectionRepository
+	teacherRepo    *repository.TeacherRepository
+	studentRepo    *repository.StudentRepository
+	curriculumRepo *repository.CurriculumRepository
+}
+
+func NewTimetableHandler() *TimetableHandler {
+	return &TimetableHandler{
+		timetableRepo:  repository.NewTimetableRepository(database.DB),
+		sectionRepo:    repository.NewSectionRepository(database.DB),
+		teacherRepo:    repository.NewTeacherRepository(database.DB),
+		studentRepo:    repository.NewStudentRepository(database.DB),
+		curriculumRepo: repository.NewCurriculumRepository(database.DB),
+	}
+}
+
+// GetTimetableSlots godoc
+// @Summary Get timetable slots
+// @Description Get timetable slots with optional filters
+// @Tags Admin - Timetable
+// @Accept json
+// @Produce json
+// @Param class_id query int false "Filter by class ID"
+// @Param section_id query int false "Filter by section ID"
+// @Param teacher_id query int false "Filter by teacher ID"
+// @Param day query string false "Filter by day (Monday, Tuesday, ...)"
+// @Param academic_year query string false "Filter by academic year"
+// @Success 200 {array} models.Timetable
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/timetable [get]
+// @Security BearerAuth
+func (h *TimetableHandler) GetTimetableSlots(c *gin.Context) {
+	var slots []models.Timetable
+	query := database.DB.Preload("Class").Preload("Section").Preload("Subject").Preload("Teacher")
+
+	if classID := c.Query("class_id"); classID != "" {
+		query = query.Where("class_id = ?", classID)
+	}
+	if sectionID := c.Query("section_id"); sectionID != "" {
+		query = query.Where("section_id = ?", sectionID)
+	}
+	if teacherID := c.Query("teacher_id"); teacherID != "" {
+		query = query.Where("teacher_id = ?", teacherID)
+	}
+	if day, ok := services.NormalizeDay(c.Query("day")); ok {
+		query = query.Where("day = ?", day)
+	}
+	if academicYear := c.Query("academic_year"); academicYear != "" {
+		query = query.Where("academic_year = ?", academicYear)
+	}
+
+	if err := query.Order("section_id ASC, period_number ASC").Find(&slots).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, slots)
+}
+
+// GetTimetableSlot godoc
+// @Summary Get timetable slot by ID
+// @Description Get a specific timetable slot by ID
+// @Tags Admin - Timetable
+// @Accept json
+// @Produce json
+// @Param id path int true "Timetable slot ID"
+// @Success 200 {object} models.Timetable
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/timetable/{id} [get]
+// @Security BearerAuth
+func (h *TimetableHandler) GetTimetableSlot(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	slot, err := h.timetableRepo.FindByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Timetable slot not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, slot)
+}
+
+// CreateTimetableSlot godoc
+// @Summary Create timetable slot
+// @Description Create a timetable slot. Rejected when the subject is not in the curriculum of the class, or when the teacher, room or section is already booked at an overlapping period on the same day of the academic year.
+// @Tags Admin - Timetable
+// @Accept json
+// @Produce json
+// @Param slot body TimetableSlotRequest true "Timetable slot data"
+// @Success 201 {object} models.Timetable
+// @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} TimetableClashResponse
+// @Router /admin/timetable [post]
+// @Security BearerAuth
+func (h *TimetableHandler) CreateTimetableSlot(c *gin.Context) {
+	var req TimetableSlotRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	slot := &models.Timetable{}
+	req.apply(slot)
+
+	if !h.checkSlot(c, slot) {
+		return
+	}
+
+	if err := h.timetableRepo.Create(slot); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, slot)
+}
+
+// UpdateTimetableSlot godoc
+// @Summary Update timetable slot
+// @Description Replace a timetable slot. The same clash rules as for creating a slot apply.
+// @Tags Admin - Timetable
+// @Accept json
+// @Produce json
+// @Param id path int true "Timetable slot ID"
+// @Param slot body TimetableSlotRequest true "Timetable slot data"
+// @Success 200 {object} models.Timetable
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} TimetableClashResponse
+// @Router /admin/timetable/{id} [put]
+// @Security BearerAuth
+func (h *TimetableHandler) UpdateTimetableSlot(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	var req TimetableSlotRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	slot, err := h.timetableRepo.FindByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Timetable slot not found"})
+		return
+	}
+	req.apply(slot)
+
+	if !h.checkSlot(c, slot) {
+		return
+	}
+
+	if err := h.timetableRepo.Update(slot); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, slot)
+}
+
+// DeleteTimetableSlot godoc
+// @Summary Delete timetable slot
+// @Description Delete a timetable slot
+// @Tags Admin - Timetable
+// @Accept json
+// @Produce json
+// @Param id path int true "Timetable slot ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/timetable/{id} [delete]
+// @Security BearerAuth
+func (h *TimetableHandler) DeleteTimetableSlot(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	if _, err := h.timetableRepo.FindByID(uint(id)); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Timetable slot not found"})
+		return
+	}
+
+	if err := h.timetableRepo.Delete(uint(id)); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Timetable slot deleted successfully"})
+}
+
+// GetSectionTimetable godoc
+// @Summary Get week timetable of a section
+// @Description Get the week grid of a section for an academic year (defaults to the current one)
+// @Tags Timetable
+// @Accept json
+// @Produce json
+// @Param id path int true "Section ID"
+// @Param academic_year query string false "Academic year (e.g. 2024-2025)"
+// @Success 200 {object} WeekTimetableResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/timetable/section/{id} [get]
+// @Router /teacher/timetable/section/{id} [get]
+// @Security BearerAuth
+func (h *TimetableHandler) GetSectionTimetable(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	scope, ok := requestScope(c)
+	if !ok {
+		return
+	}
+
+	if _, err := h.sectionRepo.Scoped(scope).FindByID(uint(id)); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Section not found"})
+		return
+	}
+
+	h.sectionGrid(c, uint(id))
+}
+
+// GetTeacherTimetable godoc
+// @Summary Get week timetable of a teacher
+// @Description Get the week grid of a teacher for an academic year (defaults to the current one)
+// @Tags Admin - Timetable
+// @Accept json
+// @Produce json
+// @Param id path int true "Teacher ID"
+// @Param academic_year query string false "Academic year (e.g. 2024-2025)"
+// @Success 200 {object} WeekTimetableResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /admin/timetable/teacher/{id} [get]
+// @Security BearerAuth
+func (h *TimetableHandler) GetTeacherTimetable(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	if _, err := h.teacherRepo.FindByID(uint(id)); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Teacher not found"})
+		return
+	}
+
+	h.teacherGrid(c, uint(id))
+}
+
+// GetMyTeacherTimetable godoc
+// @Summary Get own week timetable
+// @Description Get the week grid of the logged in teacher for an academic year (defaults to the current one)
+// @Tags Timetable
+// @Accept json
+// @Produce json
+// @Param academic_year query string false "Academic year (e.g. 2024-2025)"
+// @Success 200 {object} WeekTimetableResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /teacher/timetable [get]
+// @Security BearerAuth
+func (h *TimetableHandler) GetMyTeacherTimetable(c *gin.Context) {
+	teacher, err := h.teacherRepo.FindByUserID(c.GetUint("user_id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Teacher profile not found"})
+		return
+	}
+
+	h.teacherGrid(c, teacher.ID)
+}
+
+// GetMyStudentTimetable godoc
+// @Summary Get own week timetable
+// @Description Get the week grid of the logged in student's section for an academic year (defaults to the current one)
+// @Tags Student - Timetable
+// @Accept json
+// @Produce json
+// @Param academic_year query string false "Academic year (e.g. 2024-2025)"
+// @Success 200 {object} WeekTimetableResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /student/timetable [get]
+// @Security BearerAuth
+func (h *TimetableHandler) GetMyStudentTimetable(c *gin.Context) {
+	student, err := h.studentRepo.FindByUserID(c.GetUint("user_id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Student profile not found"})
+		return
+	}
+
+	h.sectionGrid(c, student.SectionID)
+}
+
+func (h *TimetableHandler) sectionGrid(c *gin.Context, sectionID uint) {
+	scope, ok := requestScope(c)
+	if !ok {
+		return
+	}
+
+	academicYear := academicYearOrCurrent(c)
+	slots, err := h.timetableRepo.Scoped(scope).FindBySection(sectionID, academicYear)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, WeekTimetableResponse{
+		AcademicYear: academicYear,
+		SectionID:    sectionID,
+		Days:         services.WeekGrid(slots),
+	})
+}
+
+func (h *TimetableHandler) teacherGrid(c *gin.Context, teacherID uint) {
+	scope, ok := requestScope(c)
+	if !ok {
+		return
+	}
+
+	academicYear := academicYearOrCurrent(c)
+	slots, err := h.timetableRepo.Scoped(scope).FindByTeacher(teacherID, academicYear)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, WeekTimetableResponse{
+		AcademicYear: academicYear,
+		TeacherID:    teacherID,
+		Days:         services.WeekGrid(slots),
+	})
+}
+
+// checkSlot validates a slot and rejects it when it clashes with existing
+// slots, writing the error response.
+func (h *TimetableHandler) checkSlot(c *gin.Context, slot *models.Timetable) bool {
+	if err := services.ValidateTimetableSlot(slot); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return false
+	}
+
+	section, err := h.sectionRepo.FindByID(slot.SectionID)
+	if err != nil || section.ClassID != slot.ClassID {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Section does not belong to the class"})
+		return false
+	}
+
+	_, err = services.CurriculumEntry(h.curriculumRepo, slot.ClassID, slot.SubjectID, slot.AcademicYear)
+	if errors.Is(err, services.ErrNotInCurriculum) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Subject is not in the curriculum of the class for this academic year"})
+		return false
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return false
+	}
+
+	existing, err := h.timetableRepo.FindPotentialClashes(slot)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return false
+	}
+
+	if clashes := services.FindTimetableClashes(*slot, existing); len(clashes) > 0 {
+		c.JSON(http.StatusConflict, TimetableClashResponse{
+			Error:   "Timetable slot clashes with existing slots",
+			Clashes: clashes,
+		})
+		return false
+	}
+	return true
+}
+
+// academicYearOrCurrent returns the academic_year query parameter or the
+// academic year of today.
+func academicYearOrCurrent(c *gin.Context) string {
+	if academicYear := c.Query("academic_year"); academicYear != "" {
+		return academicYear
+	}
+	return services.CurrentAcademicYear(time.Now(), config.AppConfig.AcademicYearStartMonth)
+}
+
+// Request Types
+type TimetableSlotRequest struct {
+	ClassID      uint   `json:"class_id" binding:"required"`
+	SectionID    uint   `json:"section_id" binding:"required"`
+	Day          string `json:"day" binding:"required"`
+	PeriodNumber int    `json:"period_number" binding:"required,min=1"`
+	SubjectID    uint   `json:"subject_id" binding:"required"`
+	TeacherID    uint   `json:"teacher_id" binding:"required"`
+	StartTime    string `json:"start_time" binding:"required"` // HH:MM
+	EndTime      string `json:"end_time" binding:"required"`   // HH:MM
+	RoomNumber   string `json:"room_number"`
+	AcademicYear string `json:"academic_year" binding:"required"`
+}
+
+func (req *TimetableSlotRequest) apply(slot *models.Timetable) {
+	slot.ClassID = req.ClassID
+	slot.SectionID = req.SectionID
+	slot.Day = req.Day
+	slot.PeriodNumber = req.PeriodNumber
+	slot.SubjectID = req.SubjectID
+	slot.TeacherID = req.TeacherID
+	slot.StartTime = req.StartTime
+	slot.EndTime = req.EndTime
+	slot.RoomNumber = req.RoomNumber
+	slot.AcademicYear = req.AcademicYear
+}
+
+// Response Types
+type TimetableClashResponse struct {
+	Error   string                    `json:"error"`
+	Clashes []services.TimetableClash `json:"clashes"`
+}
+
+type WeekTimetableResponse struct {
+	AcademicYear string                  `json:"academic_year"`
+	SectionID    uint                    `json:"section_id,omitempty"`
+	TeacherID    uint                    `json:"teacher_id,omitempty"`
+	Days         []services.TimetableDay `json:"days"`
+}
@@ -0,0 +1,236 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"school-erp-backend/internal/models"
+	"school-erp-backend/internal/repository"
+	"school-erp-backend/internal/services"
+	"school-erp-backend/pkg/database"
+	"github.com/gin-gonic/gin"
+)
+
+const timetableGenerationJob = "timetable_generation"
+
+type TimetableGeneratorHandler struct {
+	jobRepo        *repository.JobRepository
+	timetableRepo  *repository.TimetableRepository
+	sectionRepo    *repository.SectionRepository
+	curriculumRepo *repository.CurriculumRepository
+}
+
+func NewTimetableGeneratorHandler() *TimetableGeneratorHandler {
+	return &TimetableGeneratorHandler{
+		jobRepo:        repository.NewJobRepository(database.DB),
+		timetableRepo:  repository.NewTimetableRepository(database.DB),
+		sectionRepo:    repository.NewSectionRepository(database.DB),
+		curriculumRepo: repository.NewCurriculumRepository(database.DB),
+	}
+}
+
+// GenerateTimetable godoc
+// @Summary Generate a timetable
+// @Description Start a background job that builds a clash-free timetable for the given sections from their weekly subject periods, the subjects and availability of teachers, dedicated rooms and the period timings. Sections without subjects get the core subjects of their class curriculum with its weekly periods. Existing slots of other sections are respected. The result is only a preview until it is committed.
+// @Tags Admin - Timetable
+// @Accept json
+// @Produce json
+// @Param requirements body services.TimetableRequirements true "Teaching requirements"
+// @Success 202 {object} models.Job
+// @Failure 400 {object} ErrorResponse
+// @Router /admin/timetable/generate [post]
+// @Security BearerAuth
+func (h *TimetableGeneratorHandler) GenerateTimetable(c *gin.Context) {
+	var req services.TimetableRequirements
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	for i, requirement := range req.Sections {
+		section, err := h.sectionRepo.FindByID(requirement.SectionID)
+		if err != nil || section.ClassID != requirement.ClassID {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Section %d does not belong to class %d", requirement.SectionID, requirement.ClassID)})
+			return
+		}
+		if len(requirement.Subjects) > 0 {
+			continue
+		}
+
+		entries, err := h.curriculumRepo.FindByClass(requirement.ClassID, req.AcademicYear)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		for _, entry := range entries {
+			if !entry.IsElective && entry.WeeklyPeriods > 0 {
+				req.Sections[i].Subjects = append(req.Sections[i].Subjects, services.SubjectRequirement{
+					SubjectID:      entry.SubjectID,
+					PeriodsPerWeek: entry.WeeklyPeriods,
+				})
+			}
+		}
+	}
+
+	if err := services.ValidateTimetableRequirements(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	payload, _ := json.Marshal(req)
+	job := &models.Job{
+		Type:      timetableGenerationJob,
+		Payload:   string(payload),
+		CreatedBy: c.GetUint("user_id"),
+	}
+
+	err := services.StartJob(h.jobRepo, job, func() (interface{}, error) {
+		existing, err := h.timetableRepo.FindByAcademicYear(req.AcademicYear)
+		if err != nil {
+			return nil, err
+		}
+		return services.GenerateTimetable(&req, existing), nil
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start timetable generation job"})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, job)
+}
+
+// GetGeneratedTimetable godoc
+// @Summary Preview a generated timetable
+// @Description Get the status of a timetable generation job and, once it has completed, the generated week grid of every section or the reasons no timetable could be found
+// @Tags Admin - Timetable
+// @Produce json
+// @Param job_id path int true "Job ID"
+// @Success 200 {object} TimetablePreviewResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/timetable/generate/{job_id} [get]
+// @Security BearerAuth
+func (h *TimetableGeneratorHandler) GetGeneratedTimetable(c *gin.Context) {
+	job, result, ok := h.generationJob(c)
+	if !ok {
+		return
+	}
+
+	response := TimetablePreviewResponse{Job: *job, Result: result}
+	if result != nil {
+		bySection := make(map[uint][]models.Timetable)
+		for _, slot := range result.Slots {
+			bySection[slot.SectionID] = append(bySection[slot.SectionID], slot)
+		}
+		for _, sectionID := range result.SectionIDs {
+			response.Sections = append(response.Sections, WeekTimetableResponse{
+				AcademicYear: result.AcademicYear,
+				SectionID:    sectionID,
+				Days:         services.WeekGrid(bySection[sectionID]),
+			})
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// CommitGeneratedTimetable godoc
+// @Summary Commit a generated timetable
+// @Description Save the slots of a completed timetable generation job. Sections that already have a timetable for the academic year are rejected unless replace_existing is set, in which case their slots are replaced. The slots are checked for clashes again, as the timetable may have changed since generation.
+// @Tags Admin - Timetable
+// @Accept json
+// @Produce json
+// @Param job_id path int true "Job ID"
+// @Param commit body CommitTimetableRequest false "Commit options"
+// @Success 201 {object} TimetableCommitResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} TimetableClashResponse
+// @Router /admin/timetable/generate/{job_id}/commit [post]
+// @Security BearerAuth
+func (h *TimetableGeneratorHandler) CommitGeneratedTimetable(c *gin.Context) {
+	var req CommitTimetableRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	job, result, ok := h.generationJob(c)
+	if !ok {
+		return
+	}
+	if result == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Timetable generation job is %s", job.Status)})
+		return
+	}
+	if !result.Feasible {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Timetable generation found no feasible timetable"})
+		return
+	}
+
+	err := services.CommitGeneratedTimetable(database.DB, result, req.ReplaceExisting)
+	var clashErr *services.TimetableClashError
+	if errors.As(err, &clashErr) {
+		c.JSON(http.StatusConflict, TimetableClashResponse{
+			Error:   "Generated timetable clashes with existing slots",
+			Clashes: clashErr.Clashes,
+		})
+		return
+	}
+	if errors.Is(err, services.ErrSectionsHaveTimetable) {
+		c.JSON(http.StatusConflict, gin.H{"error": "Some sections already have a timetable for this academic year. Set replace_existing to replace it"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save timetable"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, TimetableCommitResponse{
+		AcademicYear: result.AcademicYear,
+		SectionIDs:   result.SectionIDs,
+		Slots:        len(result.Slots),
+	})
+}
+
+// generationJob loads the timetable generation job in the job_id path
+// parameter and, once it has completed, its result.
+func (h *TimetableGeneratorHandler) generationJob(c *gin.Context) (*models.Job, *services.TimetableGenerationResult, bool) {
+	id, _ := strconv.ParseUint(c.Param("job_id"), 10, 32)
+	job, err := h.jobRepo.FindByID(uint(id))
+	if err != nil || job.Type != timetableGenerationJob {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Timetable generation job not found"})
+		return nil, nil, false
+	}
+
+	if job.Status != "completed" {
+		return job, nil, true
+	}
+	var result services.TimetableGenerationResult
+	if err := json.Unmarshal([]byte(job.Result), &result); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to read timetable generation result"})
+		return nil, nil, false
+	}
+	return job, &result, true
+}
+
+// Request Types
+type CommitTimetableRequest struct {
+	ReplaceExisting bool `json:"replace_existing"`
+}
+
+// Response Types
+type TimetablePreviewResponse struct {
+	Job      models.Job                          `json:"job"`
+	Result   *services.TimetableGenerationResult `json:"result,omitempty"`
+	Sections []WeekTimetableResponse             `json:"sections,omitempty"`
+}
+
+type TimetableCommitResponse struct {
+	AcademicYear string `json:"academic_year"`
+	SectionIDs   []uint `json:"section_ids"`
+	Slots        int    `json:"slots"`
+}
@@ -0,0 +1,335 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"school-erp-backend/config"
+	"school-erp-backend/internal/models"
+	"school-erp-backend/internal/repository"
+	"school-erp-backend/internal/services"
+	"school-erp-backend/pkg/database"
+	"school-erp-backend/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type TwoFactorHandler struct {
+	twoFactorRepo *repository.TwoFactorRepository
+	userRepo      *repository.UserRepository
+	lockoutRepo   *repository.AccountLockoutRepository
+	roleRepo      *repository.RoleRepository
+}
+
+func NewTwoFactorHandler() *TwoFactorHandler {
+	return &TwoFactorHandler{
+		twoFactorRepo: repository.NewTwoFactorRepository(database.DB),
+		userRepo:      repository.NewUserRepository(database.DB),
+		lockoutRepo:   repository.NewAccountLockoutRepository(database.DB),
+		roleRepo:      repository.NewRoleRepository(database.DB),
+	}
+}
+
+// GetStatus godoc
+// @Summary Get two-factor status
+// @Description Get whether two-factor authentication is enabled for the logged in user, whether any of their roles requires it, and how many unused recovery codes they have left
+// @Tags Two-Factor Authentication
+// @Produce json
+// @Success 200 {object} TwoFactorStatusResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /auth/2fa [get]
+// @Security BearerAuth
+func (h *TwoFactorHandler) GetStatus(c *gin.Context) {
+	userID := c.GetUint("user_id")
+	roles, err := services.UserRoleNames(h.roleRepo, userID, c.GetString("user_role"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	response := TwoFactorStatusResponse{
+		Required: services.TwoFactorRequired(roles, config.AppConfig.TwoFactorRequiredRoles),
+	}
+
+	auth, err := h.twoFactorRepo.FindByUser(userID)
+	if err == nil && auth.Enabled {
+		response.Enabled = true
+		response.EnabledAt = auth.EnabledAt
+		if response.RecoveryCodesLeft, err = h.twoFactorRepo.CountRecoveryCodes(userID); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// SetUp godoc
+// @Summary Start two-factor setup
+// @Description Generate a new TOTP secret for the logged in user and its otpauth:// URI, to be shown as a QR code. It takes effect once confirmed with a code at /auth/2fa/enable.
+// @Tags Two-Factor Authentication
+// @Produce json
+// @Success 200 {object} TwoFactorSetupResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /auth/2fa/setup [post]
+// @Security BearerAuth
+func (h *TwoFactorHandler) SetUp(c *gin.Context) {
+	user, ok := h.user(c)
+	if !ok {
+		return
+	}
+
+	setup, err := services.SetUpTwoFactor(h.twoFactorRepo, user, config.AppConfig.SchoolName)
+	if errors.Is(err, services.ErrTwoFactorAlreadyEnabled) {
+		c.JSON(http.StatusConflict, gin.H{"error": "Two-factor authentication is already enabled"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to set up two-factor authentication"})
+		return
+	}
+
+	c.JSON(http.StatusOK, newTwoFactorSetupResponse(setup))
+}
+
+// Enable godoc
+// @Summary Enable two-factor authentication
+// @Description Confirm the secret from setup with a code from the authenticator app. The response carries recovery codes, which are shown only this once.
+// @Tags Two-Factor Authentication
+// @Accept json
+// @Produce json
+// @Param enable body TwoFactorCodeRequest true "Code from the authenticator app"
+// @Success 200 {object} RecoveryCodesResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 429 {object} ErrorResponse
+// @Router /auth/2fa/enable [post]
+// @Security BearerAuth
+func (h *TwoFactorHandler) Enable(c *gin.Context) {
+	var req TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !h.checkAttempts(c) {
+		return
+	}
+
+	codes, err := services.EnableTwoFactor(database.DB, c.GetUint("user_id"), req.Code)
+	switch {
+	case errors.Is(err, services.ErrInvalidTwoFactorCode):
+		h.attemptFailed(c)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid two-factor code"})
+		return
+	case errors.Is(err, services.ErrTwoFactorNotSetUp):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Set up two-factor authentication first"})
+		return
+	case errors.Is(err, services.ErrTwoFactorAlreadyEnabled):
+		c.JSON(http.StatusConflict, gin.H{"error": "Two-factor authentication is already enabled"})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to enable two-factor authentication"})
+		return
+	}
+
+	c.JSON(http.StatusOK, RecoveryCodesResponse{RecoveryCodes: codes})
+}
+
+// Disable godoc
+// @Summary Disable two-factor authentication
+// @Description Turn off two-factor authentication for the logged in user, who must give their password and a current code. Not allowed for roles that require it.
+// @Tags Two-Factor Authentication
+// @Accept json
+// @Produce json
+// @Param disable body DisableTwoFactorRequest true "Password and code"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 429 {object} ErrorResponse
+// @Router /auth/2fa/disable [post]
+// @Security BearerAuth
+func (h *TwoFactorHandler) Disable(c *gin.Context) {
+	var req DisableTwoFactorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, ok := h.user(c)
+	if !ok {
+		return
+	}
+	roles, err := services.UserRoleNames(h.roleRepo, user.ID, user.Role)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if services.TwoFactorRequired(roles, config.AppConfig.TwoFactorRequiredRoles) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Two-factor authentication is required for your role"})
+		return
+	}
+	if !utils.CheckPasswordHash(req.Password, user.PasswordHash) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Password is incorrect"})
+		return
+	}
+	if !h.verifyCode(c, req.Code) {
+		return
+	}
+
+	if err := services.DisableTwoFactor(h.twoFactorRepo, user.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to disable two-factor authentication"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Two-factor authentication disabled successfully"})
+}
+
+// RegenerateRecoveryCodes godoc
+// @Summary Regenerate recovery codes
+// @Description Replace the recovery codes of the logged in user with new ones, given a current code. The earlier codes stop working.
+// @Tags Two-Factor Authentication
+// @Accept json
+// @Produce json
+// @Param regenerate body TwoFactorCodeRequest true "Code from the authenticator app or a recovery code"
+// @Success 200 {object} RecoveryCodesResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 429 {object} ErrorResponse
+// @Router /auth/2fa/recovery-codes [post]
+// @Security BearerAuth
+func (h *TwoFactorHandler) RegenerateRecoveryCodes(c *gin.Context) {
+	var req TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !h.verifyCode(c, req.Code) {
+		return
+	}
+
+	codes, err := services.RegenerateRecoveryCodes(h.twoFactorRepo, c.GetUint("user_id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate recovery codes"})
+		return
+	}
+
+	c.JSON(http.StatusOK, RecoveryCodesResponse{RecoveryCodes: codes})
+}
+
+// ResetUserTwoFactor godoc
+// @Summary Reset two-factor authentication of a user
+// @Description Remove the TOTP secret and recovery codes of a user who has lost their authenticator app. If their role requires two-factor authentication they set it up again at their next login.
+// @Tags Admin - Users
+// @Produce json
+// @Param id path int true "User ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/users/{id}/two-factor [delete]
+// @Security BearerAuth
+func (h *TwoFactorHandler) ResetUserTwoFactor(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+
+	user, err := h.userRepo.FindByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return
+	}
+	if !checkUserManageable(c, h.roleRepo, user) {
+		return
+	}
+
+	if err := services.DisableTwoFactor(h.twoFactorRepo, user.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reset two-factor authentication"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Two-factor authentication reset successfully"})
+}
+
+func (h *TwoFactorHandler) user(c *gin.Context) (*models.User, bool) {
+	user, err := h.userRepo.FindByID(c.GetUint("user_id"))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+		return nil, false
+	}
+	return user, true
+}
+
+// verifyCode checks a TOTP or recovery code of the logged in user. Wrong
+// codes count as failed logins, so codes cannot be guessed with a stolen
+// session either.
+func (h *TwoFactorHandler) verifyCode(c *gin.Context, code string) bool {
+	if !h.checkAttempts(c) {
+		return false
+	}
+
+	err := services.VerifyTwoFactor(h.twoFactorRepo, c.GetUint("user_id"), code)
+	switch {
+	case errors.Is(err, services.ErrInvalidTwoFactorCode):
+		h.attemptFailed(c)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid two-factor code"})
+		return false
+	case errors.Is(err, services.ErrTwoFactorNotSetUp):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Two-factor authentication is not enabled"})
+		return false
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to verify two-factor code"})
+		return false
+	}
+	return true
+}
+
+func (h *TwoFactorHandler) checkAttempts(c *gin.Context) bool {
+	email := strings.ToLower(c.GetString("user_email"))
+	err := services.CheckLogin(h.lockoutRepo, loginPolicy(), email, c.ClientIP(), time.Now())
+	var throttled *services.LoginThrottledError
+	if errors.As(err, &throttled) {
+		respondLoginThrottled(c, throttled)
+		return false
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check login attempts"})
+		return false
+	}
+	return true
+}
+
+func (h *TwoFactorHandler) attemptFailed(c *gin.Context) {
+	email := strings.ToLower(c.GetString("user_email"))
+	_ = services.RecordLoginFailure(h.lockoutRepo, loginPolicy(), email, c.ClientIP(), time.Now())
+}
+
+func newTwoFactorSetupResponse(setup *services.TwoFactorSetup) TwoFactorSetupResponse {
+	return TwoFactorSetupResponse{
+		Secret:          setup.Secret,
+		ProvisioningURI: setup.URI,
+	}
+}
+
+// Request Types
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+type DisableTwoFactorRequest struct {
+	Password string `json:"password" binding:"required"`
+	Code     string `json:"code" binding:"required"` // TOTP code or recovery code
+}
+
+// Response Types
+type TwoFactorStatusResponse struct {
+	Enabled           bool       `json:"enabled"`
+	Required          bool       `json:"required"` // the role of the user requires two-factor authentication
+	EnabledAt         *time.Time `json:"enabled_at,omitempty"`
+	RecoveryCodesLeft int64      `json:"recovery_codes_left"`
+}
+
+type TwoFactorSetupResponse struct {
+	Secret          string `json:"secret"`           // for typing into the authenticator app
+	ProvisioningURI string `json:"provisioning_uri"` // otpauth:// URI to show as a QR code
+}
+
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
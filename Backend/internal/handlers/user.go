@@ -6,18 +6,23 @@ import (
 	"strings"
 	"school-erp-backend/internal/models"
 	"school-erp-backend/internal/repository"
+	"school-erp-backend/internal/services"
 	"school-erp-backend/pkg/database"
 	"school-erp-backend/pkg/utils"
 	"github.com/gin-gonic/gin"
 )
 
 type UserHandler struct {
-	userRepo *repository.UserRepository
+	userRepo    *repository.UserRepository
+	sessionRepo *repository.SessionRepository
+	roleRepo    *repository.RoleRepository
 }
 
 func NewUserHandler() *UserHandler {
 	return &UserHandler{
-		userRepo: repository.NewUserRepository(database.DB),
+		userRepo:    repository.NewUserRepository(database.DB),
+		sessionRepo: repository.NewSessionRepository(database.DB),
+		roleRepo:    repository.NewRoleRepository(database.DB),
 	}
 }
 
@@ -27,7 +32,7 @@ func NewUserHandler() *UserHandler {
 // @Tags Admin - Users
 // @Accept json
 // @Produce json
-// @Param role query string false "Filter by role (admin, teacher, student)"
+// @Param role query string false "Filter by primary role (admin, teacher, student, ...)"
 // @Param status query string false "Filter by status (active, inactive)"
 // @Success 200 {array} UserResponse
 // @Failure 500 {object} ErrorResponse
@@ -100,13 +105,14 @@ func (h *UserHandler) GetUser(c *gin.Context) {
 
 // CreateUser godoc
 // @Summary Create a new user
-// @Description Create a new user account
+// @Description Create a new user account. The role is the primary role of the user and must exist; only roles whose permissions the current user holds can be given.
 // @Tags Admin - Users
 // @Accept json
 // @Produce json
 // @Param user body CreateUserRequest true "User data"
 // @Success 201 {object} UserResponse
 // @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
 // @Router /admin/users [post]
 // @Security BearerAuth
 func (h *UserHandler) CreateUser(c *gin.Context) {
@@ -132,8 +138,7 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 
 	// Validate and normalize role
 	role := strings.ToLower(strings.TrimSpace(req.Role))
-	if role != "admin" && role != "teacher" && role != "student" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid role. Must be admin, teacher, or student"})
+	if !checkRoleGrant(c, h.roleRepo, role) {
 		return
 	}
 
@@ -163,7 +168,7 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 
 // UpdateUser godoc
 // @Summary Update user
-// @Description Update an existing user
+// @Description Update an existing user. Changing the password or role, or deactivating the user, signs them out of every session. Users with permissions the current user lacks cannot be changed.
 // @Tags Admin - Users
 // @Accept json
 // @Produce json
@@ -171,6 +176,7 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 // @Param user body UpdateUserRequest true "User data"
 // @Success 200 {object} UserResponse
 // @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
 // @Router /admin/users/{id} [put]
 // @Security BearerAuth
@@ -186,6 +192,9 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
 		return
 	}
+	if !checkUserManageable(c, h.roleRepo, user) {
+		return
+	}
 
 	var req UpdateUserRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -193,6 +202,10 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 		return
 	}
 
+	// Sessions carry the role and must not outlive a password change or
+	// deactivation
+	signOut := false
+
 	// Update email if provided and check for duplicates (case-insensitive)
 	if req.Email != "" {
 		email := strings.ToLower(strings.TrimSpace(req.Email))
@@ -214,21 +227,23 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 			return
 		}
 		user.PasswordHash = passwordHash
+		signOut = true
 	}
 
 	// Update role if provided
 	if req.Role != "" {
 		role := strings.ToLower(strings.TrimSpace(req.Role))
-		if role != "admin" && role != "teacher" && role != "student" {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid role. Must be admin, teacher, or student"})
+		if role != user.Role && !checkRoleGrant(c, h.roleRepo, role) {
 			return
 		}
+		signOut = signOut || role != user.Role
 		user.Role = role
 	}
 
 	// Update status if provided
 	if req.Status != "" {
 		user.Status = req.Status
+		signOut = signOut || user.Status != "active"
 	}
 
 	if err := h.userRepo.Update(user); err != nil {
@@ -236,6 +251,13 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 		return
 	}
 
+	if signOut {
+		if err := services.RevokeUserSessions(h.sessionRepo, user.ID); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to revoke sessions"})
+			return
+		}
+	}
+
 	c.JSON(http.StatusOK, UserResponse{
 		ID:     user.ID,
 		Email:  user.Email,
@@ -246,13 +268,14 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 
 // DeleteUser godoc
 // @Summary Delete user
-// @Description Delete a user by ID
+// @Description Delete a user by ID and sign them out of every session
 // @Tags Admin - Users
 // @Accept json
 // @Produce json
 // @Param id path int true "User ID"
 // @Success 200 {object} map[string]string
 // @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
 // @Router /admin/users/{id} [delete]
 // @Security BearerAuth
@@ -264,17 +287,25 @@ func (h *UserHandler) DeleteUser(c *gin.Context) {
 	}
 
 	// Check if user exists
-	_, err = h.userRepo.FindByID(uint(id))
+	user, err := h.userRepo.FindByID(uint(id))
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
 		return
 	}
+	if !checkUserManageable(c, h.roleRepo, user) {
+		return
+	}
 
 	if err := h.userRepo.Delete(uint(id)); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete user"})
 		return
 	}
 
+	if err := services.RevokeUserSessions(h.sessionRepo, uint(id)); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to revoke sessions"})
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{"message": "User deleted successfully"})
 }
 
@@ -4,6 +4,9 @@ import (
 	"net/http"
 	"strings"
 
+	"school-erp-backend/internal/repository"
+	"school-erp-backend/internal/services"
+	"school-erp-backend/pkg/database"
 	"school-erp-backend/pkg/jwt"
 	"github.com/gin-gonic/gin"
 )
@@ -51,11 +54,27 @@ func AuthMiddleware() gin.HandlerFunc {
 			c.Abort()
 			return
 		}
+		if claims.Type != jwt.TokenTypeAccess {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token type"})
+			c.Abort()
+			return
+		}
+
+		// Reject tokens of revoked sessions before they expire
+		sessionRepo := repository.NewSessionRepository(database.DB)
+		session, err := sessionRepo.FindByID(claims.SessionID)
+		if err != nil || session.UserID != claims.UserID || session.RevokedAt != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Session has been revoked"})
+			c.Abort()
+			return
+		}
+		_ = services.TouchSession(sessionRepo, session)
 
 		// Set user context
 		c.Set("user_id", claims.UserID)
 		c.Set("user_email", claims.Email)
 		c.Set("user_role", claims.Role)
+		c.Set("session_id", claims.SessionID)
 
 		c.Next()
 	}
@@ -83,3 +102,48 @@ func RoleMiddleware(allowedRoles ...string) gin.HandlerFunc {
 	}
 }
 
+
+// RequirePermission lets the request through when the user holds every one of
+// the permissions through any of their roles. Unknown permission names panic
+// when the routes are set up, so typos cannot silently lock routes.
+func RequirePermission(permissions ...string) gin.HandlerFunc {
+	for _, permission := range permissions {
+		if !services.IsPermission(permission) {
+			panic("unknown permission: " + permission)
+		}
+	}
+
+	return func(c *gin.Context) {
+		held, ok := Permissions(c)
+		if !ok {
+			c.Abort()
+			return
+		}
+
+		for _, permission := range permissions {
+			if !services.HasPermission(held, permission) {
+				c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+				c.Abort()
+				return
+			}
+		}
+
+		c.Next()
+	}
+}
+
+// Permissions returns the effective permissions of the authenticated user,
+// loading them once per request. On failure it has already responded.
+func Permissions(c *gin.Context) ([]string, bool) {
+	if held, exists := c.Get("permissions"); exists {
+		return held.([]string), true
+	}
+
+	held, err := services.EffectivePermissions(repository.NewRoleRepository(database.DB), c.GetUint("user_id"), c.GetString("user_role"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load permissions"})
+		return nil, false
+	}
+	c.Set("permissions", held)
+	return held, true
+}
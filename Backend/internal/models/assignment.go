@@ -28,10 +28,16 @@ type AssignmentSubmission struct {
 	AssignmentID  uint           `gorm:"not null" json:"assignment_id"`
 	StudentID     uint           `gorm:"not null" json:"student_id"`
 	SubmissionDate time.Time     `gorm:"not null" json:"submission_date"`
-	FilePath      string         `json:"file_path"`
+	FilePath      string         `json:"file_path"` // storage key
+	FileName      string         `json:"file_name"`
+	ContentType   string         `json:"content_type"`
+	FileSize      int64          `json:"file_size"`
 	MarksObtained float64        `json:"marks_obtained"`
 	Feedback      string         `json:"feedback"`
 	Status        string         `gorm:"default:pending" json:"status"` // pending, graded, late
+	IsLate        bool           `gorm:"default:false" json:"is_late"`  // kept once graded
+	GradedBy      *uint          `json:"graded_by"`
+	GradedAt      *time.Time     `json:"graded_at"`
 	SubmittedAt   time.Time      `json:"submitted_at"`
 	UpdatedAt     time.Time      `json:"updated_at"`
 	DeletedAt     gorm.DeletedAt `gorm:"index" json:"-"`
@@ -9,10 +9,13 @@ type CalendarEvent struct {
 	ID          uint           `gorm:"primaryKey" json:"id"`
 	Title       string         `gorm:"not null" json:"title"`
 	Description string         `gorm:"type:text" json:"description"`
-	StartDate   time.Time      `gorm:"not null" json:"start_date"`
-	EndDate     time.Time      `json:"end_date"`
-	EventType   string         `gorm:"not null" json:"event_type"` // holiday, exam, event, meeting
-	Visibility  string         `gorm:"default:all" json:"visibility"` // all, admin, teacher, student
+	StartDate   time.Time      `gorm:"not null;index" json:"start_date"`
+	EndDate     time.Time      `gorm:"not null" json:"end_date"` // last day of all-day events, end time of others
+	AllDay      bool           `json:"all_day"`
+	EventType   string         `gorm:"not null" json:"event_type"`    // holiday, exam, event, meeting
+	Visibility  string         `gorm:"default:all" json:"visibility"` // all, admin, teacher, student, parent
+	Recurrence  string         `json:"recurrence"`                    // RRULE without the prefix, e.g. FREQ=WEEKLY;BYDAY=MO
+	OccursUntil *time.Time     `gorm:"index" json:"occurs_until"`     // end of the last occurrence, nil when repeating forever
 	CreatedBy   uint           `gorm:"not null" json:"created_by"`
 	CreatedAt   time.Time      `json:"created_at"`
 	UpdatedAt   time.Time      `json:"updated_at"`
@@ -21,5 +24,12 @@ type CalendarEvent struct {
 	Creator User `gorm:"foreignKey:CreatedBy" json:"creator,omitempty"`
 }
 
-
-
+// CalendarFeedToken is the secret in the iCalendar feed URL of a user, so
+// calendar apps can subscribe without logging in. A user has at most one.
+type CalendarFeedToken struct {
+	ID         uint       `gorm:"primaryKey" json:"id"`
+	UserID     uint       `gorm:"not null;uniqueIndex" json:"user_id"`
+	Token      string     `gorm:"not null;uniqueIndex" json:"-"` // SHA-256 of the token in the URL
+	LastUsedAt *time.Time `json:"last_used_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+	"gorm.io/gorm"
+)
+
+// CurriculumSubject places a subject in the curriculum of a class for an
+// academic year.
+type CurriculumSubject struct {
+	ID            uint           `gorm:"primaryKey" json:"id"`
+	ClassID       uint           `gorm:"not null;index" json:"class_id"`
+	SubjectID     uint           `gorm:"not null" json:"subject_id"`
+	AcademicYear  string         `gorm:"not null" json:"academic_year"`
+	IsElective    bool           `gorm:"default:false" json:"is_elective"`
+	WeeklyPeriods int            `json:"weekly_periods"`
+	MaxMarks      float64        `json:"max_marks"`
+	Capacity      int            `gorm:"default:0" json:"capacity"` // seats of an elective, 0 for no limit
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `gorm:"index" json:"-"`
+
+	Class   Class   `gorm:"foreignKey:ClassID" json:"class,omitempty"`
+	Subject Subject `gorm:"foreignKey:SubjectID" json:"subject,omitempty"`
+}
+
+// ElectiveEnrollment records that a student takes an elective subject of
+// their class in an academic year.
+type ElectiveEnrollment struct {
+	ID           uint           `gorm:"primaryKey" json:"id"`
+	StudentID    uint           `gorm:"not null;index" json:"student_id"`
+	ClassID      uint           `gorm:"not null" json:"class_id"`
+	SubjectID    uint           `gorm:"not null;index" json:"subject_id"`
+	AcademicYear string         `gorm:"not null" json:"academic_year"`
+	EnrolledBy   uint           `gorm:"not null" json:"enrolled_by"` // User ID of the admin
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+	DeletedAt    gorm.DeletedAt `gorm:"index" json:"-"`
+
+	Student Student `gorm:"foreignKey:StudentID" json:"student,omitempty"`
+	Subject Subject `gorm:"foreignKey:SubjectID" json:"subject,omitempty"`
+}
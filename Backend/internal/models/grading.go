@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+	"gorm.io/gorm"
+)
+
+type GradingScale struct {
+	ID          uint           `gorm:"primaryKey" json:"id"`
+	Name        string         `gorm:"unique;not null" json:"name"` // e.g., "CBSE 9-point", "A-F"
+	Description string         `json:"description"`
+	Status      string         `gorm:"default:active" json:"status"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+
+	// Relationships
+	Bands []GradeBand `gorm:"foreignKey:GradingScaleID" json:"bands,omitempty"`
+}
+
+type GradeBand struct {
+	ID             uint      `gorm:"primaryKey" json:"id"`
+	GradingScaleID uint      `gorm:"not null;index" json:"grading_scale_id"`
+	MinPercentage  float64   `gorm:"not null" json:"min_percentage"` // inclusive
+	MaxPercentage  float64   `gorm:"not null" json:"max_percentage"`
+	Grade          string    `gorm:"not null" json:"grade"`
+	GradePoint     float64   `json:"grade_point"`
+	Description    string    `json:"description"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// ClassGradingScale selects the grading scale used for a class in an academic year
+type ClassGradingScale struct {
+	ID             uint      `gorm:"primaryKey" json:"id"`
+	ClassID        uint      `gorm:"not null;uniqueIndex:idx_class_grading_scale_year" json:"class_id"`
+	AcademicYear   string    `gorm:"not null;uniqueIndex:idx_class_grading_scale_year" json:"academic_year"`
+	GradingScaleID uint      `gorm:"not null" json:"grading_scale_id"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+
+	Class        Class        `gorm:"foreignKey:ClassID" json:"class,omitempty"`
+	GradingScale GradingScale `gorm:"foreignKey:GradingScaleID" json:"grading_scale,omitempty"`
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+	"gorm.io/gorm"
+)
+
+// Guardian is a parent or guardian who logs in with a user of the parent
+// role. A guardian may be linked to several students, e.g. siblings.
+type Guardian struct {
+	ID        uint           `gorm:"primaryKey" json:"id"`
+	UserID    uint           `gorm:"not null;unique" json:"user_id"`
+	Name      string         `gorm:"not null" json:"name"`
+	Phone     string         `gorm:"index" json:"phone"`
+	Address   string         `json:"address"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+
+	User User `gorm:"foreignKey:UserID" json:"user,omitempty"`
+}
+
+// StudentGuardian links a guardian to one of their children
+type StudentGuardian struct {
+	GuardianID   uint      `gorm:"primaryKey" json:"guardian_id"`
+	StudentID    uint      `gorm:"primaryKey;index" json:"student_id"`
+	Relationship string    `json:"relationship"` // father, mother, guardian, ...
+	CreatedAt    time.Time `json:"created_at"`
+
+	Student Student `gorm:"foreignKey:StudentID" json:"student,omitempty"`
+}
@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+)
+
+// Job tracks a long-running task executed in the background
+type Job struct {
+	ID         uint       `gorm:"primaryKey" json:"id"`
+	Type       string     `gorm:"not null;index" json:"type"`   // e.g., "grade_recompute"
+	Status     string     `gorm:"default:queued" json:"status"` // queued, running, completed, failed
+	Payload    string     `gorm:"type:text" json:"payload"`     // JSON input
+	Result     string     `gorm:"type:text" json:"result"`      // JSON output
+	Error      string     `gorm:"type:text" json:"error,omitempty"`
+	CreatedBy  uint       `gorm:"not null" json:"created_by"`
+	StartedAt  *time.Time `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
@@ -6,21 +6,64 @@ import (
 )
 
 type LeaveRequest struct {
-	ID          uint           `gorm:"primaryKey" json:"id"`
-	TeacherID   uint           `gorm:"not null" json:"teacher_id"`
-	LeaveType   string         `gorm:"not null" json:"leave_type"` // sick, casual, personal, emergency
-	StartDate   time.Time      `gorm:"not null" json:"start_date"`
-	EndDate     time.Time      `gorm:"not null" json:"end_date"`
-	Reason      string         `gorm:"type:text" json:"reason"`
-	Status      string         `gorm:"default:pending" json:"status"` // pending, approved, rejected
-	ApprovedBy  *uint          `json:"approved_by"`
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
-	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+	ID              uint           `gorm:"primaryKey" json:"id"`
+	TeacherID       uint           `gorm:"not null;index" json:"teacher_id"`
+	LeaveType       string         `gorm:"not null" json:"leave_type"` // one of the types with a LeaveEntitlement, e.g. sick, casual
+	StartDate       time.Time      `gorm:"type:date;not null" json:"start_date"`
+	EndDate         time.Time      `gorm:"type:date;not null" json:"end_date"`
+	AcademicYear    string         `gorm:"index" json:"academic_year"` // the year whose balance the leave counts against
+	WorkingDays     int            `json:"working_days"`               // school days in the leave, deducted from the balance
+	Reason          string         `gorm:"type:text" json:"reason"`
+	Status          string         `gorm:"default:pending;index" json:"status"` // pending, approved, rejected, cancelled
+	ApprovedBy      *uint          `json:"approved_by"`                         // user who approved or rejected the request
+	DecidedAt       *time.Time     `json:"decided_at"`
+	DecisionComment string         `gorm:"type:text" json:"decision_comment"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt `gorm:"index" json:"-"`
 
-	Teacher   Teacher `gorm:"foreignKey:TeacherID" json:"teacher,omitempty"`
-	Approver  *User   `gorm:"foreignKey:ApprovedBy" json:"approver,omitempty"`
+	Teacher  Teacher `gorm:"foreignKey:TeacherID" json:"teacher,omitempty"`
+	Approver *User   `gorm:"foreignKey:ApprovedBy" json:"approver,omitempty"`
 }
 
+// LeaveEntitlement is how many working days of a leave type every teacher
+// may take in an academic year. Teachers can only apply for the types that
+// have one.
+type LeaveEntitlement struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	LeaveType   string    `gorm:"not null;uniqueIndex" json:"leave_type"`
+	DaysPerYear int       `gorm:"not null" json:"days_per_year"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
 
+// StudentLeaveRequest is an absence a student or one of their guardians
+// applies for. The class teacher of the student's section decides it, and
+// approval turns the absences of its days into excused ones.
+type StudentLeaveRequest struct {
+	ID              uint           `gorm:"primaryKey" json:"id"`
+	StudentID       uint           `gorm:"not null;index" json:"student_id"`
+	ClassID         uint           `gorm:"not null" json:"class_id"`   // class of the student when applying
+	SectionID       uint           `gorm:"not null" json:"section_id"` // section whose class teacher decides the request
+	StartDate       time.Time      `gorm:"type:date;not null" json:"start_date"`
+	EndDate         time.Time      `gorm:"type:date;not null" json:"end_date"`
+	AcademicYear    string         `gorm:"index" json:"academic_year"`
+	SchoolDays      int            `json:"school_days"` // days the school is open during the leave
+	Reason          string         `gorm:"type:text;not null" json:"reason"`
+	AttachmentPath  string         `json:"attachment_path,omitempty"` // storage key of a supporting document, e.g. a medical certificate
+	AttachmentName  string         `json:"attachment_name,omitempty"`
+	AttachmentType  string         `json:"attachment_type,omitempty"`
+	AttachmentSize  int64          `json:"attachment_size,omitempty"`
+	AppliedBy       uint           `gorm:"not null" json:"applied_by"`          // user of the student or guardian who applied
+	Status          string         `gorm:"default:pending;index" json:"status"` // pending, approved, rejected, cancelled
+	DecidedBy       *uint          `json:"decided_by"`
+	DecidedAt       *time.Time     `json:"decided_at"`
+	DecisionComment string         `gorm:"type:text" json:"decision_comment"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt `gorm:"index" json:"-"`
 
+	Student   Student `gorm:"foreignKey:StudentID" json:"student,omitempty"`
+	Applicant User    `gorm:"foreignKey:AppliedBy" json:"applicant,omitempty"`
+	Decider   *User   `gorm:"foreignKey:DecidedBy" json:"decider,omitempty"`
+}
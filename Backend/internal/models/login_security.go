@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+)
+
+// LoginEvent records a login attempt for security review
+type LoginEvent struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	UserID    *uint     `gorm:"index" json:"user_id"` // set when the email belongs to a user
+	Email     string    `gorm:"index" json:"email"`
+	IPAddress string    `gorm:"index" json:"ip_address"`
+	UserAgent string    `json:"user_agent"`
+	Success   bool      `json:"success"`
+	Reason    string    `json:"reason"` // invalid_credentials, inactive, throttled, locked
+	CreatedAt time.Time `gorm:"index" json:"created_at"`
+}
+
+// AccountLockout counts recent failed logins of an email address or an IP
+// address, and locks further attempts once there are too many.
+type AccountLockout struct {
+	ID             uint       `gorm:"primaryKey" json:"id"`
+	Scope          string     `gorm:"not null;uniqueIndex:idx_account_lockouts_scope_identifier" json:"scope"` // email, ip, reset_email, reset_ip
+	Identifier     string     `gorm:"not null;uniqueIndex:idx_account_lockouts_scope_identifier" json:"identifier"`
+	FailedAttempts int        `gorm:"default:0" json:"failed_attempts"`
+	LastFailedAt   time.Time  `json:"last_failed_at"`
+	LockedUntil    *time.Time `json:"locked_until"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
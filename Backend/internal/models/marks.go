@@ -15,6 +15,9 @@ type Exam struct {
 	TotalMarks   float64        `gorm:"not null" json:"total_marks"`
 	PassingMarks float64        `json:"passing_marks"`
 	AcademicYear string         `gorm:"not null" json:"academic_year"`
+	Status       string         `gorm:"default:draft" json:"status"` // draft, published
+	PublishedAt  *time.Time     `json:"published_at"`
+	PublishedBy  *uint          `json:"published_by"`
 	CreatedAt    time.Time      `json:"created_at"`
 	UpdatedAt    time.Time      `json:"updated_at"`
 	DeletedAt    gorm.DeletedAt `gorm:"index" json:"-"`
@@ -33,6 +36,7 @@ type Mark struct {
 	TotalMarks   float64        `gorm:"not null" json:"total_marks"`
 	Percentage   float64        `json:"percentage"`
 	Grade        string         `json:"grade"`
+	GradePoint   float64        `json:"grade_point"`
 	AcademicYear string         `gorm:"not null" json:"academic_year"`
 	CreatedBy    uint           `gorm:"not null" json:"created_by"`
 	CreatedAt    time.Time      `json:"created_at"`
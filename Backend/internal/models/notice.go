@@ -6,21 +6,35 @@ import (
 )
 
 type Notice struct {
-	ID            uint           `gorm:"primaryKey" json:"id"`
-	Title         string         `gorm:"not null" json:"title"`
-	Content       string         `gorm:"type:text" json:"content"`
-	Category      string         `json:"category"`
-	Priority      string         `gorm:"default:normal" json:"priority"` // low, normal, high, urgent
-	VisibilityType string        `gorm:"not null" json:"visibility_type"` // all, class, role
-	TargetAudience string        `json:"target_audience"` // JSON string for class IDs or roles
-	PublishedAt   time.Time      `json:"published_at"`
-	CreatedBy     uint           `gorm:"not null" json:"created_by"`
-	CreatedAt     time.Time      `json:"created_at"`
-	UpdatedAt     time.Time      `json:"updated_at"`
-	DeletedAt     gorm.DeletedAt `gorm:"index" json:"-"`
+	ID             uint           `gorm:"primaryKey" json:"id"`
+	Title          string         `gorm:"not null" json:"title"`
+	Content        string         `gorm:"type:text" json:"content"`
+	Category       string         `json:"category"`
+	Priority       string         `gorm:"default:normal" json:"priority"`  // low, normal, high, urgent
+	VisibilityType string         `gorm:"not null" json:"visibility_type"` // all, targeted (see Targets)
+	PublishedAt    time.Time      `gorm:"index" json:"published_at"`       // notices show up in feeds from then on
+	CreatedBy      uint           `gorm:"not null;index" json:"created_by"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	DeletedAt      gorm.DeletedAt `gorm:"index" json:"-"`
 
-	Creator User `gorm:"foreignKey:CreatedBy" json:"creator,omitempty"`
+	Creator User           `gorm:"foreignKey:CreatedBy" json:"creator,omitempty"`
+	Targets []NoticeTarget `gorm:"foreignKey:NoticeID" json:"targets,omitempty"`
 }
 
+// NoticeTarget is one audience of a targeted notice: the users of a role,
+// the students of a class or section and their guardians, or a single user.
+type NoticeTarget struct {
+	ID         uint   `gorm:"primaryKey" json:"id"`
+	NoticeID   uint   `gorm:"not null;index" json:"notice_id"`
+	TargetType string `gorm:"not null" json:"target_type"` // role, class, section, user
+	Role       string `json:"role,omitempty"`              // for role targets
+	TargetID   uint   `json:"target_id,omitempty"`         // class, section or user ID
+}
 
-
+// NoticeRead records that a user has read a notice
+type NoticeRead struct {
+	NoticeID uint      `gorm:"primaryKey" json:"notice_id"`
+	UserID   uint      `gorm:"primaryKey;index" json:"user_id"`
+	ReadAt   time.Time `json:"read_at"`
+}
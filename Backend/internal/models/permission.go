@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+)
+
+// Permission is a named right such as "students.read". The catalogue of
+// permissions is defined in code and stored so roles can refer to it.
+type Permission struct {
+	ID          uint   `gorm:"primaryKey" json:"id"`
+	Name        string `gorm:"not null;uniqueIndex" json:"name"`
+	Description string `json:"description"`
+}
+
+// Role is a named set of permissions. Every user has a primary role, kept in
+// users.role, which also decides the portal they use, and may be given more
+// roles through user_roles. System roles cannot be deleted.
+type Role struct {
+	ID          uint         `gorm:"primaryKey" json:"id"`
+	Name        string       `gorm:"not null;uniqueIndex" json:"name"`
+	Description string       `json:"description"`
+	IsSystem    bool         `gorm:"default:false" json:"is_system"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+	Permissions []Permission `gorm:"many2many:role_permissions" json:"permissions,omitempty"`
+}
+
+// UserRole gives a user a role in addition to their primary role
+type UserRole struct {
+	UserID    uint      `gorm:"primaryKey" json:"user_id"`
+	RoleID    uint      `gorm:"primaryKey;index" json:"role_id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	Role Role `gorm:"foreignKey:RoleID" json:"role,omitempty"`
+}
@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+)
+
+// ReportCardRemark holds the class teacher's remarks printed on a student's
+// report card for an exam type of an academic year.
+type ReportCardRemark struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	StudentID    uint      `gorm:"not null;uniqueIndex:idx_report_card_remark" json:"student_id"`
+	AcademicYear string    `gorm:"not null;uniqueIndex:idx_report_card_remark" json:"academic_year"`
+	ExamType     string    `gorm:"not null;uniqueIndex:idx_report_card_remark" json:"exam_type"`
+	Remarks      string    `gorm:"type:text" json:"remarks"`
+	CreatedBy    uint      `gorm:"not null" json:"created_by"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+
+	Student Student `gorm:"foreignKey:StudentID" json:"student,omitempty"`
+}
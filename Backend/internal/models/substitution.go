@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// Substitution hands one timetable period on one date to another teacher
+// while the teacher of the period is on leave.
+type Substitution struct {
+	ID                  uint      `gorm:"primaryKey" json:"id"`
+	Date                time.Time `gorm:"type:date;not null;uniqueIndex:idx_substitution_period" json:"date"`
+	TimetableID         uint      `gorm:"not null;uniqueIndex:idx_substitution_period" json:"timetable_id"`
+	AbsentTeacherID     uint      `gorm:"not null;index" json:"absent_teacher_id"`
+	SubstituteTeacherID uint      `gorm:"not null;index" json:"substitute_teacher_id"`
+	LeaveRequestID      *uint     `json:"leave_request_id"` // the approved leave that left the period uncovered
+	Note                string    `gorm:"type:text" json:"note"`
+	AssignedBy          uint      `json:"assigned_by"`
+	CreatedAt           time.Time `json:"created_at"`
+	UpdatedAt           time.Time `json:"updated_at"`
+
+	Timetable         Timetable `gorm:"foreignKey:TimetableID" json:"timetable,omitempty"`
+	AbsentTeacher     Teacher   `gorm:"foreignKey:AbsentTeacherID" json:"absent_teacher,omitempty"`
+	SubstituteTeacher Teacher   `gorm:"foreignKey:SubstituteTeacherID" json:"substitute_teacher,omitempty"`
+}
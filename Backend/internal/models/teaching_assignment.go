@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+	"gorm.io/gorm"
+)
+
+// TeachingAssignment records that a teacher teaches a subject to a section
+// in an academic year. One teacher per section and year may also be marked
+// as the class teacher.
+type TeachingAssignment struct {
+	ID             uint           `gorm:"primaryKey" json:"id"`
+	TeacherID      uint           `gorm:"not null;index" json:"teacher_id"`
+	ClassID        uint           `gorm:"not null" json:"class_id"`
+	SectionID      uint           `gorm:"not null;index" json:"section_id"`
+	SubjectID      uint           `gorm:"not null" json:"subject_id"`
+	AcademicYear   string         `gorm:"not null" json:"academic_year"`
+	IsClassTeacher bool           `gorm:"default:false" json:"is_class_teacher"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	DeletedAt      gorm.DeletedAt `gorm:"index" json:"-"`
+
+	Teacher Teacher `gorm:"foreignKey:TeacherID" json:"teacher,omitempty"`
+	Class   Class   `gorm:"foreignKey:ClassID" json:"class,omitempty"`
+	Section Section `gorm:"foreignKey:SectionID" json:"section,omitempty"`
+	Subject Subject `gorm:"foreignKey:SubjectID" json:"subject,omitempty"`
+}
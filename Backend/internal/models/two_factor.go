@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+)
+
+// TwoFactorAuth holds the TOTP secret of a user. The secret is pending until
+// the user confirms it with a code from their authenticator app.
+type TwoFactorAuth struct {
+	ID           uint       `gorm:"primaryKey" json:"id"`
+	UserID       uint       `gorm:"not null;uniqueIndex" json:"user_id"`
+	Secret       string     `gorm:"not null" json:"-"` // base32 TOTP secret
+	Enabled      bool       `gorm:"default:false" json:"enabled"`
+	EnabledAt    *time.Time `json:"enabled_at"`
+	LastUsedStep int64      `gorm:"default:0" json:"-"` // time step of the last accepted code, which cannot be used again
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// RecoveryCode is a single-use code that replaces a TOTP code when the
+// authenticator app is lost.
+type RecoveryCode struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	UserID    uint       `gorm:"not null;index" json:"user_id"`
+	CodeHash  string     `gorm:"not null" json:"-"` // SHA-256 of the code
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
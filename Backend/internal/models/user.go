@@ -9,7 +9,7 @@ type User struct {
 	ID           uint           `gorm:"primaryKey" json:"id"`
 	Email        string         `gorm:"unique;not null" json:"email"`
 	PasswordHash string         `gorm:"not null" json:"-"`
-	Role         string         `gorm:"not null;check:role IN ('admin','teacher','student')" json:"role"`
+	Role         string         `gorm:"not null" json:"role"` // primary role, see Role
 	Status       string         `gorm:"default:active" json:"status"`
 	CreatedAt    time.Time      `json:"created_at"`
 	UpdatedAt    time.Time      `json:"updated_at"`
@@ -20,15 +20,33 @@ type User struct {
 	Teacher *Teacher `gorm:"foreignKey:UserID" json:"teacher,omitempty"`
 }
 
+// Session holds one refresh token. Refreshing rotates the token into a new
+// session of the same family, and reusing a rotated token revokes the whole
+// family. The family is what users see as one signed-in device.
 type Session struct {
-	ID        uint      `gorm:"primaryKey" json:"id"`
-	UserID    uint      `gorm:"not null" json:"user_id"`
-	Token     string    `gorm:"not null" json:"token"`
-	ExpiresAt time.Time `gorm:"not null" json:"expires_at"`
-	CreatedAt time.Time `json:"created_at"`
+	ID         uint       `gorm:"primaryKey" json:"id"`
+	UserID     uint       `gorm:"not null;index" json:"user_id"`
+	FamilyID   string     `gorm:"not null;index" json:"family_id"`
+	Token      string     `gorm:"not null;uniqueIndex" json:"-"` // SHA-256 of the refresh token
+	UserAgent  string     `json:"user_agent"`
+	IPAddress  string     `json:"ip_address"`
+	StartedAt  time.Time  `json:"started_at"` // login of the family
+	LastSeenAt time.Time  `json:"last_seen_at"`
+	ExpiresAt  time.Time  `gorm:"not null" json:"expires_at"`
+	RotatedAt  *time.Time `json:"rotated_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	CreatedAt  time.Time  `json:"created_at"`
 
 	User User `gorm:"foreignKey:UserID" json:"user,omitempty"`
 }
 
-
-
+// PasswordResetToken is a single-use token mailed to a user who forgot
+// their password.
+type PasswordResetToken struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	UserID    uint       `gorm:"not null;index" json:"user_id"`
+	Token     string     `gorm:"not null;uniqueIndex" json:"-"` // SHA-256 of the mailed token
+	ExpiresAt time.Time  `gorm:"not null" json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"errors"
+	"time"
+	"school-erp-backend/internal/models"
+	"gorm.io/gorm"
+)
+
+type AccountLockoutRepository struct {
+	db *gorm.DB
+}
+
+func NewAccountLockoutRepository(db *gorm.DB) *AccountLockoutRepository {
+	return &AccountLockoutRepository{db: db}
+}
+
+func (r *AccountLockoutRepository) FindByID(id uint) (*models.AccountLockout, error) {
+	var lockout models.AccountLockout
+	err := r.db.First(&lockout, id).Error
+	return &lockout, err
+}
+
+func (r *AccountLockoutRepository) Find(scope, identifier string) (*models.AccountLockout, error) {
+	var lockout models.AccountLockout
+	err := r.db.Where("scope = ? AND identifier = ?", scope, identifier).First(&lockout).Error
+	return &lockout, err
+}
+
+// FindAll returns the tracked failures, limited to current lockouts when
+// lockedOnly is set.
+func (r *AccountLockoutRepository) FindAll(lockedOnly bool, now time.Time) ([]models.AccountLockout, error) {
+	var lockouts []models.AccountLockout
+	query := r.db
+	if lockedOnly {
+		query = query.Where("locked_until > ?", now)
+	}
+	err := query.Order("last_failed_at DESC").Find(&lockouts).Error
+	return lockouts, err
+}
+
+// RecordFailure counts a failed attempt and returns the updated record. The
+// count starts over when the previous failure is older than window.
+func (r *AccountLockoutRepository) RecordFailure(scope, identifier string, now time.Time, window time.Duration) (*models.AccountLockout, error) {
+	for attempt := 0; attempt < 2; attempt++ {
+		result := r.db.Model(&models.AccountLockout{}).
+			Where("scope = ? AND identifier = ?", scope, identifier).
+			Updates(map[string]interface{}{
+				"failed_attempts": gorm.Expr("CASE WHEN last_failed_at < ? THEN 1 ELSE failed_attempts + 1 END", now.Add(-window)),
+				"last_failed_at":  now,
+			})
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		if result.RowsAffected > 0 {
+			return r.Find(scope, identifier)
+		}
+
+		// First failure: a concurrent insert makes Create fail on the unique
+		// index, in which case the update is tried again
+		lockout := &models.AccountLockout{
+			Scope:          scope,
+			Identifier:     identifier,
+			FailedAttempts: 1,
+			LastFailedAt:   now,
+		}
+		if err := r.db.Create(lockout).Error; err == nil {
+			return lockout, nil
+		}
+	}
+	return nil, errors.New("failed to record login failure")
+}
+
+func (r *AccountLockoutRepository) Lock(id uint, until time.Time) error {
+	return r.db.Model(&models.AccountLockout{}).Where("id = ?", id).Update("locked_until", until).Error
+}
+
+// Clear forgets the failures of an email or IP address
+func (r *AccountLockoutRepository) Clear(scope, identifier string) error {
+	return r.db.Where("scope = ? AND identifier = ?", scope, identifier).Delete(&models.AccountLockout{}).Error
+}
+
+func (r *AccountLockoutRepository) Delete(id uint) error {
+	return r.db.Delete(&models.AccountLockout{}, id).Error
+}
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"school-erp-backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type AssignmentRepository struct {
+	db *gorm.DB
+}
+
+func NewAssignmentRepository(db *gorm.DB) *AssignmentRepository {
+	return &AssignmentRepository{db: db}
+}
+
+// Scoped returns the repository restricted to the assignments the scope allows
+func (r *AssignmentRepository) Scoped(scope Scope) *AssignmentRepository {
+	return &AssignmentRepository{db: scope.apply(r.db, scope.Assignments)}
+}
+
+func (r *AssignmentRepository) Create(assignment *models.Assignment) error {
+	return r.db.Create(assignment).Error
+}
+
+func (r *AssignmentRepository) FindByID(id uint) (*models.Assignment, error) {
+	var assignment models.Assignment
+	err := r.db.Preload("Subject").Preload("Class").Preload("Teacher").First(&assignment, id).Error
+	return &assignment, err
+}
+
+// Update saves the assignment columns only, so a changed ClassID or SubjectID
+// is not overwritten by the preloaded associations.
+func (r *AssignmentRepository) Update(assignment *models.Assignment) error {
+	return r.db.Omit(clause.Associations).Save(assignment).Error
+}
+
+func (r *AssignmentRepository) Delete(id uint) error {
+	return r.db.Delete(&models.Assignment{}, id).Error
+}
+
+func (r *AssignmentRepository) FindByTeacher(teacherID uint, classID, subjectID uint) ([]models.Assignment, error) {
+	var assignments []models.Assignment
+	query := r.db.Where("teacher_id = ?", teacherID)
+	if classID != 0 {
+		query = query.Where("class_id = ?", classID)
+	}
+	if subjectID != 0 {
+		query = query.Where("subject_id = ?", subjectID)
+	}
+	err := query.Preload("Subject").Preload("Class").Order("due_date DESC").Find(&assignments).Error
+	return assignments, err
+}
+
+func (r *AssignmentRepository) FindByClass(classID, subjectID uint) ([]models.Assignment, error) {
+	var assignments []models.Assignment
+	query := r.db.Where("class_id = ?", classID)
+	if subjectID != 0 {
+		query = query.Where("subject_id = ?", subjectID)
+	}
+	err := query.Preload("Subject").Preload("Teacher").Order("due_date DESC").Find(&assignments).Error
+	return assignments, err
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"school-erp-backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type AssignmentSubmissionRepository struct {
+	db *gorm.DB
+}
+
+func NewAssignmentSubmissionRepository(db *gorm.DB) *AssignmentSubmissionRepository {
+	return &AssignmentSubmissionRepository{db: db}
+}
+
+// Scoped returns the repository restricted to the submissions the scope allows
+func (r *AssignmentSubmissionRepository) Scoped(scope Scope) *AssignmentSubmissionRepository {
+	return &AssignmentSubmissionRepository{db: scope.apply(r.db, scope.Submissions)}
+}
+
+func (r *AssignmentSubmissionRepository) Create(submission *models.AssignmentSubmission) error {
+	return r.db.Create(submission).Error
+}
+
+func (r *AssignmentSubmissionRepository) FindByID(id uint) (*models.AssignmentSubmission, error) {
+	var submission models.AssignmentSubmission
+	err := r.db.Preload("Assignment").Preload("Student").First(&submission, id).Error
+	return &submission, err
+}
+
+func (r *AssignmentSubmissionRepository) FindByAssignmentAndStudent(assignmentID, studentID uint) (*models.AssignmentSubmission, error) {
+	var submission models.AssignmentSubmission
+	err := r.db.Where("assignment_id = ? AND student_id = ?", assignmentID, studentID).First(&submission).Error
+	return &submission, err
+}
+
+func (r *AssignmentSubmissionRepository) FindByAssignment(assignmentID uint) ([]models.AssignmentSubmission, error) {
+	var submissions []models.AssignmentSubmission
+	err := r.db.Where("assignment_id = ?", assignmentID).Preload("Student").Order("submitted_at ASC").Find(&submissions).Error
+	return submissions, err
+}
+
+func (r *AssignmentSubmissionRepository) FindByStudent(studentID uint, assignmentIDs []uint) ([]models.AssignmentSubmission, error) {
+	var submissions []models.AssignmentSubmission
+	if len(assignmentIDs) == 0 {
+		return submissions, nil
+	}
+	err := r.db.Where("student_id = ? AND assignment_id IN ?", studentID, assignmentIDs).Find(&submissions).Error
+	return submissions, err
+}
+
+func (r *AssignmentSubmissionRepository) CountByAssignment(assignmentID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.AssignmentSubmission{}).Where("assignment_id = ?", assignmentID).Count(&count).Error
+	return count, err
+}
+
+// Update saves the submission columns only, leaving preloaded associations alone.
+func (r *AssignmentSubmissionRepository) Update(submission *models.AssignmentSubmission) error {
+	return r.db.Omit(clause.Associations).Save(submission).Error
+}
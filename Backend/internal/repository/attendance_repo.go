@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"errors"
+	"time"
+	"school-erp-backend/internal/models"
+	"gorm.io/gorm"
+)
+
+type AttendanceRepository struct {
+	db *gorm.DB
+}
+
+func NewAttendanceRepository(db *gorm.DB) *AttendanceRepository {
+	return &AttendanceRepository{db: db}
+}
+
+// Scoped returns the repository restricted to the attendance records the scope allows
+func (r *AttendanceRepository) Scoped(scope Scope) *AttendanceRepository {
+	return &AttendanceRepository{db: scope.apply(r.db, scope.Attendance)}
+}
+
+func (r *AttendanceRepository) FindByClassSectionAndDate(classID, sectionID uint, date time.Time) ([]models.Attendance, error) {
+	var records []models.Attendance
+	err := r.db.Where("class_id = ? AND section_id = ? AND date = ?", classID, sectionID, date).Find(&records).Error
+	return records, err
+}
+
+func (r *AttendanceRepository) FindByStudent(studentID uint, from, to time.Time) ([]models.Attendance, error) {
+	var records []models.Attendance
+	err := r.db.Where("student_id = ? AND date BETWEEN ? AND ?", studentID, from, to).Order("date ASC").Find(&records).Error
+	return records, err
+}
+
+func (r *AttendanceRepository) FindBySectionInRange(classID, sectionID uint, from, to time.Time) ([]models.Attendance, error) {
+	var records []models.Attendance
+	err := r.db.Where("class_id = ? AND section_id = ? AND date BETWEEN ? AND ?", classID, sectionID, from, to).Order("date ASC").Find(&records).Error
+	return records, err
+}
+
+func (r *AttendanceRepository) FindByClassInRange(classID uint, from, to time.Time) ([]models.Attendance, error) {
+	var records []models.Attendance
+	err := r.db.Where("class_id = ? AND date BETWEEN ? AND ?", classID, from, to).Order("date ASC").Find(&records).Error
+	return records, err
+}
+
+// UpsertBulk writes all records in a single transaction. A record for a
+// student that already has attendance on the same date updates that row
+// instead of inserting a duplicate.
+func (r *AttendanceRepository) UpsertBulk(records []models.Attendance) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for i := range records {
+			record := &records[i]
+
+			var existing models.Attendance
+			err := tx.Where("student_id = ? AND date = ?", record.StudentID, record.Date).First(&existing).Error
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				if err := tx.Create(record).Error; err != nil {
+					return err
+				}
+				continue
+			}
+			if err != nil {
+				return err
+			}
+
+			existing.ClassID = record.ClassID
+			existing.SectionID = record.SectionID
+			existing.Status = record.Status
+			existing.MarkedBy = record.MarkedBy
+			if err := tx.Save(&existing).Error; err != nil {
+				return err
+			}
+			*record = existing
+		}
+		return nil
+	})
+}
+
+// ExcuseAbsences marks the absences of a student from from to to, both
+// included, as excused and returns how many it changed.
+func (r *AttendanceRepository) ExcuseAbsences(studentID uint, from, to time.Time) (int64, error) {
+	result := r.db.Model(&models.Attendance{}).
+		Where("student_id = ? AND status = ? AND date BETWEEN ? AND ?", studentID, "absent", from, to).
+		Update("status", "excused")
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"time"
+	"school-erp-backend/internal/models"
+	"gorm.io/gorm"
+)
+
+type CalendarFeedTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewCalendarFeedTokenRepository(db *gorm.DB) *CalendarFeedTokenRepository {
+	return &CalendarFeedTokenRepository{db: db}
+}
+
+func (r *CalendarFeedTokenRepository) FindByUser(userID uint) (*models.CalendarFeedToken, error) {
+	var token models.CalendarFeedToken
+	err := r.db.Where("user_id = ?", userID).First(&token).Error
+	return &token, err
+}
+
+func (r *CalendarFeedTokenRepository) FindByToken(tokenHash string) (*models.CalendarFeedToken, error) {
+	var token models.CalendarFeedToken
+	err := r.db.Where("token = ?", tokenHash).First(&token).Error
+	return &token, err
+}
+
+// Replace stores the token of a user in place of the one they had, so the
+// old feed URL stops working.
+func (r *CalendarFeedTokenRepository) Replace(token *models.CalendarFeedToken) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", token.UserID).Delete(&models.CalendarFeedToken{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(token).Error
+	})
+}
+
+func (r *CalendarFeedTokenRepository) DeleteByUser(userID uint) error {
+	return r.db.Where("user_id = ?", userID).Delete(&models.CalendarFeedToken{}).Error
+}
+
+func (r *CalendarFeedTokenRepository) MarkUsed(id uint, at time.Time) error {
+	return r.db.Model(&models.CalendarFeedToken{}).Where("id = ?", id).Update("last_used_at", at).Error
+}
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"time"
+	"school-erp-backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CalendarEventRepository struct {
+	db *gorm.DB
+}
+
+func NewCalendarEventRepository(db *gorm.DB) *CalendarEventRepository {
+	return &CalendarEventRepository{db: db}
+}
+
+func (r *CalendarEventRepository) Create(event *models.CalendarEvent) error {
+	return r.db.Create(event).Error
+}
+
+func (r *CalendarEventRepository) FindByID(id uint) (*models.CalendarEvent, error) {
+	var event models.CalendarEvent
+	err := r.db.Preload("Creator").First(&event, id).Error
+	return &event, err
+}
+
+func (r *CalendarEventRepository) Update(event *models.CalendarEvent) error {
+	return r.db.Omit(clause.Associations).Save(event).Error
+}
+
+func (r *CalendarEventRepository) Delete(id uint) error {
+	return r.db.Delete(&models.CalendarEvent{}, id).Error
+}
+
+// FindAll returns the events matching the non-zero filters whose first
+// occurrence starts before to and whose last occurrence ends on or after
+// from. Zero from or to leave that side open. A nil visibilities allows
+// every visibility.
+func (r *CalendarEventRepository) FindAll(filter models.CalendarEvent, visibilities []string, from, to time.Time) ([]models.CalendarEvent, error) {
+	var events []models.CalendarEvent
+	query := r.db.Model(&models.CalendarEvent{})
+	if filter.EventType != "" {
+		query = query.Where("event_type = ?", filter.EventType)
+	}
+	if filter.Visibility != "" {
+		query = query.Where("visibility = ?", filter.Visibility)
+	}
+	if filter.CreatedBy != 0 {
+		query = query.Where("created_by = ?", filter.CreatedBy)
+	}
+	if visibilities != nil {
+		query = query.Where("visibility IN ?", visibilities)
+	}
+	if !to.IsZero() {
+		query = query.Where("start_date < ?", to)
+	}
+	if !from.IsZero() {
+		query = query.Where("occurs_until IS NULL OR occurs_until >= ?", from)
+	}
+	err := query.Order("start_date, id").Find(&events).Error
+	return events, err
+}
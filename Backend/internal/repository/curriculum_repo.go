@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"school-erp-backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CurriculumRepository struct {
+	db *gorm.DB
+}
+
+func NewCurriculumRepository(db *gorm.DB) *CurriculumRepository {
+	return &CurriculumRepository{db: db}
+}
+
+func (r *CurriculumRepository) Create(entry *models.CurriculumSubject) error {
+	return r.db.Create(entry).Error
+}
+
+func (r *CurriculumRepository) FindByID(id uint) (*models.CurriculumSubject, error) {
+	var entry models.CurriculumSubject
+	err := r.db.Preload("Class").Preload("Subject").First(&entry, id).Error
+	return &entry, err
+}
+
+// Update saves the entry columns only, so changed foreign keys are not
+// overwritten by the preloaded associations.
+func (r *CurriculumRepository) Update(entry *models.CurriculumSubject) error {
+	return r.db.Omit(clause.Associations).Save(entry).Error
+}
+
+func (r *CurriculumRepository) Delete(id uint) error {
+	return r.db.Delete(&models.CurriculumSubject{}, id).Error
+}
+
+// FindByClass returns the subjects of a class in an academic year, core
+// subjects before electives.
+func (r *CurriculumRepository) FindByClass(classID uint, academicYear string) ([]models.CurriculumSubject, error) {
+	var entries []models.CurriculumSubject
+	err := r.db.Where("class_id = ? AND academic_year = ?", classID, academicYear).
+		Preload("Subject").Order("is_elective, subject_id").Find(&entries).Error
+	return entries, err
+}
+
+func (r *CurriculumRepository) FindByYear(academicYear string) ([]models.CurriculumSubject, error) {
+	var entries []models.CurriculumSubject
+	err := r.db.Where("academic_year = ?", academicYear).Find(&entries).Error
+	return entries, err
+}
+
+func (r *CurriculumRepository) FindEntry(classID, subjectID uint, academicYear string) (*models.CurriculumSubject, error) {
+	var entry models.CurriculumSubject
+	err := r.db.Where("class_id = ? AND subject_id = ? AND academic_year = ?", classID, subjectID, academicYear).
+		First(&entry).Error
+	return &entry, err
+}
+
+// HasCurriculum reports whether any subjects are set up for a class in an
+// academic year.
+func (r *CurriculumRepository) HasCurriculum(classID uint, academicYear string) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.CurriculumSubject{}).
+		Where("class_id = ? AND academic_year = ?", classID, academicYear).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// CreateBatch inserts several entries in one statement
+func (r *CurriculumRepository) CreateBatch(entries []models.CurriculumSubject) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	return r.db.Omit(clause.Associations).Create(&entries).Error
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"school-erp-backend/internal/models"
+	"gorm.io/gorm"
+)
+
+type ElectiveRepository struct {
+	db *gorm.DB
+}
+
+func NewElectiveRepository(db *gorm.DB) *ElectiveRepository {
+	return &ElectiveRepository{db: db}
+}
+
+func (r *ElectiveRepository) FindByID(id uint) (*models.ElectiveEnrollment, error) {
+	var enrollment models.ElectiveEnrollment
+	err := r.db.Preload("Student").Preload("Subject").First(&enrollment, id).Error
+	return &enrollment, err
+}
+
+func (r *ElectiveRepository) Delete(id uint) error {
+	return r.db.Delete(&models.ElectiveEnrollment{}, id).Error
+}
+
+// FindAll returns the enrollments matching the non-zero filters
+func (r *ElectiveRepository) FindAll(filter models.ElectiveEnrollment) ([]models.ElectiveEnrollment, error) {
+	var enrollments []models.ElectiveEnrollment
+	query := r.db.Preload("Student").Preload("Subject")
+	if filter.StudentID != 0 {
+		query = query.Where("student_id = ?", filter.StudentID)
+	}
+	if filter.ClassID != 0 {
+		query = query.Where("class_id = ?", filter.ClassID)
+	}
+	if filter.SubjectID != 0 {
+		query = query.Where("subject_id = ?", filter.SubjectID)
+	}
+	if filter.AcademicYear != "" {
+		query = query.Where("academic_year = ?", filter.AcademicYear)
+	}
+	err := query.Order("class_id, subject_id, student_id").Find(&enrollments).Error
+	return enrollments, err
+}
+
+// SubjectIDs returns the electives a student is enrolled in for an
+// academic year.
+func (r *ElectiveRepository) SubjectIDs(studentID uint, academicYear string) ([]uint, error) {
+	var ids []uint
+	err := r.db.Model(&models.ElectiveEnrollment{}).
+		Where("student_id = ? AND academic_year = ?", studentID, academicYear).
+		Pluck("subject_id", &ids).Error
+	return ids, err
+}
+
+// EnrolledStudents returns the students of a class enrolled in an elective,
+// limited to one section unless sectionID is 0.
+func (r *ElectiveRepository) EnrolledStudents(classID, sectionID, subjectID uint, academicYear string) ([]models.Student, error) {
+	enrolled := r.db.Model(&models.ElectiveEnrollment{}).Select("student_id").
+		Where("class_id = ? AND subject_id = ? AND academic_year = ?", classID, subjectID, academicYear)
+
+	var students []models.Student
+	query := r.db.Where("class_id = ? AND id IN (?)", classID, enrolled)
+	if sectionID != 0 {
+		query = query.Where("section_id = ?", sectionID)
+	}
+	err := query.Find(&students).Error
+	return students, err
+}
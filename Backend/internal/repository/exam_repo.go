@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"school-erp-backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ExamRepository struct {
+	db *gorm.DB
+}
+
+func NewExamRepository(db *gorm.DB) *ExamRepository {
+	return &ExamRepository{db: db}
+}
+
+// Scoped returns the repository restricted to the exams the scope allows
+func (r *ExamRepository) Scoped(scope Scope) *ExamRepository {
+	return &ExamRepository{db: scope.apply(r.db, scope.Exams)}
+}
+
+func (r *ExamRepository) Create(exam *models.Exam) error {
+	return r.db.Create(exam).Error
+}
+
+func (r *ExamRepository) FindByID(id uint) (*models.Exam, error) {
+	var exam models.Exam
+	err := r.db.Preload("Class").Preload("Subject").First(&exam, id).Error
+	return &exam, err
+}
+
+// Update saves the exam columns only, so a changed ClassID or SubjectID is not
+// overwritten by the preloaded associations.
+func (r *ExamRepository) Update(exam *models.Exam) error {
+	return r.db.Omit(clause.Associations).Save(exam).Error
+}
+
+func (r *ExamRepository) Delete(id uint) error {
+	return r.db.Delete(&models.Exam{}, id).Error
+}
+
+// FindAll returns the exams matching the non-zero filters in date order
+func (r *ExamRepository) FindAll(filter models.Exam) ([]models.Exam, error) {
+	var exams []models.Exam
+	query := r.db.Preload("Class").Preload("Subject")
+	if filter.ClassID != 0 {
+		query = query.Where("class_id = ?", filter.ClassID)
+	}
+	if filter.SubjectID != 0 {
+		query = query.Where("subject_id = ?", filter.SubjectID)
+	}
+	if filter.AcademicYear != "" {
+		query = query.Where("academic_year = ?", filter.AcademicYear)
+	}
+	if filter.ExamType != "" {
+		query = query.Where("exam_type = ?", filter.ExamType)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	err := query.Order("exam_date ASC").Find(&exams).Error
+	return exams, err
+}
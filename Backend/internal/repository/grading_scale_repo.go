@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"school-erp-backend/internal/models"
+	"gorm.io/gorm"
+)
+
+type GradingScaleRepository struct {
+	db *gorm.DB
+}
+
+func NewGradingScaleRepository(db *gorm.DB) *GradingScaleRepository {
+	return &GradingScaleRepository{db: db}
+}
+
+func (r *GradingScaleRepository) Create(scale *models.GradingScale) error {
+	return r.db.Create(scale).Error
+}
+
+func (r *GradingScaleRepository) FindByID(id uint) (*models.GradingScale, error) {
+	var scale models.GradingScale
+	err := r.db.Preload("Bands", func(db *gorm.DB) *gorm.DB {
+		return db.Order("min_percentage DESC")
+	}).First(&scale, id).Error
+	return &scale, err
+}
+
+func (r *GradingScaleRepository) FindAll() ([]models.GradingScale, error) {
+	var scales []models.GradingScale
+	err := r.db.Preload("Bands", func(db *gorm.DB) *gorm.DB {
+		return db.Order("min_percentage DESC")
+	}).Order("name ASC").Find(&scales).Error
+	return scales, err
+}
+
+// Update saves the scale and, when bands is not nil, replaces its bands.
+func (r *GradingScaleRepository) Update(scale *models.GradingScale, bands []models.GradeBand) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Bands").Save(scale).Error; err != nil {
+			return err
+		}
+		if bands == nil {
+			return nil
+		}
+		if err := tx.Where("grading_scale_id = ?", scale.ID).Delete(&models.GradeBand{}).Error; err != nil {
+			return err
+		}
+		for i := range bands {
+			bands[i].ID = 0
+			bands[i].GradingScaleID = scale.ID
+		}
+		if err := tx.Create(&bands).Error; err != nil {
+			return err
+		}
+		scale.Bands = bands
+		return nil
+	})
+}
+
+func (r *GradingScaleRepository) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("grading_scale_id = ?", id).Delete(&models.GradeBand{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.GradingScale{}, id).Error
+	})
+}
+
+func (r *GradingScaleRepository) CountAssignments(scaleID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.ClassGradingScale{}).Where("grading_scale_id = ?", scaleID).Count(&count).Error
+	return count, err
+}
+
+// FindAssignment returns the scale assigned to a class for an academic year,
+// with its bands loaded.
+func (r *GradingScaleRepository) FindAssignment(classID uint, academicYear string) (*models.ClassGradingScale, error) {
+	var assignment models.ClassGradingScale
+	err := r.db.Where("class_id = ? AND academic_year = ?", classID, academicYear).
+		Preload("GradingScale.Bands", func(db *gorm.DB) *gorm.DB {
+			return db.Order("min_percentage DESC")
+		}).First(&assignment).Error
+	return &assignment, err
+}
+
+func (r *GradingScaleRepository) SaveAssignment(assignment *models.ClassGradingScale) error {
+	return r.db.Omit("Class", "GradingScale").Save(assignment).Error
+}
+
+func (r *GradingScaleRepository) FindAssignments(classID uint, academicYear string) ([]models.ClassGradingScale, error) {
+	var assignments []models.ClassGradingScale
+	query := r.db.Preload("Class").Preload("GradingScale")
+	if classID != 0 {
+		query = query.Where("class_id = ?", classID)
+	}
+	if academicYear != "" {
+		query = query.Where("academic_year = ?", academicYear)
+	}
+	err := query.Order("academic_year DESC, class_id ASC").Find(&assignments).Error
+	return assignments, err
+}
+
+// CountMarks counts the marks of a class and academic year that a
+// recompute would regrade.
+func (r *GradingScaleRepository) CountMarks(classID uint, academicYear string) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Mark{}).
+		Where("academic_year = ? AND exam_id IN (?)", academicYear,
+			r.db.Model(&models.Exam{}).Select("id").Where("class_id = ?", classID)).
+		Count(&count).Error
+	return count, err
+}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"school-erp-backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type GuardianRepository struct {
+	db *gorm.DB
+}
+
+func NewGuardianRepository(db *gorm.DB) *GuardianRepository {
+	return &GuardianRepository{db: db}
+}
+
+func (r *GuardianRepository) Create(guardian *models.Guardian) error {
+	return r.db.Omit(clause.Associations).Create(guardian).Error
+}
+
+func (r *GuardianRepository) FindByID(id uint) (*models.Guardian, error) {
+	var guardian models.Guardian
+	err := r.db.Preload("User").First(&guardian, id).Error
+	return &guardian, err
+}
+
+func (r *GuardianRepository) FindByUserID(userID uint) (*models.Guardian, error) {
+	var guardian models.Guardian
+	err := r.db.Where("user_id = ?", userID).First(&guardian).Error
+	return &guardian, err
+}
+
+func (r *GuardianRepository) FindByPhone(phone string) (*models.Guardian, error) {
+	var guardian models.Guardian
+	err := r.db.Where("phone = ?", phone).Order("id").First(&guardian).Error
+	return &guardian, err
+}
+
+// FindAll returns the guardians matching the non-zero filters, optionally
+// only those linked to a student.
+func (r *GuardianRepository) FindAll(filter models.Guardian, studentID uint) ([]models.Guardian, error) {
+	var guardians []models.Guardian
+	query := r.db.Preload("User")
+	if filter.Phone != "" {
+		query = query.Where("guardians.phone = ?", filter.Phone)
+	}
+	if studentID != 0 {
+		query = query.Joins("JOIN student_guardians ON student_guardians.guardian_id = guardians.id").
+			Where("student_guardians.student_id = ?", studentID)
+	}
+	err := query.Order("guardians.name, guardians.id").Find(&guardians).Error
+	return guardians, err
+}
+
+func (r *GuardianRepository) Update(guardian *models.Guardian) error {
+	return r.db.Omit(clause.Associations).Save(guardian).Error
+}
+
+// Delete removes a guardian and their links to students. The user of the
+// guardian is kept.
+func (r *GuardianRepository) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("guardian_id = ?", id).Delete(&models.StudentGuardian{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Guardian{}, id).Error
+	})
+}
+
+// FindLinks returns the links of the guardians to their children, with the
+// students, their classes and sections.
+func (r *GuardianRepository) FindLinks(guardianIDs []uint) ([]models.StudentGuardian, error) {
+	var links []models.StudentGuardian
+	if len(guardianIDs) == 0 {
+		return links, nil
+	}
+	err := r.db.Where("guardian_id IN ?", guardianIDs).
+		Preload("Student").Preload("Student.Class").Preload("Student.Section").
+		Order("guardian_id, student_id").Find(&links).Error
+	return links, err
+}
+
+func (r *GuardianRepository) FindLink(guardianID, studentID uint) (*models.StudentGuardian, error) {
+	var link models.StudentGuardian
+	err := r.db.Where("guardian_id = ? AND student_id = ?", guardianID, studentID).First(&link).Error
+	return &link, err
+}
+
+func (r *GuardianRepository) Link(link *models.StudentGuardian) error {
+	return r.db.Omit(clause.Associations).Create(link).Error
+}
+
+func (r *GuardianRepository) Unlink(guardianID, studentID uint) error {
+	return r.db.Where("guardian_id = ? AND student_id = ?", guardianID, studentID).
+		Delete(&models.StudentGuardian{}).Error
+}
+
+// Children returns the students linked to a guardian
+func (r *GuardianRepository) Children(guardianID uint) ([]models.Student, error) {
+	var students []models.Student
+	err := r.db.Joins("JOIN student_guardians ON student_guardians.student_id = students.id").
+		Where("student_guardians.guardian_id = ?", guardianID).
+		Preload("Class").Preload("Section").
+		Order("students.first_name, students.last_name").Find(&students).Error
+	return students, err
+}
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"school-erp-backend/internal/models"
+	"gorm.io/gorm"
+)
+
+type JobRepository struct {
+	db *gorm.DB
+}
+
+func NewJobRepository(db *gorm.DB) *JobRepository {
+	return &JobRepository{db: db}
+}
+
+func (r *JobRepository) Create(job *models.Job) error {
+	return r.db.Create(job).Error
+}
+
+func (r *JobRepository) FindByID(id uint) (*models.Job, error) {
+	var job models.Job
+	err := r.db.First(&job, id).Error
+	return &job, err
+}
+
+func (r *JobRepository) Update(job *models.Job) error {
+	return r.db.Save(job).Error
+}
+
+func (r *JobRepository) FindAll(jobType, status string) ([]models.Job, error) {
+	var jobs []models.Job
+	query := r.db
+	if jobType != "" {
+		query = query.Where("type = ?", jobType)
+	}
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	err := query.Order("created_at DESC").Limit(100).Find(&jobs).Error
+	return jobs, err
+}
@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"school-erp-backend/internal/models"
+	"gorm.io/gorm"
+)
+
+type LeaveEntitlementRepository struct {
+	db *gorm.DB
+}
+
+func NewLeaveEntitlementRepository(db *gorm.DB) *LeaveEntitlementRepository {
+	return &LeaveEntitlementRepository{db: db}
+}
+
+func (r *LeaveEntitlementRepository) FindAll() ([]models.LeaveEntitlement, error) {
+	var entitlements []models.LeaveEntitlement
+	err := r.db.Order("leave_type").Find(&entitlements).Error
+	return entitlements, err
+}
+
+func (r *LeaveEntitlementRepository) FindByType(leaveType string) (*models.LeaveEntitlement, error) {
+	var entitlement models.LeaveEntitlement
+	err := r.db.Where("leave_type = ?", leaveType).First(&entitlement).Error
+	return &entitlement, err
+}
+
+func (r *LeaveEntitlementRepository) Save(entitlement *models.LeaveEntitlement) error {
+	return r.db.Save(entitlement).Error
+}
+
+func (r *LeaveEntitlementRepository) Delete(id uint) error {
+	return r.db.Delete(&models.LeaveEntitlement{}, id).Error
+}
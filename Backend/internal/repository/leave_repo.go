@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"time"
+	"school-erp-backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// LeaveDays is the number of working days of one leave type and status
+type LeaveDays struct {
+	LeaveType string
+	Status    string
+	Days      int
+}
+
+type LeaveRequestRepository struct {
+	db *gorm.DB
+}
+
+func NewLeaveRequestRepository(db *gorm.DB) *LeaveRequestRepository {
+	return &LeaveRequestRepository{db: db}
+}
+
+func (r *LeaveRequestRepository) Create(request *models.LeaveRequest) error {
+	return r.db.Create(request).Error
+}
+
+func (r *LeaveRequestRepository) FindByID(id uint) (*models.LeaveRequest, error) {
+	var request models.LeaveRequest
+	err := r.db.Preload("Teacher").Preload("Approver").First(&request, id).Error
+	return &request, err
+}
+
+// FindAll returns the leave requests matching the non-zero filters, latest
+// first. Non-zero from and to keep the requests overlapping those days.
+func (r *LeaveRequestRepository) FindAll(filter models.LeaveRequest, from, to time.Time) ([]models.LeaveRequest, error) {
+	var requests []models.LeaveRequest
+	query := r.db.Preload("Teacher").Preload("Approver")
+	if filter.TeacherID != 0 {
+		query = query.Where("teacher_id = ?", filter.TeacherID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.LeaveType != "" {
+		query = query.Where("leave_type = ?", filter.LeaveType)
+	}
+	if filter.AcademicYear != "" {
+		query = query.Where("academic_year = ?", filter.AcademicYear)
+	}
+	if !from.IsZero() {
+		query = query.Where("end_date >= ?", from)
+	}
+	if !to.IsZero() {
+		query = query.Where("start_date <= ?", to)
+	}
+	err := query.Order("start_date DESC, id DESC").Find(&requests).Error
+	return requests, err
+}
+
+// FindOverlapping returns the pending and approved requests of a teacher that
+// share a day with from to to, leaving out the request excludeID.
+func (r *LeaveRequestRepository) FindOverlapping(teacherID uint, from, to time.Time, excludeID uint) ([]models.LeaveRequest, error) {
+	var requests []models.LeaveRequest
+	err := r.db.Where("teacher_id = ? AND status IN ? AND start_date <= ? AND end_date >= ? AND id <> ?",
+		teacherID, []string{"pending", "approved"}, to, from, excludeID).
+		Order("start_date").Find(&requests).Error
+	return requests, err
+}
+
+// SumWorkingDays adds up the working days of the requests of a teacher in an
+// academic year by leave type and status.
+func (r *LeaveRequestRepository) SumWorkingDays(teacherID uint, academicYear string) ([]LeaveDays, error) {
+	var days []LeaveDays
+	err := r.db.Model(&models.LeaveRequest{}).
+		Select("leave_type, status, SUM(working_days) AS days").
+		Where("teacher_id = ? AND academic_year = ?", teacherID, academicYear).
+		Group("leave_type, status").
+		Scan(&days).Error
+	return days, err
+}
+
+// Decide moves a pending request to status, recording who decided and why.
+// It reports false when the request was no longer pending, so that it cannot
+// be decided twice.
+func (r *LeaveRequestRepository) Decide(request *models.LeaveRequest, status string, by *uint, comment string, at time.Time) (bool, error) {
+	result := r.db.Model(&models.LeaveRequest{}).
+		Where("id = ? AND status = ?", request.ID, "pending").
+		Updates(map[string]interface{}{
+			"status":           status,
+			"working_days":     request.WorkingDays,
+			"approved_by":      by,
+			"decided_at":       at,
+			"decision_comment": comment,
+		})
+	return result.RowsAffected == 1, result.Error
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"time"
+	"school-erp-backend/internal/models"
+	"gorm.io/gorm"
+)
+
+type LoginEventRepository struct {
+	db *gorm.DB
+}
+
+func NewLoginEventRepository(db *gorm.DB) *LoginEventRepository {
+	return &LoginEventRepository{db: db}
+}
+
+func (r *LoginEventRepository) Create(event *models.LoginEvent) error {
+	return r.db.Create(event).Error
+}
+
+// FindAll returns the most recent events matching the non-zero filters and
+// the optional time range, newest first. success filters on the outcome
+// unless it is nil.
+func (r *LoginEventRepository) FindAll(filter models.LoginEvent, success *bool, from, to time.Time, limit int) ([]models.LoginEvent, error) {
+	var events []models.LoginEvent
+	query := r.db
+	if filter.UserID != nil {
+		query = query.Where("user_id = ?", *filter.UserID)
+	}
+	if filter.Email != "" {
+		query = query.Where("email = ?", filter.Email)
+	}
+	if filter.IPAddress != "" {
+		query = query.Where("ip_address = ?", filter.IPAddress)
+	}
+	if success != nil {
+		query = query.Where("success = ?", *success)
+	}
+	if !from.IsZero() {
+		query = query.Where("created_at >= ?", from)
+	}
+	if !to.IsZero() {
+		query = query.Where("created_at < ?", to)
+	}
+	err := query.Order("created_at DESC").Limit(limit).Find(&events).Error
+	return events, err
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"errors"
+	"school-erp-backend/internal/models"
+	"gorm.io/gorm"
+)
+
+type MarkRepository struct {
+	db *gorm.DB
+}
+
+func NewMarkRepository(db *gorm.DB) *MarkRepository {
+	return &MarkRepository{db: db}
+}
+
+// Scoped returns the repository restricted to the marks the scope allows
+func (r *MarkRepository) Scoped(scope Scope) *MarkRepository {
+	return &MarkRepository{db: scope.apply(r.db, scope.Marks)}
+}
+
+func (r *MarkRepository) FindByExam(examID uint) ([]models.Mark, error) {
+	var marks []models.Mark
+	err := r.db.Where("exam_id = ?", examID).Find(&marks).Error
+	return marks, err
+}
+
+func (r *MarkRepository) CountByExam(examID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Mark{}).Where("exam_id = ?", examID).Count(&count).Error
+	return count, err
+}
+
+func (r *MarkRepository) FindByStudent(studentID uint, academicYear string) ([]models.Mark, error) {
+	var marks []models.Mark
+	err := r.db.Where("student_id = ? AND academic_year = ?", studentID, academicYear).
+		Preload("Subject").Preload("Exam").Find(&marks).Error
+	return marks, err
+}
+
+// UpsertBulk writes all marks in a single transaction, updating the existing
+// row when the student already has marks for the exam.
+func (r *MarkRepository) UpsertBulk(marks []models.Mark) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for i := range marks {
+			mark := &marks[i]
+
+			var existing models.Mark
+			err := tx.Where("student_id = ? AND exam_id = ?", mark.StudentID, mark.ExamID).First(&existing).Error
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				if err := tx.Create(mark).Error; err != nil {
+					return err
+				}
+				continue
+			}
+			if err != nil {
+				return err
+			}
+
+			existing.MarksObtained = mark.MarksObtained
+			existing.TotalMarks = mark.TotalMarks
+			existing.Percentage = mark.Percentage
+			existing.Grade = mark.Grade
+			existing.GradePoint = mark.GradePoint
+			existing.CreatedBy = mark.CreatedBy
+			if err := tx.Save(&existing).Error; err != nil {
+				return err
+			}
+			*mark = existing
+		}
+		return nil
+	})
+}
+
+// FindPublishedByStudent returns the marks of a student in exams whose
+// results are published
+func (r *MarkRepository) FindPublishedByStudent(studentID uint, academicYear string) ([]models.Mark, error) {
+	var marks []models.Mark
+	err := r.db.Joins("JOIN exams ON exams.id = marks.exam_id").
+		Where("marks.student_id = ? AND marks.academic_year = ? AND exams.status = ?", studentID, academicYear, "published").
+		Preload("Subject").Preload("Exam").
+		Order("exams.exam_date, marks.id").Find(&marks).Error
+	return marks, err
+}
@@ -0,0 +1,220 @@
+package repository
+
+import (
+	"time"
+	"school-erp-backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// noticePriorityOrder sorts urgent notices first
+const noticePriorityOrder = "CASE notices.priority WHEN 'urgent' THEN 0 WHEN 'high' THEN 1 WHEN 'normal' THEN 2 ELSE 3 END"
+
+// NoticeReader is who a notice feed is for: a user with all their roles, and
+// the classes and sections they belong to as a student or through their
+// children as a guardian.
+type NoticeReader struct {
+	UserID     uint
+	Roles      []string
+	ClassIDs   []uint
+	SectionIDs []uint
+}
+
+type NoticeRepository struct {
+	db *gorm.DB
+}
+
+func NewNoticeRepository(db *gorm.DB) *NoticeRepository {
+	return &NoticeRepository{db: db}
+}
+
+// Create stores a notice with its targets
+func (r *NoticeRepository) Create(notice *models.Notice) error {
+	return r.db.Create(notice).Error
+}
+
+func (r *NoticeRepository) FindByID(id uint) (*models.Notice, error) {
+	var notice models.Notice
+	err := r.db.Preload("Targets").Preload("Creator").First(&notice, id).Error
+	return &notice, err
+}
+
+// FindAll returns the notices matching the non-zero filters. A non-empty
+// status keeps only the "published" or the "scheduled" notices at now.
+func (r *NoticeRepository) FindAll(filter models.Notice, status string, now time.Time) ([]models.Notice, error) {
+	var notices []models.Notice
+	query := r.db.Preload("Targets").Preload("Creator")
+	if filter.CreatedBy != 0 {
+		query = query.Where("created_by = ?", filter.CreatedBy)
+	}
+	if filter.Priority != "" {
+		query = query.Where("priority = ?", filter.Priority)
+	}
+	if filter.Category != "" {
+		query = query.Where("category = ?", filter.Category)
+	}
+	switch status {
+	case "published":
+		query = query.Where("published_at <= ?", now)
+	case "scheduled":
+		query = query.Where("published_at > ?", now)
+	}
+	err := query.Order("published_at DESC, id DESC").Find(&notices).Error
+	return notices, err
+}
+
+// Update saves a notice and replaces its targets
+func (r *NoticeRepository) Update(notice *models.Notice) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Save(notice).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("notice_id = ?", notice.ID).Delete(&models.NoticeTarget{}).Error; err != nil {
+			return err
+		}
+		for i := range notice.Targets {
+			notice.Targets[i].ID = 0
+			notice.Targets[i].NoticeID = notice.ID
+		}
+		if len(notice.Targets) == 0 {
+			return nil
+		}
+		return tx.Create(&notice.Targets).Error
+	})
+}
+
+// Delete removes a notice with its targets and reads
+func (r *NoticeRepository) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("notice_id = ?", id).Delete(&models.NoticeTarget{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("notice_id = ?", id).Delete(&models.NoticeRead{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Notice{}, id).Error
+	})
+}
+
+// Feed returns the notices published to the reader by now, urgent and then
+// newest first. unreadOnly drops those the reader has read.
+func (r *NoticeRepository) Feed(reader NoticeReader, now time.Time, unreadOnly bool, limit, offset int) ([]models.Notice, error) {
+	var notices []models.Notice
+	query := r.visible(reader, now)
+	if unreadOnly {
+		query = query.Where("notices.id NOT IN (?)", r.readBy(reader.UserID))
+	}
+	err := query.Preload("Creator").
+		Order(noticePriorityOrder).Order("notices.published_at DESC, notices.id DESC").
+		Limit(limit).Offset(offset).Find(&notices).Error
+	return notices, err
+}
+
+// FindVisible returns a notice if it is published to the reader
+func (r *NoticeRepository) FindVisible(reader NoticeReader, id uint, now time.Time) (*models.Notice, error) {
+	var notice models.Notice
+	err := r.visible(reader, now).Preload("Creator").First(&notice, id).Error
+	return &notice, err
+}
+
+// CountUnread counts the notices published to the reader that they have not
+// read, in total and of urgent priority.
+func (r *NoticeRepository) CountUnread(reader NoticeReader, now time.Time) (int64, int64, error) {
+	var total, urgent int64
+	unread := func() *gorm.DB {
+		return r.visible(reader, now).Model(&models.Notice{}).
+			Where("notices.id NOT IN (?)", r.readBy(reader.UserID))
+	}
+	if err := unread().Count(&total).Error; err != nil {
+		return 0, 0, err
+	}
+	if err := unread().Where("notices.priority = ?", "urgent").Count(&urgent).Error; err != nil {
+		return 0, 0, err
+	}
+	return total, urgent, nil
+}
+
+// MarkRead records that a user read a notice. Reading again keeps the first
+// read time.
+func (r *NoticeRepository) MarkRead(noticeID, userID uint, at time.Time) (*models.NoticeRead, error) {
+	read := models.NoticeRead{NoticeID: noticeID, UserID: userID}
+	err := r.db.Attrs(models.NoticeRead{ReadAt: at}).FirstOrCreate(&read).Error
+	return &read, err
+}
+
+// FindReads returns the reads of a user among the given notices
+func (r *NoticeRepository) FindReads(userID uint, noticeIDs []uint) ([]models.NoticeRead, error) {
+	var reads []models.NoticeRead
+	if len(noticeIDs) == 0 {
+		return reads, nil
+	}
+	err := r.db.Where("user_id = ? AND notice_id IN ?", userID, noticeIDs).Find(&reads).Error
+	return reads, err
+}
+
+// Recipients returns the active users a notice is published to: everyone, or
+// the users matching any of its targets. Class and section targets reach the
+// students in them and their guardians.
+func (r *NoticeRepository) Recipients(notice *models.Notice) ([]models.User, error) {
+	var users []models.User
+	query := r.db.Where("users.status = ?", "active")
+	if notice.VisibilityType != "all" {
+		var roles []string
+		var classIDs, sectionIDs, userIDs []uint
+		for _, target := range notice.Targets {
+			switch target.TargetType {
+			case "role":
+				roles = append(roles, target.Role)
+			case "class":
+				classIDs = append(classIDs, target.TargetID)
+			case "section":
+				sectionIDs = append(sectionIDs, target.TargetID)
+			case "user":
+				userIDs = append(userIDs, target.TargetID)
+			}
+		}
+
+		newDB := func() *gorm.DB { return r.db.Session(&gorm.Session{NewDB: true}) }
+		extraRoles := newDB().Model(&models.UserRole{}).Select("user_roles.user_id").
+			Joins("JOIN roles ON roles.id = user_roles.role_id").Where("roles.name IN ?", roles)
+		students := newDB().Model(&models.Student{}).Select("id").
+			Where("class_id IN ? OR section_id IN ?", classIDs, sectionIDs)
+		studentUsers := newDB().Model(&models.Student{}).Select("user_id").Where("id IN (?)", students)
+		guardianUsers := newDB().Model(&models.Guardian{}).Select("guardians.user_id").
+			Joins("JOIN student_guardians ON student_guardians.guardian_id = guardians.id").
+			Where("student_guardians.student_id IN (?)", students)
+
+		query = query.Where(newDB().
+			Where("users.role IN ?", roles).
+			Or("users.id IN (?)", extraRoles).
+			Or("users.id IN (?)", studentUsers).
+			Or("users.id IN (?)", guardianUsers).
+			Or("users.id IN ?", userIDs))
+	}
+	err := query.Order("users.id").Find(&users).Error
+	return users, err
+}
+
+// ReadUserIDs returns the users who have read a notice
+func (r *NoticeRepository) ReadUserIDs(noticeID uint) ([]uint, error) {
+	var userIDs []uint
+	err := r.db.Model(&models.NoticeRead{}).Where("notice_id = ?", noticeID).Pluck("user_id", &userIDs).Error
+	return userIDs, err
+}
+
+// visible restricts notices to those published to the reader by now
+func (r *NoticeRepository) visible(reader NoticeReader, now time.Time) *gorm.DB {
+	newDB := r.db.Session(&gorm.Session{NewDB: true})
+	targeted := newDB.Model(&models.NoticeTarget{}).Select("notice_id").Where(
+		newDB.Where("target_type = ? AND role IN ?", "role", reader.Roles).
+			Or("target_type = ? AND target_id IN ?", "class", reader.ClassIDs).
+			Or("target_type = ? AND target_id IN ?", "section", reader.SectionIDs).
+			Or("target_type = ? AND target_id = ?", "user", reader.UserID))
+	return r.db.Where("notices.published_at <= ?", now).
+		Where(newDB.Where("notices.visibility_type = ?", "all").Or("notices.id IN (?)", targeted))
+}
+
+func (r *NoticeRepository) readBy(userID uint) *gorm.DB {
+	return r.db.Session(&gorm.Session{NewDB: true}).Model(&models.NoticeRead{}).
+		Select("notice_id").Where("user_id = ?", userID)
+}
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"school-erp-backend/internal/models"
+	"gorm.io/gorm"
+)
+
+type PermissionRepository struct {
+	db *gorm.DB
+}
+
+func NewPermissionRepository(db *gorm.DB) *PermissionRepository {
+	return &PermissionRepository{db: db}
+}
+
+func (r *PermissionRepository) FindAll() ([]models.Permission, error) {
+	var permissions []models.Permission
+	err := r.db.Order("name").Find(&permissions).Error
+	return permissions, err
+}
+
+func (r *PermissionRepository) FindByNames(names []string) ([]models.Permission, error) {
+	var permissions []models.Permission
+	err := r.db.Where("name IN ?", names).Order("name").Find(&permissions).Error
+	return permissions, err
+}
+
+// Ensure creates a permission or updates its description
+func (r *PermissionRepository) Ensure(permission *models.Permission) error {
+	return r.db.Where(models.Permission{Name: permission.Name}).
+		Assign(models.Permission{Description: permission.Description}).
+		FirstOrCreate(permission).Error
+}
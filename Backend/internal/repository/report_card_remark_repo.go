@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"errors"
+	"school-erp-backend/internal/models"
+	"gorm.io/gorm"
+)
+
+type ReportCardRemarkRepository struct {
+	db *gorm.DB
+}
+
+func NewReportCardRemarkRepository(db *gorm.DB) *ReportCardRemarkRepository {
+	return &ReportCardRemarkRepository{db: db}
+}
+
+// Scoped returns the repository restricted to the remarks the scope allows
+func (r *ReportCardRemarkRepository) Scoped(scope Scope) *ReportCardRemarkRepository {
+	return &ReportCardRemarkRepository{db: scope.apply(r.db, scope.Remarks)}
+}
+
+func (r *ReportCardRemarkRepository) Find(studentID uint, academicYear, examType string) (*models.ReportCardRemark, error) {
+	var remark models.ReportCardRemark
+	err := r.db.Where("student_id = ? AND academic_year = ? AND exam_type = ?", studentID, academicYear, examType).First(&remark).Error
+	return &remark, err
+}
+
+func (r *ReportCardRemarkRepository) FindByStudents(studentIDs []uint, academicYear, examType string) ([]models.ReportCardRemark, error) {
+	var remarks []models.ReportCardRemark
+	if len(studentIDs) == 0 {
+		return remarks, nil
+	}
+	err := r.db.Where("student_id IN ? AND academic_year = ? AND exam_type = ?", studentIDs, academicYear, examType).Find(&remarks).Error
+	return remarks, err
+}
+
+// Upsert creates the remark or replaces the text of the existing one for the
+// same student, academic year and exam type.
+func (r *ReportCardRemarkRepository) Upsert(remark *models.ReportCardRemark) error {
+	existing, err := r.Find(remark.StudentID, remark.AcademicYear, remark.ExamType)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return r.db.Create(remark).Error
+	}
+	if err != nil {
+		return err
+	}
+
+	existing.Remarks = remark.Remarks
+	existing.CreatedBy = remark.CreatedBy
+	if err := r.db.Save(existing).Error; err != nil {
+		return err
+	}
+	*remark = *existing
+	return nil
+}
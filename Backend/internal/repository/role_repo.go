@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"school-erp-backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RoleRepository struct {
+	db *gorm.DB
+}
+
+func NewRoleRepository(db *gorm.DB) *RoleRepository {
+	return &RoleRepository{db: db}
+}
+
+func (r *RoleRepository) Create(role *models.Role) error {
+	return r.db.Omit(clause.Associations).Create(role).Error
+}
+
+func (r *RoleRepository) FindByID(id uint) (*models.Role, error) {
+	var role models.Role
+	err := r.db.Preload("Permissions", func(db *gorm.DB) *gorm.DB {
+		return db.Order("permissions.name")
+	}).First(&role, id).Error
+	return &role, err
+}
+
+func (r *RoleRepository) FindByName(name string) (*models.Role, error) {
+	var role models.Role
+	err := r.db.Where("name = ?", name).First(&role).Error
+	return &role, err
+}
+
+func (r *RoleRepository) FindByNames(names []string) ([]models.Role, error) {
+	var roles []models.Role
+	err := r.db.Where("name IN ?", names).Order("name").Find(&roles).Error
+	return roles, err
+}
+
+func (r *RoleRepository) FindAll() ([]models.Role, error) {
+	var roles []models.Role
+	err := r.db.Preload("Permissions", func(db *gorm.DB) *gorm.DB {
+		return db.Order("permissions.name")
+	}).Order("name").Find(&roles).Error
+	return roles, err
+}
+
+// Update saves the role columns only, see SetPermissions for its permissions
+func (r *RoleRepository) Update(role *models.Role) error {
+	return r.db.Omit(clause.Associations).Save(role).Error
+}
+
+// Delete removes a role along with its permissions and its assignments to
+// users.
+func (r *RoleRepository) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM role_permissions WHERE role_id = ?", id).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("role_id = ?", id).Delete(&models.UserRole{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Role{}, id).Error
+	})
+}
+
+// SetPermissions replaces the permissions of a role
+func (r *RoleRepository) SetPermissions(role *models.Role, permissions []models.Permission) error {
+	return r.db.Model(role).Association("Permissions").Replace(permissions)
+}
+
+// CountPrimaryUsers returns how many users have the role as their primary
+// role.
+func (r *RoleRepository) CountPrimaryUsers(name string) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.User{}).Where("role = ?", name).Count(&count).Error
+	return count, err
+}
+
+// UserRoles returns the roles a user has been given besides their primary
+// role.
+func (r *RoleRepository) UserRoles(userID uint) ([]models.Role, error) {
+	var roles []models.Role
+	err := r.db.Joins("JOIN user_roles ON user_roles.role_id = roles.id").
+		Where("user_roles.user_id = ?", userID).
+		Order("roles.name").Find(&roles).Error
+	return roles, err
+}
+
+// SetUserRoles replaces the additional roles of a user
+func (r *RoleRepository) SetUserRoles(userID uint, roleIDs []uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&models.UserRole{}).Error; err != nil {
+			return err
+		}
+		userRoles := make([]models.UserRole, 0, len(roleIDs))
+		for _, roleID := range roleIDs {
+			userRoles = append(userRoles, models.UserRole{UserID: userID, RoleID: roleID})
+		}
+		if len(userRoles) == 0 {
+			return nil
+		}
+		return tx.Omit(clause.Associations).Create(&userRoles).Error
+	})
+}
+
+// DeleteUserRoles removes the additional roles of a user
+func (r *RoleRepository) DeleteUserRoles(userID uint) error {
+	return r.db.Where("user_id = ?", userID).Delete(&models.UserRole{}).Error
+}
+
+// PermissionNames returns the distinct permissions granted by any of the
+// named roles.
+func (r *RoleRepository) PermissionNames(roleNames []string) ([]string, error) {
+	var names []string
+	err := r.db.Model(&models.Permission{}).
+		Distinct("permissions.name").
+		Joins("JOIN role_permissions ON role_permissions.permission_id = permissions.id").
+		Joins("JOIN roles ON roles.id = role_permissions.role_id").
+		Where("roles.name IN ?", roleNames).
+		Order("permissions.name").
+		Pluck("permissions.name", &names).Error
+	return names, err
+}
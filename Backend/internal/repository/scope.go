@@ -0,0 +1,251 @@
+package repository
+
+import (
+	"errors"
+	"school-erp-backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// ErrNoProfile is returned for teachers, students and parents without a
+// teacher, student or guardian record, who cannot be scoped.
+var ErrNoProfile = errors.New("user has no teacher, student or guardian profile")
+
+// Scope restricts queries to the rows a user may see. Teachers see the
+// sections they are assigned to teach, in any academic year, students see
+// their own rows and the data of their class and section, and parents see
+// those of their children. Every other role is unrestricted, and so is the
+// zero Scope.
+//
+// Repositories return a restricted copy of themselves from Scoped, so handlers
+// serving teachers and students cannot leak rows by forgetting a condition.
+// Scoped repositories are meant for reads; writes go through the unscoped
+// repository after the row was read through the scoped one.
+type Scope struct {
+	TeacherID  uint
+	StudentID  uint
+	ClassID    uint // class of the student
+	SectionID  uint // section of the student
+	GuardianID uint
+}
+
+// NewScope returns the scope of an authenticated user
+func NewScope(db *gorm.DB, userID uint, role string) (Scope, error) {
+	switch role {
+	case "teacher":
+		teacher, err := NewTeacherRepository(db).FindByUserID(userID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return Scope{}, ErrNoProfile
+		}
+		if err != nil {
+			return Scope{}, err
+		}
+		return Scope{TeacherID: teacher.ID}, nil
+	case "student":
+		student, err := NewStudentRepository(db).FindByUserID(userID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return Scope{}, ErrNoProfile
+		}
+		if err != nil {
+			return Scope{}, err
+		}
+		return StudentScope(student), nil
+	case "parent":
+		guardian, err := NewGuardianRepository(db).FindByUserID(userID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return Scope{}, ErrNoProfile
+		}
+		if err != nil {
+			return Scope{}, err
+		}
+		return Scope{GuardianID: guardian.ID}, nil
+	}
+	return Scope{}, nil
+}
+
+// StudentScope returns the scope of a student
+func StudentScope(student *models.Student) Scope {
+	return Scope{StudentID: student.ID, ClassID: student.ClassID, SectionID: student.SectionID}
+}
+
+// Restricted reports whether the scope hides any rows
+func (s Scope) Restricted() bool {
+	return s.TeacherID != 0 || s.StudentID != 0 || s.GuardianID != 0
+}
+
+// Students limits students to those of the taught sections, or to the
+// student themselves.
+func (s Scope) Students(db *gorm.DB) *gorm.DB {
+	switch {
+	case s.TeacherID != 0:
+		return db.Where("students.section_id IN (?)", s.taughtSections(db))
+	case s.StudentID != 0:
+		return db.Where("students.id = ?", s.StudentID)
+	case s.GuardianID != 0:
+		return db.Where("students.id IN (?)", s.children(db))
+	}
+	return db
+}
+
+// Sections limits sections to the taught ones, or to the section of the
+// student.
+func (s Scope) Sections(db *gorm.DB) *gorm.DB {
+	switch {
+	case s.TeacherID != 0:
+		return db.Where("sections.id IN (?)", s.taughtSections(db))
+	case s.StudentID != 0:
+		return db.Where("sections.id = ?", s.SectionID)
+	case s.GuardianID != 0:
+		return db.Where("sections.id IN (?)", s.childColumn(db, "section_id"))
+	}
+	return db
+}
+
+// Attendance limits attendance to the taught sections, or to the records of
+// the student.
+func (s Scope) Attendance(db *gorm.DB) *gorm.DB {
+	switch {
+	case s.TeacherID != 0:
+		return db.Where("attendances.section_id IN (?)", s.taughtSections(db))
+	case s.StudentID != 0:
+		return db.Where("attendances.student_id = ?", s.StudentID)
+	case s.GuardianID != 0:
+		return db.Where("attendances.student_id IN (?)", s.children(db))
+	}
+	return db
+}
+
+// Marks limits marks to students of the taught sections, or to the marks of
+// the student.
+func (s Scope) Marks(db *gorm.DB) *gorm.DB {
+	switch {
+	case s.TeacherID != 0:
+		return db.Where("marks.student_id IN (?)", s.taughtStudents(db))
+	case s.StudentID != 0:
+		return db.Where("marks.student_id = ?", s.StudentID)
+	case s.GuardianID != 0:
+		return db.Where("marks.student_id IN (?)", s.children(db))
+	}
+	return db
+}
+
+// Exams limits exams to the classes a teacher teaches in, or to the class of
+// the student.
+func (s Scope) Exams(db *gorm.DB) *gorm.DB {
+	switch {
+	case s.TeacherID != 0:
+		return db.Where("exams.class_id IN (?)", s.taughtClasses(db))
+	case s.StudentID != 0:
+		return db.Where("exams.class_id = ?", s.ClassID)
+	case s.GuardianID != 0:
+		return db.Where("exams.class_id IN (?)", s.childColumn(db, "class_id"))
+	}
+	return db
+}
+
+// Assignments limits assignments to those a teacher set, or to the class of
+// the student.
+func (s Scope) Assignments(db *gorm.DB) *gorm.DB {
+	switch {
+	case s.TeacherID != 0:
+		return db.Where("assignments.teacher_id = ?", s.TeacherID)
+	case s.StudentID != 0:
+		return db.Where("assignments.class_id = ?", s.ClassID)
+	case s.GuardianID != 0:
+		return db.Where("assignments.class_id IN (?)", s.childColumn(db, "class_id"))
+	}
+	return db
+}
+
+// Submissions limits submissions to the assignments a teacher set, or to the
+// submissions of the student.
+func (s Scope) Submissions(db *gorm.DB) *gorm.DB {
+	switch {
+	case s.TeacherID != 0:
+		own := db.Session(&gorm.Session{NewDB: true}).Model(&models.Assignment{}).
+			Select("id").Where("teacher_id = ?", s.TeacherID)
+		return db.Where("assignment_submissions.assignment_id IN (?)", own)
+	case s.StudentID != 0:
+		return db.Where("assignment_submissions.student_id = ?", s.StudentID)
+	case s.GuardianID != 0:
+		return db.Where("assignment_submissions.student_id IN (?)", s.children(db))
+	}
+	return db
+}
+
+// Timetables limits timetable slots to those a teacher takes or those of the
+// taught sections, or to the section of the student.
+func (s Scope) Timetables(db *gorm.DB) *gorm.DB {
+	switch {
+	case s.TeacherID != 0:
+		return db.Where("timetables.teacher_id = ? OR timetables.section_id IN (?)", s.TeacherID, s.taughtSections(db))
+	case s.StudentID != 0:
+		return db.Where("timetables.section_id = ?", s.SectionID)
+	case s.GuardianID != 0:
+		return db.Where("timetables.section_id IN (?)", s.childColumn(db, "section_id"))
+	}
+	return db
+}
+
+// Remarks limits report card remarks to students of the taught sections, or
+// to the remarks of the student.
+func (s Scope) Remarks(db *gorm.DB) *gorm.DB {
+	switch {
+	case s.TeacherID != 0:
+		return db.Where("report_card_remarks.student_id IN (?)", s.taughtStudents(db))
+	case s.StudentID != 0:
+		return db.Where("report_card_remarks.student_id = ?", s.StudentID)
+	case s.GuardianID != 0:
+		return db.Where("report_card_remarks.student_id IN (?)", s.children(db))
+	}
+	return db
+}
+
+// TeachingAssignments limits teaching assignments to those of the teacher,
+// or to those of the section of the student.
+func (s Scope) TeachingAssignments(db *gorm.DB) *gorm.DB {
+	switch {
+	case s.TeacherID != 0:
+		return db.Where("teaching_assignments.teacher_id = ?", s.TeacherID)
+	case s.StudentID != 0:
+		return db.Where("teaching_assignments.section_id = ?", s.SectionID)
+	case s.GuardianID != 0:
+		return db.Where("teaching_assignments.section_id IN (?)", s.childColumn(db, "section_id"))
+	}
+	return db
+}
+
+// apply returns db restricted by a condition of the scope. The session keeps
+// the condition on every query made with the returned db.
+func (s Scope) apply(db *gorm.DB, condition func(*gorm.DB) *gorm.DB) *gorm.DB {
+	if !s.Restricted() {
+		return db
+	}
+	return condition(db).Session(&gorm.Session{})
+}
+
+func (s Scope) taughtSections(db *gorm.DB) *gorm.DB {
+	return db.Session(&gorm.Session{NewDB: true}).Model(&models.TeachingAssignment{}).
+		Select("section_id").Where("teacher_id = ?", s.TeacherID)
+}
+
+func (s Scope) taughtClasses(db *gorm.DB) *gorm.DB {
+	return db.Session(&gorm.Session{NewDB: true}).Model(&models.TeachingAssignment{}).
+		Select("class_id").Where("teacher_id = ?", s.TeacherID)
+}
+
+func (s Scope) children(db *gorm.DB) *gorm.DB {
+	return db.Session(&gorm.Session{NewDB: true}).Model(&models.StudentGuardian{}).
+		Select("student_id").Where("guardian_id = ?", s.GuardianID)
+}
+
+// childColumn selects a column, such as the class or section, of the children
+// of the guardian
+func (s Scope) childColumn(db *gorm.DB, column string) *gorm.DB {
+	return db.Session(&gorm.Session{NewDB: true}).Model(&models.Student{}).
+		Select(column).Where("id IN (?)", s.children(db))
+}
+
+func (s Scope) taughtStudents(db *gorm.DB) *gorm.DB {
+	return db.Session(&gorm.Session{NewDB: true}).Model(&models.Student{}).
+		Select("id").Where("section_id IN (?)", s.taughtSections(db))
+}
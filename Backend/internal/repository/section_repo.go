@@ -13,6 +13,11 @@ func NewSectionRepository(db *gorm.DB) *SectionRepository {
 	return &SectionRepository{db: db}
 }
 
+// Scoped returns the repository restricted to the sections the scope allows
+func (r *SectionRepository) Scoped(scope Scope) *SectionRepository {
+	return &SectionRepository{db: scope.apply(r.db, scope.Sections)}
+}
+
 func (r *SectionRepository) Create(section *models.Section) error {
 	return r.db.Create(section).Error
 }
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"time"
+	"school-erp-backend/internal/models"
+	"gorm.io/gorm"
+)
+
+type SessionRepository struct {
+	db *gorm.DB
+}
+
+func NewSessionRepository(db *gorm.DB) *SessionRepository {
+	return &SessionRepository{db: db}
+}
+
+func (r *SessionRepository) Create(session *models.Session) error {
+	return r.db.Create(session).Error
+}
+
+func (r *SessionRepository) FindByID(id uint) (*models.Session, error) {
+	var session models.Session
+	err := r.db.First(&session, id).Error
+	return &session, err
+}
+
+// FindByToken looks a session up by the hash of its refresh token, including
+// rotated and revoked sessions.
+func (r *SessionRepository) FindByToken(tokenHash string) (*models.Session, error) {
+	var session models.Session
+	err := r.db.Where("token = ?", tokenHash).First(&session).Error
+	return &session, err
+}
+
+// MarkRotated records that the refresh token of a session has been used. It
+// reports false when the token was already rotated, so that two concurrent
+// refreshes with one token cannot both succeed.
+func (r *SessionRepository) MarkRotated(id uint, at time.Time) (bool, error) {
+	result := r.db.Model(&models.Session{}).
+		Where("id = ? AND rotated_at IS NULL", id).
+		Update("rotated_at", at)
+	return result.RowsAffected == 1, result.Error
+}
+
+// RevokeFamily revokes every session of a family
+func (r *SessionRepository) RevokeFamily(familyID string, at time.Time) error {
+	return r.db.Model(&models.Session{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", at).Error
+}
+
+// RevokeUser revokes every session of a user, signing them out everywhere
+func (r *SessionRepository) RevokeUser(userID uint, at time.Time) error {
+	return r.db.Model(&models.Session{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", at).Error
+}
+
+// FindActiveByUser returns the current session of every family of a user
+// that is still signed in, most recently used first.
+func (r *SessionRepository) FindActiveByUser(userID uint, now time.Time) ([]models.Session, error) {
+	var sessions []models.Session
+	err := r.db.Where("user_id = ? AND rotated_at IS NULL AND revoked_at IS NULL AND expires_at > ?", userID, now).
+		Order("last_seen_at DESC").Find(&sessions).Error
+	return sessions, err
+}
+
+// Touch records activity on a session and on the current session of its
+// family.
+func (r *SessionRepository) Touch(session *models.Session, at time.Time) error {
+	return r.db.Model(&models.Session{}).
+		Where("family_id = ? AND (id = ? OR rotated_at IS NULL)", session.FamilyID, session.ID).
+		Update("last_seen_at", at).Error
+}
+
+// RevokeUserExcept revokes every session of a user outside one family, so
+// the device the request came from stays signed in.
+func (r *SessionRepository) RevokeUserExcept(userID uint, familyID string, at time.Time) error {
+	return r.db.Model(&models.Session{}).
+		Where("user_id = ? AND family_id <> ? AND revoked_at IS NULL", userID, familyID).
+		Update("revoked_at", at).Error
+}
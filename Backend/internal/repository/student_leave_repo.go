@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"time"
+	"school-erp-backend/internal/models"
+	"gorm.io/gorm"
+)
+
+type StudentLeaveRequestRepository struct {
+	db *gorm.DB
+}
+
+func NewStudentLeaveRequestRepository(db *gorm.DB) *StudentLeaveRequestRepository {
+	return &StudentLeaveRequestRepository{db: db}
+}
+
+func (r *StudentLeaveRequestRepository) Create(request *models.StudentLeaveRequest) error {
+	return r.db.Create(request).Error
+}
+
+func (r *StudentLeaveRequestRepository) FindByID(id uint) (*models.StudentLeaveRequest, error) {
+	var request models.StudentLeaveRequest
+	err := r.db.Preload("Student").Preload("Applicant").Preload("Decider").First(&request, id).Error
+	return &request, err
+}
+
+// FindAll returns the leave requests matching the non-zero filters, latest
+// first. Non-zero from and to keep the requests overlapping those days.
+func (r *StudentLeaveRequestRepository) FindAll(filter models.StudentLeaveRequest, from, to time.Time) ([]models.StudentLeaveRequest, error) {
+	var requests []models.StudentLeaveRequest
+	query := r.db.Preload("Student").Preload("Applicant").Preload("Decider")
+	if filter.StudentID != 0 {
+		query = query.Where("student_id = ?", filter.StudentID)
+	}
+	if filter.ClassID != 0 {
+		query = query.Where("class_id = ?", filter.ClassID)
+	}
+	if filter.SectionID != 0 {
+		query = query.Where("section_id = ?", filter.SectionID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.AcademicYear != "" {
+		query = query.Where("academic_year = ?", filter.AcademicYear)
+	}
+	if !from.IsZero() {
+		query = query.Where("end_date >= ?", from)
+	}
+	if !to.IsZero() {
+		query = query.Where("start_date <= ?", to)
+	}
+	err := query.Order("start_date DESC, id DESC").Find(&requests).Error
+	return requests, err
+}
+
+// FindOverlapping returns the pending and approved requests of a student that
+// share a day with from to to.
+func (r *StudentLeaveRequestRepository) FindOverlapping(studentID uint, from, to time.Time) ([]models.StudentLeaveRequest, error) {
+	var requests []models.StudentLeaveRequest
+	err := r.db.Where("student_id = ? AND status IN ? AND start_date <= ? AND end_date >= ?",
+		studentID, []string{"pending", "approved"}, to, from).
+		Order("start_date").Find(&requests).Error
+	return requests, err
+}
+
+// StudentsOnLeave returns which of the students have approved leave on a date
+func (r *StudentLeaveRequestRepository) StudentsOnLeave(studentIDs []uint, date time.Time) ([]uint, error) {
+	var ids []uint
+	if len(studentIDs) == 0 {
+		return ids, nil
+	}
+	err := r.db.Model(&models.StudentLeaveRequest{}).
+		Where("student_id IN ? AND status = ? AND start_date <= ? AND end_date >= ?", studentIDs, "approved", date, date).
+		Distinct().Pluck("student_id", &ids).Error
+	return ids, err
+}
+
+// Decide moves a pending request to status, recording who decided and why.
+// It reports false when the request was no longer pending, so that it cannot
+// be decided twice.
+func (r *StudentLeaveRequestRepository) Decide(request *models.StudentLeaveRequest, status string, by *uint, comment string, at time.Time) (bool, error) {
+	result := r.db.Model(&models.StudentLeaveRequest{}).
+		Where("id = ? AND status = ?", request.ID, "pending").
+		Updates(map[string]interface{}{
+			"status":           status,
+			"school_days":      request.SchoolDays,
+			"decided_by":       by,
+			"decided_at":       at,
+			"decision_comment": comment,
+		})
+	return result.RowsAffected == 1, result.Error
+}
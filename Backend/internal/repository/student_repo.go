@@ -13,6 +13,11 @@ func NewStudentRepository(db *gorm.DB) *StudentRepository {
 	return &StudentRepository{db: db}
 }
 
+// Scoped returns the repository restricted to the students the scope allows
+func (r *StudentRepository) Scoped(scope Scope) *StudentRepository {
+	return &StudentRepository{db: scope.apply(r.db, scope.Students)}
+}
+
 func (r *StudentRepository) Create(student *models.Student) error {
 	return r.db.Create(student).Error
 }
@@ -43,5 +48,37 @@ func (r *StudentRepository) FindByClassAndSection(classID, sectionID uint) ([]mo
 	return students, err
 }
 
+func (r *StudentRepository) FindByClass(classID uint) ([]models.Student, error) {
+	var students []models.Student
+	err := r.db.Where("class_id = ?", classID).Find(&students).Error
+	return students, err
+}
 
+func (r *StudentRepository) FindByUserID(userID uint) (*models.Student, error) {
+	var student models.Student
+	err := r.db.Where("user_id = ?", userID).Preload("Class").Preload("Section").First(&student).Error
+	return &student, err
+}
 
+// FindAll returns the students matching the non-zero filters
+func (r *StudentRepository) FindAll(filter models.Student) ([]models.Student, error) {
+	var students []models.Student
+	query := r.db
+	if filter.ClassID != 0 {
+		query = query.Where("class_id = ?", filter.ClassID)
+	}
+	if filter.SectionID != 0 {
+		query = query.Where("section_id = ?", filter.SectionID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	err := query.Order("id").Find(&students).Error
+	return students, err
+}
+
+func (r *StudentRepository) FindByIDs(ids []uint) ([]models.Student, error) {
+	var students []models.Student
+	err := r.db.Where("id IN ?", ids).Order("id").Find(&students).Error
+	return students, err
+}
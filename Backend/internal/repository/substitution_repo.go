@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"time"
+	"school-erp-backend/internal/models"
+	"gorm.io/gorm"
+)
+
+type SubstitutionRepository struct {
+	db *gorm.DB
+}
+
+func NewSubstitutionRepository(db *gorm.DB) *SubstitutionRepository {
+	return &SubstitutionRepository{db: db}
+}
+
+func (r *SubstitutionRepository) Create(substitution *models.Substitution) error {
+	return r.db.Create(substitution).Error
+}
+
+func (r *SubstitutionRepository) FindByID(id uint) (*models.Substitution, error) {
+	var substitution models.Substitution
+	err := r.preload(r.db).First(&substitution, id).Error
+	return &substitution, err
+}
+
+func (r *SubstitutionRepository) Delete(id uint) error {
+	return r.db.Delete(&models.Substitution{}, id).Error
+}
+
+// FindAll returns the substitutions from from to to, both included, that
+// match the non-zero teacher filters, by date.
+func (r *SubstitutionRepository) FindAll(filter models.Substitution, from, to time.Time) ([]models.Substitution, error) {
+	var substitutions []models.Substitution
+	query := r.preload(r.db).Where("date >= ? AND date <= ?", from, to)
+	if filter.AbsentTeacherID != 0 {
+		query = query.Where("absent_teacher_id = ?", filter.AbsentTeacherID)
+	}
+	if filter.SubstituteTeacherID != 0 {
+		query = query.Where("substitute_teacher_id = ?", filter.SubstituteTeacherID)
+	}
+	err := query.Order("date, id").Find(&substitutions).Error
+	return substitutions, err
+}
+
+// FindByPeriod returns the substitution of a timetable slot on a date
+func (r *SubstitutionRepository) FindByPeriod(date time.Time, timetableID uint) (*models.Substitution, error) {
+	var substitution models.Substitution
+	err := r.db.Where("date = ? AND timetable_id = ?", date, timetableID).First(&substitution).Error
+	return &substitution, err
+}
+
+func (r *SubstitutionRepository) preload(db *gorm.DB) *gorm.DB {
+	return db.Preload("Timetable.Class").Preload("Timetable.Section").Preload("Timetable.Subject").
+		Preload("AbsentTeacher").Preload("SubstituteTeacher")
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"school-erp-backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TeachingAssignmentRepository struct {
+	db *gorm.DB
+}
+
+func NewTeachingAssignmentRepository(db *gorm.DB) *TeachingAssignmentRepository {
+	return &TeachingAssignmentRepository{db: db}
+}
+
+// Scoped returns the repository restricted to the teaching assignments the scope allows
+func (r *TeachingAssignmentRepository) Scoped(scope Scope) *TeachingAssignmentRepository {
+	return &TeachingAssignmentRepository{db: scope.apply(r.db, scope.TeachingAssignments)}
+}
+
+func (r *TeachingAssignmentRepository) Create(assignment *models.TeachingAssignment) error {
+	return r.db.Create(assignment).Error
+}
+
+func (r *TeachingAssignmentRepository) FindByID(id uint) (*models.TeachingAssignment, error) {
+	var assignment models.TeachingAssignment
+	err := r.db.Preload("Teacher").Preload("Class").Preload("Section").Preload("Subject").First(&assignment, id).Error
+	return &assignment, err
+}
+
+// Update saves the assignment columns only, so changed foreign keys are not
+// overwritten by the preloaded associations.
+func (r *TeachingAssignmentRepository) Update(assignment *models.TeachingAssignment) error {
+	return r.db.Omit(clause.Associations).Save(assignment).Error
+}
+
+func (r *TeachingAssignmentRepository) Delete(id uint) error {
+	return r.db.Delete(&models.TeachingAssignment{}, id).Error
+}
+
+// FindAll returns the assignments matching the non-zero filters
+func (r *TeachingAssignmentRepository) FindAll(filter models.TeachingAssignment) ([]models.TeachingAssignment, error) {
+	var assignments []models.TeachingAssignment
+	query := r.db.Preload("Teacher").Preload("Class").Preload("Section").Preload("Subject")
+	if filter.TeacherID != 0 {
+		query = query.Where("teacher_id = ?", filter.TeacherID)
+	}
+	if filter.ClassID != 0 {
+		query = query.Where("class_id = ?", filter.ClassID)
+	}
+	if filter.SectionID != 0 {
+		query = query.Where("section_id = ?", filter.SectionID)
+	}
+	if filter.SubjectID != 0 {
+		query = query.Where("subject_id = ?", filter.SubjectID)
+	}
+	if filter.AcademicYear != "" {
+		query = query.Where("academic_year = ?", filter.AcademicYear)
+	}
+	err := query.Order("class_id, section_id, subject_id").Find(&assignments).Error
+	return assignments, err
+}
+
+// FindBySubject returns the assignment of a subject in a section, which has
+// at most one teacher.
+func (r *TeachingAssignmentRepository) FindBySubject(sectionID, subjectID uint, academicYear string) (*models.TeachingAssignment, error) {
+	var assignment models.TeachingAssignment
+	err := r.db.Where("section_id = ? AND subject_id = ? AND academic_year = ?", sectionID, subjectID, academicYear).
+		First(&assignment).Error
+	return &assignment, err
+}
+
+func (r *TeachingAssignmentRepository) FindClassTeacher(sectionID uint, academicYear string) (*models.TeachingAssignment, error) {
+	var assignment models.TeachingAssignment
+	err := r.db.Where("section_id = ? AND academic_year = ? AND is_class_teacher = ?", sectionID, academicYear, true).
+		Preload("Teacher").First(&assignment).Error
+	return &assignment, err
+}
+
+// Teaches reports whether a teacher is assigned to a class in an academic
+// year. A zero sectionID or subjectID matches any section or subject, and
+// classTeacher restricts the match to class teacher assignments.
+func (r *TeachingAssignmentRepository) Teaches(teacherID, classID, sectionID, subjectID uint, academicYear string, classTeacher bool) (bool, error) {
+	query := r.db.Model(&models.TeachingAssignment{}).
+		Where("teacher_id = ? AND class_id = ? AND academic_year = ?", teacherID, classID, academicYear)
+	if sectionID != 0 {
+		query = query.Where("section_id = ?", sectionID)
+	}
+	if subjectID != 0 {
+		query = query.Where("subject_id = ?", subjectID)
+	}
+	if classTeacher {
+		query = query.Where("is_class_teacher = ?", true)
+	}
+	var count int64
+	err := query.Count(&count).Error
+	return count > 0, err
+}
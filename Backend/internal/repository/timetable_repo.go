@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"school-erp-backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TimetableRepository struct {
+	db *gorm.DB
+}
+
+func NewTimetableRepository(db *gorm.DB) *TimetableRepository {
+	return &TimetableRepository{db: db}
+}
+
+// Scoped returns the repository restricted to the timetable slots the scope allows
+func (r *TimetableRepository) Scoped(scope Scope) *TimetableRepository {
+	return &TimetableRepository{db: scope.apply(r.db, scope.Timetables)}
+}
+
+func (r *TimetableRepository) Create(slot *models.Timetable) error {
+	return r.db.Create(slot).Error
+}
+
+func (r *TimetableRepository) FindByID(id uint) (*models.Timetable, error) {
+	var slot models.Timetable
+	err := r.db.Preload("Class").Preload("Section").Preload("Subject").Preload("Teacher").First(&slot, id).Error
+	return &slot, err
+}
+
+// Update saves the slot columns only, so changed foreign keys are not
+// overwritten by the preloaded associations.
+func (r *TimetableRepository) Update(slot *models.Timetable) error {
+	return r.db.Omit(clause.Associations).Save(slot).Error
+}
+
+func (r *TimetableRepository) Delete(id uint) error {
+	return r.db.Delete(&models.Timetable{}, id).Error
+}
+
+// FindPotentialClashes returns the slots on the same academic year and day
+// that share the teacher, section or room of slot.
+func (r *TimetableRepository) FindPotentialClashes(slot *models.Timetable) ([]models.Timetable, error) {
+	var slots []models.Timetable
+	query := r.db.Where("academic_year = ? AND day = ?", slot.AcademicYear, slot.Day)
+	if slot.RoomNumber != "" {
+		query = query.Where("teacher_id = ? OR section_id = ? OR LOWER(room_number) = LOWER(?)", slot.TeacherID, slot.SectionID, slot.RoomNumber)
+	} else {
+		query = query.Where("teacher_id = ? OR section_id = ?", slot.TeacherID, slot.SectionID)
+	}
+	err := query.Find(&slots).Error
+	return slots, err
+}
+
+func (r *TimetableRepository) FindBySection(sectionID uint, academicYear string) ([]models.Timetable, error) {
+	var slots []models.Timetable
+	err := r.db.Where("section_id = ? AND academic_year = ?", sectionID, academicYear).
+		Preload("Subject").Preload("Teacher").Find(&slots).Error
+	return slots, err
+}
+
+func (r *TimetableRepository) FindByTeacher(teacherID uint, academicYear string) ([]models.Timetable, error) {
+	var slots []models.Timetable
+	err := r.db.Where("teacher_id = ? AND academic_year = ?", teacherID, academicYear).
+		Preload("Class").Preload("Section").Preload("Subject").Find(&slots).Error
+	return slots, err
+}
+
+func (r *TimetableRepository) FindByAcademicYear(academicYear string) ([]models.Timetable, error) {
+	var slots []models.Timetable
+	err := r.db.Where("academic_year = ?", academicYear).Find(&slots).Error
+	return slots, err
+}
+
+// FindByDay returns the slots of every section on one weekday of an academic year
+func (r *TimetableRepository) FindByDay(academicYear, day string) ([]models.Timetable, error) {
+	var slots []models.Timetable
+	err := r.db.Where("academic_year = ? AND day = ?", academicYear, day).Find(&slots).Error
+	return slots, err
+}
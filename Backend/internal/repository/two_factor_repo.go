@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"time"
+	"school-erp-backend/internal/models"
+	"gorm.io/gorm"
+)
+
+type TwoFactorRepository struct {
+	db *gorm.DB
+}
+
+func NewTwoFactorRepository(db *gorm.DB) *TwoFactorRepository {
+	return &TwoFactorRepository{db: db}
+}
+
+func (r *TwoFactorRepository) FindByUser(userID uint) (*models.TwoFactorAuth, error) {
+	var auth models.TwoFactorAuth
+	err := r.db.Where("user_id = ?", userID).First(&auth).Error
+	return &auth, err
+}
+
+func (r *TwoFactorRepository) Save(auth *models.TwoFactorAuth) error {
+	return r.db.Save(auth).Error
+}
+
+// DeleteByUser removes the secret and recovery codes of a user
+func (r *TwoFactorRepository) DeleteByUser(userID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&models.RecoveryCode{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("user_id = ?", userID).Delete(&models.TwoFactorAuth{}).Error
+	})
+}
+
+// UseStep records the time step of an accepted code. It reports false when
+// that step or a later one was already used, so a code cannot be replayed.
+func (r *TwoFactorRepository) UseStep(id uint, step int64) (bool, error) {
+	result := r.db.Model(&models.TwoFactorAuth{}).
+		Where("id = ? AND last_used_step < ?", id, step).
+		Update("last_used_step", step)
+	return result.RowsAffected == 1, result.Error
+}
+
+// ReplaceRecoveryCodes discards the recovery codes of a user and stores new
+// ones.
+func (r *TwoFactorRepository) ReplaceRecoveryCodes(userID uint, codeHashes []string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&models.RecoveryCode{}).Error; err != nil {
+			return err
+		}
+		codes := make([]models.RecoveryCode, 0, len(codeHashes))
+		for _, hash := range codeHashes {
+			codes = append(codes, models.RecoveryCode{UserID: userID, CodeHash: hash})
+		}
+		if len(codes) == 0 {
+			return nil
+		}
+		return tx.Create(&codes).Error
+	})
+}
+
+// UseRecoveryCode uses up an unused recovery code of a user, reporting
+// whether there was one with that hash.
+func (r *TwoFactorRepository) UseRecoveryCode(userID uint, codeHash string, at time.Time) (bool, error) {
+	result := r.db.Model(&models.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", at)
+	return result.RowsAffected > 0, result.Error
+}
+
+// CountRecoveryCodes returns how many unused recovery codes a user has left
+func (r *TwoFactorRepository) CountRecoveryCodes(userID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.RecoveryCode{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Count(&count).Error
+	return count, err
+}
@@ -0,0 +1,40 @@
+package services
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// AcademicYearRange returns the first and last day of an academic year given
+// as "2024-2025" or "2024-25". The year starts on the first day of startMonth
+// of the leading calendar year and lasts twelve months.
+func AcademicYearRange(academicYear string, startMonth int) (time.Time, time.Time, error) {
+	if len(academicYear) < 4 {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid academic year %q", academicYear)
+	}
+	year, err := strconv.Atoi(academicYear[:4])
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid academic year %q", academicYear)
+	}
+	if startMonth < 1 || startMonth > 12 {
+		startMonth = 1
+	}
+
+	from := time.Date(year, time.Month(startMonth), 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(1, 0, -1)
+	return from, to, nil
+}
+
+// CurrentAcademicYear returns the academic year, formatted as "2024-2025",
+// that contains the given date.
+func CurrentAcademicYear(now time.Time, startMonth int) string {
+	if startMonth < 1 || startMonth > 12 {
+		startMonth = 1
+	}
+	year := now.Year()
+	if int(now.Month()) < startMonth {
+		year--
+	}
+	return fmt.Sprintf("%d-%d", year, year+1)
+}
@@ -0,0 +1,154 @@
+package services
+
+import (
+	"math"
+	"sort"
+	"time"
+	"school-erp-backend/internal/models"
+)
+
+// AttendanceSummary aggregates attendance records. Excused days are reported
+// but left out of the percentage; late counts as attended.
+type AttendanceSummary struct {
+	TotalDays  int     `json:"total_days"`
+	Present    int     `json:"present"`
+	Absent     int     `json:"absent"`
+	Late       int     `json:"late"`
+	Excused    int     `json:"excused"`
+	Percentage float64 `json:"percentage"`
+}
+
+type MonthlyAttendance struct {
+	Month string `json:"month"` // YYYY-MM
+	AttendanceSummary
+}
+
+type AbsenceStreak struct {
+	StudentID     uint      `json:"student_id"`
+	LongestStreak int       `json:"longest_streak"`
+	StreakStart   time.Time `json:"streak_start"`
+	StreakEnd     time.Time `json:"streak_end"`
+	CurrentStreak int       `json:"current_streak"` // consecutive absences up to the latest record
+}
+
+func (s *AttendanceSummary) Add(status string) {
+	s.TotalDays++
+	switch status {
+	case "present":
+		s.Present++
+	case "absent":
+		s.Absent++
+	case "late":
+		s.Late++
+	case "excused":
+		s.Excused++
+	}
+
+	counted := s.Present + s.Late + s.Absent
+	if counted == 0 {
+		s.Percentage = 0
+		return
+	}
+	s.Percentage = math.Round(float64(s.Present+s.Late)/float64(counted)*10000) / 100
+}
+
+// Counted reports whether the summary has any day that contributes to the percentage.
+func (s *AttendanceSummary) Counted() bool {
+	return s.Present+s.Late+s.Absent > 0
+}
+
+func SummarizeAttendance(records []models.Attendance) AttendanceSummary {
+	var summary AttendanceSummary
+	for _, record := range records {
+		summary.Add(record.Status)
+	}
+	return summary
+}
+
+func SummarizeAttendanceByStudent(records []models.Attendance) map[uint]*AttendanceSummary {
+	summaries := make(map[uint]*AttendanceSummary)
+	for _, record := range records {
+		summary, ok := summaries[record.StudentID]
+		if !ok {
+			summary = &AttendanceSummary{}
+			summaries[record.StudentID] = summary
+		}
+		summary.Add(record.Status)
+	}
+	return summaries
+}
+
+func SummarizeAttendanceBySection(records []models.Attendance) map[uint]*AttendanceSummary {
+	summaries := make(map[uint]*AttendanceSummary)
+	for _, record := range records {
+		summary, ok := summaries[record.SectionID]
+		if !ok {
+			summary = &AttendanceSummary{}
+			summaries[record.SectionID] = summary
+		}
+		summary.Add(record.Status)
+	}
+	return summaries
+}
+
+// MonthlyAttendanceBreakdown groups records by calendar month, oldest first.
+func MonthlyAttendanceBreakdown(records []models.Attendance) []MonthlyAttendance {
+	byMonth := make(map[string]*MonthlyAttendance)
+	for _, record := range records {
+		month := record.Date.Format("2006-01")
+		entry, ok := byMonth[month]
+		if !ok {
+			entry = &MonthlyAttendance{Month: month}
+			byMonth[month] = entry
+		}
+		entry.Add(record.Status)
+	}
+
+	breakdown := make([]MonthlyAttendance, 0, len(byMonth))
+	for _, entry := range byMonth {
+		breakdown = append(breakdown, *entry)
+	}
+	sort.Slice(breakdown, func(i, j int) bool {
+		return breakdown[i].Month < breakdown[j].Month
+	})
+	return breakdown
+}
+
+// AbsenceStreaks finds, per student, the longest run of consecutive marked
+// days with status absent. Days without a record (weekends, holidays) do not
+// break a streak; any other status does.
+func AbsenceStreaks(records []models.Attendance) map[uint]*AbsenceStreak {
+	byStudent := make(map[uint][]models.Attendance)
+	for _, record := range records {
+		byStudent[record.StudentID] = append(byStudent[record.StudentID], record)
+	}
+
+	streaks := make(map[uint]*AbsenceStreak)
+	for studentID, studentRecords := range byStudent {
+		sort.Slice(studentRecords, func(i, j int) bool {
+			return studentRecords[i].Date.Before(studentRecords[j].Date)
+		})
+
+		streak := &AbsenceStreak{StudentID: studentID}
+		var runStart time.Time
+		run := 0
+		for _, record := range studentRecords {
+			if record.Status != "absent" {
+				run = 0
+				continue
+			}
+			if run == 0 {
+				runStart = record.Date
+			}
+			run++
+			if run > streak.LongestStreak {
+				streak.LongestStreak = run
+				streak.StreakStart = runStart
+				streak.StreakEnd = record.Date
+			}
+		}
+		streak.CurrentStreak = run
+		streaks[studentID] = streak
+	}
+	return streaks
+}
@@ -0,0 +1,475 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"school-erp-backend/internal/models"
+	"school-erp-backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+// CalendarEventTypes lists the kinds of calendar events. Holidays close the
+// school, see SchoolCalendar.
+var CalendarEventTypes = []string{"holiday", "exam", "event", "meeting"}
+
+// CalendarVisibilities lists who events can be shown to. "admin" events are
+// shown to staff outside the teacher, student and parent portals.
+var CalendarVisibilities = []string{"all", "admin", "teacher", "student", "parent"}
+
+// maxRecurrenceCount bounds COUNT and the occurrences up to UNTIL, so the end
+// of a repeating event can be worked out when it is saved.
+const maxRecurrenceCount = 1000
+
+var (
+	ErrInvalidRecurrence = errors.New("invalid recurrence rule")
+	ErrInvalidEventDates = errors.New("end_date must not be before start_date")
+)
+
+// Recurrence is the part of an iCalendar RRULE that events may use: a
+// DAILY, WEEKLY, MONTHLY or YEARLY frequency with INTERVAL, ending after
+// COUNT occurrences or at UNTIL, and BYDAY weekdays for weekly rules.
+type Recurrence struct {
+	Freq     string
+	Interval int
+	Count    int
+	Until    *time.Time
+	ByDay    []time.Weekday
+}
+
+// CalendarOccurrence is one occurrence of an event in a range, with the
+// times of that occurrence.
+type CalendarOccurrence struct {
+	EventID     uint      `json:"event_id"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	EventType   string    `json:"event_type"`
+	Visibility  string    `json:"visibility"`
+	AllDay      bool      `json:"all_day"`
+	Start       time.Time `json:"start"`
+	End         time.Time `json:"end"` // last day of all-day events
+	Recurring   bool      `json:"recurring"`
+}
+
+// SchoolDay tells whether the school is open on a date, and why not
+type SchoolDay struct {
+	Date      string   `json:"date"`
+	SchoolDay bool     `json:"school_day"`
+	WeeklyOff bool     `json:"weekly_off"`
+	Holidays  []string `json:"holidays"` // titles of the holidays on the date
+}
+
+var rruleWeekdays = map[string]time.Weekday{
+	"MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday, "TH": time.Thursday,
+	"FR": time.Friday, "SA": time.Saturday, "SU": time.Sunday,
+}
+
+// ParseRecurrence reads an RRULE, with or without the "RRULE:" prefix
+func ParseRecurrence(rule string) (*Recurrence, error) {
+	rule = strings.TrimPrefix(strings.TrimSpace(rule), "RRULE:")
+	recurrence := &Recurrence{Interval: 1}
+	for _, part := range strings.Split(rule, ";") {
+		if part == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidRecurrence, part)
+		}
+		switch strings.ToUpper(name) {
+		case "FREQ":
+			recurrence.Freq = strings.ToUpper(value)
+		case "INTERVAL":
+			interval, err := strconv.Atoi(value)
+			if err != nil || interval < 1 {
+				return nil, fmt.Errorf("%w: INTERVAL must be a positive number", ErrInvalidRecurrence)
+			}
+			recurrence.Interval = interval
+		case "COUNT":
+			count, err := strconv.Atoi(value)
+			if err != nil || count < 1 || count > maxRecurrenceCount {
+				return nil, fmt.Errorf("%w: COUNT must be between 1 and %d", ErrInvalidRecurrence, maxRecurrenceCount)
+			}
+			recurrence.Count = count
+		case "UNTIL":
+			until, err := parseRecurrenceUntil(value)
+			if err != nil {
+				return nil, fmt.Errorf("%w: UNTIL must be YYYYMMDD or YYYYMMDDTHHMMSSZ", ErrInvalidRecurrence)
+			}
+			recurrence.Until = &until
+		case "BYDAY":
+			for _, day := range strings.Split(strings.ToUpper(value), ",") {
+				weekday, ok := rruleWeekdays[day]
+				if !ok {
+					return nil, fmt.Errorf("%w: unknown BYDAY %q", ErrInvalidRecurrence, day)
+				}
+				recurrence.ByDay = append(recurrence.ByDay, weekday)
+			}
+		case "WKST":
+			if strings.ToUpper(value) != "MO" {
+				return nil, fmt.Errorf("%w: only WKST=MO is supported", ErrInvalidRecurrence)
+			}
+		default:
+			return nil, fmt.Errorf("%w: %s is not supported", ErrInvalidRecurrence, strings.ToUpper(name))
+		}
+	}
+
+	switch recurrence.Freq {
+	case "DAILY", "WEEKLY", "MONTHLY", "YEARLY":
+	default:
+		return nil, fmt.Errorf("%w: FREQ must be DAILY, WEEKLY, MONTHLY or YEARLY", ErrInvalidRecurrence)
+	}
+	if recurrence.Count > 0 && recurrence.Until != nil {
+		return nil, fmt.Errorf("%w: COUNT and UNTIL cannot be combined", ErrInvalidRecurrence)
+	}
+	if len(recurrence.ByDay) > 0 && recurrence.Freq != "WEEKLY" {
+		return nil, fmt.Errorf("%w: BYDAY is only supported with FREQ=WEEKLY", ErrInvalidRecurrence)
+	}
+	sort.Slice(recurrence.ByDay, func(i, j int) bool {
+		return weekdayIndex(recurrence.ByDay[i]) < weekdayIndex(recurrence.ByDay[j])
+	})
+	return recurrence, nil
+}
+
+// Rule writes the recurrence back as an RRULE. UNTIL is a date for all-day
+// events and a UTC time otherwise, as iCalendar requires it to match the
+// start of the event.
+func (r *Recurrence) Rule(allDay bool) string {
+	parts := []string{"FREQ=" + r.Freq}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if r.Until != nil {
+		if allDay {
+			parts = append(parts, "UNTIL="+r.Until.Format("20060102"))
+		} else {
+			parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+		}
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, len(r.ByDay))
+		for i, weekday := range r.ByDay {
+			days[i] = strings.ToUpper(weekday.String()[:2])
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	return strings.Join(parts, ";")
+}
+
+// starts calls visit with the start of every occurrence beginning at first,
+// in order, until visit returns false or the rule ends.
+func (r *Recurrence) starts(first time.Time, visit func(time.Time) bool) {
+	count := 0
+	emit := func(start time.Time) bool {
+		if r.Until != nil && start.After(*r.Until) {
+			return false
+		}
+		if r.Count > 0 && count >= r.Count {
+			return false
+		}
+		count++
+		return visit(start)
+	}
+
+	on := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, first.Hour(), first.Minute(), first.Second(), 0, first.Location())
+	}
+
+	switch r.Freq {
+	case "DAILY":
+		for n := 0; ; n++ {
+			if !emit(first.AddDate(0, 0, n*r.Interval)) {
+				return
+			}
+		}
+	case "WEEKLY":
+		if len(r.ByDay) == 0 {
+			for n := 0; ; n++ {
+				if !emit(first.AddDate(0, 0, 7*n*r.Interval)) {
+					return
+				}
+			}
+		}
+		weekStart := first.AddDate(0, 0, -weekdayIndex(first.Weekday()))
+		for n := 0; ; n++ {
+			week := weekStart.AddDate(0, 0, 7*n*r.Interval)
+			for _, weekday := range r.ByDay {
+				start := week.AddDate(0, 0, weekdayIndex(weekday))
+				if start.Before(first) {
+					continue
+				}
+				if !emit(start) {
+					return
+				}
+			}
+		}
+	case "MONTHLY":
+		for n := 0; ; n++ {
+			year, month := first.Year(), first.Month()+time.Month(n*r.Interval)
+			// months without the day of the first occurrence are skipped
+			if first.Day() > daysIn(year, month) {
+				continue
+			}
+			if !emit(on(year, month, first.Day())) {
+				return
+			}
+		}
+	case "YEARLY":
+		for n := 0; ; n++ {
+			year := first.Year() + n*r.Interval
+			if first.Day() > daysIn(year, first.Month()) {
+				continue
+			}
+			if !emit(on(year, first.Month(), first.Day())) {
+				return
+			}
+		}
+	}
+}
+
+// PrepareCalendarEvent checks the dates and recurrence of an event and works
+// out when its last occurrence ends. All-day events keep only the dates.
+func PrepareCalendarEvent(event *models.CalendarEvent) error {
+	if event.AllDay {
+		event.StartDate = dateOnly(event.StartDate)
+		event.EndDate = dateOnly(event.EndDate)
+	}
+	if event.EndDate.IsZero() {
+		event.EndDate = event.StartDate
+	}
+	if event.EndDate.Before(event.StartDate) {
+		return ErrInvalidEventDates
+	}
+
+	if strings.TrimSpace(event.Recurrence) == "" {
+		event.Recurrence = ""
+		end := event.EndDate
+		event.OccursUntil = &end
+		return nil
+	}
+
+	recurrence, err := ParseRecurrence(event.Recurrence)
+	if err != nil {
+		return err
+	}
+	if len(recurrence.ByDay) > 0 && !containsWeekday(recurrence.ByDay, event.StartDate.Weekday()) {
+		// iCalendar counts the start as an occurrence whatever its weekday
+		return fmt.Errorf("%w: start_date must fall on one of the BYDAY weekdays", ErrInvalidRecurrence)
+	}
+	if recurrence.Until != nil && event.AllDay {
+		until := dateOnly(*recurrence.Until)
+		recurrence.Until = &until
+	}
+	event.Recurrence = recurrence.Rule(event.AllDay)
+	event.OccursUntil = nil
+	if recurrence.Count == 0 && recurrence.Until == nil {
+		return nil
+	}
+
+	var last time.Time
+	occurrences := 0
+	recurrence.starts(event.StartDate, func(start time.Time) bool {
+		last = start
+		occurrences++
+		return occurrences <= maxRecurrenceCount
+	})
+	if occurrences > maxRecurrenceCount {
+		return fmt.Errorf("%w: more than %d occurrences", ErrInvalidRecurrence, maxRecurrenceCount)
+	}
+	if occurrences == 0 {
+		return fmt.Errorf("%w: UNTIL is before start_date", ErrInvalidRecurrence)
+	}
+	end := last.Add(event.EndDate.Sub(event.StartDate))
+	event.OccursUntil = &end
+	return nil
+}
+
+// EventOccurrences expands events into their occurrences that overlap the
+// days from to to, both included, sorted by start.
+func EventOccurrences(events []models.CalendarEvent, from, to time.Time) []CalendarOccurrence {
+	from = dateOnly(from)
+	until := dateOnly(to).AddDate(0, 0, 1)
+	occurrences := []CalendarOccurrence{}
+	for _, event := range events {
+		duration := event.EndDate.Sub(event.StartDate)
+		add := func(start time.Time) bool {
+			if !start.Before(until) {
+				return false
+			}
+			end := start.Add(duration)
+			if !end.Before(from) {
+				occurrences = append(occurrences, CalendarOccurrence{
+					EventID:     event.ID,
+					Title:       event.Title,
+					Description: event.Description,
+					EventType:   event.EventType,
+					Visibility:  event.Visibility,
+					AllDay:      event.AllDay,
+					Start:       start,
+					End:         end,
+					Recurring:   event.Recurrence != "",
+				})
+			}
+			return true
+		}
+
+		if event.Recurrence == "" {
+			add(event.StartDate)
+			continue
+		}
+		recurrence, err := ParseRecurrence(event.Recurrence)
+		if err != nil {
+			add(event.StartDate)
+			continue
+		}
+		recurrence.starts(event.StartDate, add)
+	}
+
+	sort.SliceStable(occurrences, func(i, j int) bool {
+		return occurrences[i].Start.Before(occurrences[j].Start)
+	})
+	return occurrences
+}
+
+// VisibleCalendar returns the event visibilities shown to a user with the
+// given roles, or nil when every event is. Parents see the events meant for
+// students as well as their own.
+func VisibleCalendar(roles []string) []string {
+	visibilities := []string{"all"}
+	add := func(visibility string) {
+		for _, existing := range visibilities {
+			if existing == visibility {
+				return
+			}
+		}
+		visibilities = append(visibilities, visibility)
+	}
+	for _, role := range roles {
+		switch role {
+		case RoleAdmin:
+			return nil
+		case "teacher", "student":
+			add(role)
+		case RoleParent:
+			add(RoleParent)
+			add("student")
+		default:
+			add("admin")
+		}
+	}
+	return visibilities
+}
+
+// SchoolCalendar tells school days from days off: the weekly off days and
+// the days covered by holiday events of any visibility.
+type SchoolCalendar struct {
+	eventRepo *repository.CalendarEventRepository
+	weeklyOff map[time.Weekday]bool
+}
+
+// NewSchoolCalendar returns the calendar of a school closed on the named
+// weekdays, e.g. "Sunday". Unknown names are ignored.
+func NewSchoolCalendar(db *gorm.DB, weeklyOffDays []string) *SchoolCalendar {
+	weeklyOff := make(map[time.Weekday]bool, len(weeklyOffDays))
+	for _, name := range weeklyOffDays {
+		for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+			if strings.EqualFold(name, weekday.String()) {
+				weeklyOff[weekday] = true
+			}
+		}
+	}
+	return &SchoolCalendar{eventRepo: repository.NewCalendarEventRepository(db), weeklyOff: weeklyOff}
+}
+
+// IsSchoolDay reports whether the school is open on the date
+func (s *SchoolCalendar) IsSchoolDay(date time.Time) (bool, error) {
+	days, err := s.Days(date, date)
+	if err != nil {
+		return false, err
+	}
+	return days[0].SchoolDay, nil
+}
+
+// SchoolDays returns the dates from from to to, both included, on which the
+// school is open.
+func (s *SchoolCalendar) SchoolDays(from, to time.Time) ([]time.Time, error) {
+	days, err := s.Days(from, to)
+	if err != nil {
+		return nil, err
+	}
+	open := []time.Time{}
+	for _, day := range days {
+		if day.SchoolDay {
+			date, _ := time.Parse("2006-01-02", day.Date)
+			open = append(open, date)
+		}
+	}
+	return open, nil
+}
+
+// Days tells for every date from from to to, both included, whether the
+// school is open.
+func (s *SchoolCalendar) Days(from, to time.Time) ([]SchoolDay, error) {
+	from, to = dateOnly(from), dateOnly(to)
+	holidays, err := s.eventRepo.FindAll(models.CalendarEvent{EventType: "holiday"}, nil, from, to.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+
+	closed := make(map[string][]string)
+	for _, occurrence := range EventOccurrences(holidays, from, to) {
+		for day := dateOnly(occurrence.Start); !day.After(dateOnly(occurrence.End)); day = day.AddDate(0, 0, 1) {
+			key := day.Format("2006-01-02")
+			closed[key] = append(closed[key], occurrence.Title)
+		}
+	}
+
+	days := []SchoolDay{}
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		key := day.Format("2006-01-02")
+		schoolDay := SchoolDay{Date: key, WeeklyOff: s.weeklyOff[day.Weekday()], Holidays: closed[key]}
+		if schoolDay.Holidays == nil {
+			schoolDay.Holidays = []string{}
+		}
+		schoolDay.SchoolDay = !schoolDay.WeeklyOff && len(schoolDay.Holidays) == 0
+		days = append(days, schoolDay)
+	}
+	return days, nil
+}
+
+// parseRecurrenceUntil reads an UNTIL date or UTC time
+func parseRecurrenceUntil(value string) (time.Time, error) {
+	if until, err := time.Parse("20060102T150405Z", value); err == nil {
+		return until, nil
+	}
+	return time.Parse("20060102", value)
+}
+
+// dateOnly drops the time of day, keeping the date as written
+func dateOnly(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// weekdayIndex numbers weekdays from Monday, the start of iCalendar weeks
+func weekdayIndex(weekday time.Weekday) int {
+	return (int(weekday) + 6) % 7
+}
+
+func containsWeekday(weekdays []time.Weekday, weekday time.Weekday) bool {
+	for _, candidate := range weekdays {
+		if candidate == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
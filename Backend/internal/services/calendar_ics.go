@@ -0,0 +1,89 @@
+package services
+
+import (
+	"strconv"
+	"strings"
+	"time"
+	"school-erp-backend/internal/models"
+)
+
+// icsLineLength is the longest content line iCalendar allows, in octets
+const icsLineLength = 75
+
+// CalendarICS renders events as an iCalendar file (RFC 5545). Repeating
+// events are written once with their RRULE, so calendar apps expand them.
+func CalendarICS(name string, events []models.CalendarEvent, now time.Time) []byte {
+	var ics strings.Builder
+	line := func(content string) {
+		ics.WriteString(foldICSLine(content))
+		ics.WriteString("\r\n")
+	}
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//School ERP//Calendar//EN")
+	line("CALSCALE:GREGORIAN")
+	line("METHOD:PUBLISH")
+	line("X-WR-CALNAME:" + escapeICSText(name))
+	for _, event := range events {
+		line("BEGIN:VEVENT")
+		line("UID:calendar-event-" + strconv.FormatUint(uint64(event.ID), 10) + "@school-erp")
+		line("DTSTAMP:" + now.UTC().Format("20060102T150405Z"))
+		if event.AllDay {
+			// DTEND of all-day events is the day after the last one
+			line("DTSTART;VALUE=DATE:" + event.StartDate.Format("20060102"))
+			line("DTEND;VALUE=DATE:" + event.EndDate.AddDate(0, 0, 1).Format("20060102"))
+		} else {
+			line("DTSTART:" + event.StartDate.UTC().Format("20060102T150405Z"))
+			line("DTEND:" + event.EndDate.UTC().Format("20060102T150405Z"))
+		}
+		if event.Recurrence != "" {
+			line("RRULE:" + event.Recurrence)
+		}
+		line("SUMMARY:" + escapeICSText(event.Title))
+		if event.Description != "" {
+			line("DESCRIPTION:" + escapeICSText(event.Description))
+		}
+		line("CATEGORIES:" + escapeICSText(strings.ToUpper(event.EventType)))
+		if event.EventType == "holiday" {
+			line("TRANSP:TRANSPARENT")
+		}
+		line("LAST-MODIFIED:" + event.UpdatedAt.UTC().Format("20060102T150405Z"))
+		line("END:VEVENT")
+	}
+	line("END:VCALENDAR")
+	return []byte(ics.String())
+}
+
+// escapeICSText escapes the characters with a meaning in iCalendar text
+func escapeICSText(text string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", `\n`,
+	).Replace(text)
+}
+
+// foldICSLine breaks a content line into lines of at most 75 octets, each
+// continuation starting with a space. Lines are only broken between UTF-8
+// characters.
+func foldICSLine(content string) string {
+	if len(content) <= icsLineLength {
+		return content
+	}
+	var folded strings.Builder
+	length := 0
+	for _, r := range content {
+		size := len(string(r))
+		if length+size > icsLineLength {
+			folded.WriteString("\r\n ")
+			length = 1
+		}
+		folded.WriteRune(r)
+		length += size
+	}
+	return folded.String()
+}
@@ -0,0 +1,251 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"school-erp-backend/internal/models"
+)
+
+func ymd(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestParseRecurrence(t *testing.T) {
+	tests := []struct {
+		rule string
+		want string // the rule written back for a timed event
+	}{
+		{"FREQ=DAILY", "FREQ=DAILY"},
+		{"RRULE:FREQ=weekly;INTERVAL=2;BYDAY=fr,mo", "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR"},
+		{"FREQ=WEEKLY;BYDAY=SU,MO;WKST=MO", "FREQ=WEEKLY;BYDAY=MO,SU"},
+		{"FREQ=MONTHLY;COUNT=12;", "FREQ=MONTHLY;COUNT=12"},
+		{"FREQ=YEARLY;INTERVAL=1;UNTIL=20300101", "FREQ=YEARLY;UNTIL=20300101T000000Z"},
+		{"FREQ=DAILY;UNTIL=20240110T083000Z", "FREQ=DAILY;UNTIL=20240110T083000Z"},
+	}
+	for _, tt := range tests {
+		recurrence, err := ParseRecurrence(tt.rule)
+		if err != nil {
+			t.Errorf("ParseRecurrence(%q): %v", tt.rule, err)
+			continue
+		}
+		if got := recurrence.Rule(false); got != tt.want {
+			t.Errorf("ParseRecurrence(%q).Rule() = %q, want %q", tt.rule, got, tt.want)
+		}
+	}
+
+	invalid := []string{
+		"",
+		"FREQ=HOURLY",
+		"FREQ=DAILY;INTERVAL=0",
+		"FREQ=DAILY;COUNT=0",
+		"FREQ=DAILY;COUNT=1001",
+		"FREQ=DAILY;COUNT=2;UNTIL=20240101",
+		"FREQ=DAILY;UNTIL=2024-01-01",
+		"FREQ=MONTHLY;BYDAY=MO",
+		"FREQ=WEEKLY;BYDAY=XX",
+		"FREQ=WEEKLY;WKST=SU",
+		"FREQ=MONTHLY;BYMONTHDAY=1",
+		"FREQ",
+	}
+	for _, rule := range invalid {
+		if _, err := ParseRecurrence(rule); !errors.Is(err, ErrInvalidRecurrence) {
+			t.Errorf("ParseRecurrence(%q) error = %v, want ErrInvalidRecurrence", rule, err)
+		}
+	}
+}
+
+func TestRecurrenceStarts(t *testing.T) {
+	tests := []struct {
+		name  string
+		rule  string
+		first time.Time
+		want  []time.Time
+	}{
+		{
+			name:  "daily with interval until a date",
+			rule:  "FREQ=DAILY;INTERVAL=3;UNTIL=20240110",
+			first: ymd(2024, 1, 1),
+			want:  []time.Time{ymd(2024, 1, 1), ymd(2024, 1, 4), ymd(2024, 1, 7), ymd(2024, 1, 10)},
+		},
+		{
+			name:  "monthly on the 31st skips shorter months",
+			rule:  "FREQ=MONTHLY;COUNT=5",
+			first: ymd(2024, 1, 31),
+			want:  []time.Time{ymd(2024, 1, 31), ymd(2024, 3, 31), ymd(2024, 5, 31), ymd(2024, 7, 31), ymd(2024, 8, 31)},
+		},
+		{
+			name:  "monthly with interval across the year end",
+			rule:  "FREQ=MONTHLY;INTERVAL=2;COUNT=4",
+			first: ymd(2024, 8, 31),
+			want:  []time.Time{ymd(2024, 8, 31), ymd(2024, 10, 31), ymd(2024, 12, 31), ymd(2025, 8, 31)},
+		},
+		{
+			name:  "monthly on the 30th skips February only",
+			rule:  "FREQ=MONTHLY;COUNT=3",
+			first: ymd(2025, 1, 30),
+			want:  []time.Time{ymd(2025, 1, 30), ymd(2025, 3, 30), ymd(2025, 4, 30)},
+		},
+		{
+			name:  "yearly on February 29 skips common years",
+			rule:  "FREQ=YEARLY;COUNT=3",
+			first: ymd(2024, 2, 29),
+			want:  []time.Time{ymd(2024, 2, 29), ymd(2028, 2, 29), ymd(2032, 2, 29)},
+		},
+		{
+			name:  "yearly on February 29 until a date",
+			rule:  "FREQ=YEARLY;UNTIL=20310101",
+			first: ymd(2024, 2, 29),
+			want:  []time.Time{ymd(2024, 2, 29), ymd(2028, 2, 29)},
+		},
+		{
+			name:  "weekly BYDAY before the start in the first week",
+			rule:  "FREQ=WEEKLY;BYDAY=MO,WE,FR;COUNT=4",
+			first: ymd(2024, 1, 3), // a Wednesday
+			want:  []time.Time{ymd(2024, 1, 3), ymd(2024, 1, 5), ymd(2024, 1, 8), ymd(2024, 1, 10)},
+		},
+		{
+			name:  "fortnightly BYDAY",
+			rule:  "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,TH;COUNT=3",
+			first: ymd(2024, 1, 4), // a Thursday
+			want:  []time.Time{ymd(2024, 1, 4), ymd(2024, 1, 16), ymd(2024, 1, 18)},
+		},
+		{
+			name:  "weekly BYDAY with Sunday ending the week",
+			rule:  "FREQ=WEEKLY;BYDAY=SU,SA;COUNT=3",
+			first: ymd(2024, 1, 7), // a Sunday
+			want:  []time.Time{ymd(2024, 1, 7), ymd(2024, 1, 13), ymd(2024, 1, 14)},
+		},
+		{
+			name:  "weekly without BYDAY",
+			rule:  "FREQ=WEEKLY;UNTIL=20240115",
+			first: ymd(2024, 1, 1),
+			want:  []time.Time{ymd(2024, 1, 1), ymd(2024, 1, 8), ymd(2024, 1, 15)},
+		},
+		{
+			name:  "time of day kept",
+			rule:  "FREQ=MONTHLY;COUNT=2",
+			first: time.Date(2024, 1, 31, 9, 30, 0, 0, time.UTC),
+			want:  []time.Time{time.Date(2024, 1, 31, 9, 30, 0, 0, time.UTC), time.Date(2024, 3, 31, 9, 30, 0, 0, time.UTC)},
+		},
+		{
+			name:  "until before the start",
+			rule:  "FREQ=DAILY;UNTIL=20231231",
+			first: ymd(2024, 1, 1),
+			want:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recurrence, err := ParseRecurrence(tt.rule)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got []time.Time
+			recurrence.starts(tt.first, func(start time.Time) bool {
+				got = append(got, start)
+				return len(got) < 20
+			})
+			if len(got) != len(tt.want) {
+				t.Fatalf("starts = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if !got[i].Equal(tt.want[i]) {
+					t.Errorf("occurrence %d = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestRecurrenceStartsStopsWhenVisitDoes(t *testing.T) {
+	recurrence, err := ParseRecurrence("FREQ=DAILY")
+	if err != nil {
+		t.Fatal(err)
+	}
+	visited := 0
+	recurrence.starts(ymd(2024, 1, 1), func(time.Time) bool {
+		visited++
+		return visited < 3
+	})
+	if visited != 3 {
+		t.Errorf("visited %d occurrences of an endless rule, want 3", visited)
+	}
+}
+
+func TestPrepareCalendarEvent(t *testing.T) {
+	tests := []struct {
+		name        string
+		event       models.CalendarEvent
+		rule        string
+		occursUntil *time.Time
+		err         error
+	}{
+		{
+			name:        "single event",
+			event:       models.CalendarEvent{AllDay: true, StartDate: ymd(2024, 3, 1), EndDate: ymd(2024, 3, 2)},
+			occursUntil: ptrTime(ymd(2024, 3, 2)),
+		},
+		{
+			name:        "last occurrence of a counted rule",
+			event:       models.CalendarEvent{AllDay: true, StartDate: ymd(2024, 1, 31), EndDate: ymd(2024, 2, 1), Recurrence: "FREQ=MONTHLY;COUNT=3"},
+			rule:        "FREQ=MONTHLY;COUNT=3",
+			occursUntil: ptrTime(ymd(2024, 6, 1)),
+		},
+		{
+			name:  "endless rule",
+			event: models.CalendarEvent{AllDay: true, StartDate: ymd(2024, 1, 1), Recurrence: "RRULE:FREQ=WEEKLY;BYDAY=MO"},
+			rule:  "FREQ=WEEKLY;BYDAY=MO",
+		},
+		{
+			name:  "start outside BYDAY",
+			event: models.CalendarEvent{AllDay: true, StartDate: ymd(2024, 1, 2), Recurrence: "FREQ=WEEKLY;BYDAY=MO"},
+			err:   ErrInvalidRecurrence,
+		},
+		{
+			name:  "until before the start",
+			event: models.CalendarEvent{AllDay: true, StartDate: ymd(2024, 1, 2), Recurrence: "FREQ=DAILY;UNTIL=20240101"},
+			err:   ErrInvalidRecurrence,
+		},
+		{
+			name:  "too many occurrences",
+			event: models.CalendarEvent{AllDay: true, StartDate: ymd(2024, 1, 1), Recurrence: "FREQ=DAILY;UNTIL=20300101"},
+			err:   ErrInvalidRecurrence,
+		},
+		{
+			name:  "end before start",
+			event: models.CalendarEvent{AllDay: true, StartDate: ymd(2024, 1, 2), EndDate: ymd(2024, 1, 1)},
+			err:   ErrInvalidEventDates,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := tt.event
+			err := PrepareCalendarEvent(&event)
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Fatalf("error = %v, want %v", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if event.Recurrence != tt.rule {
+				t.Errorf("recurrence = %q, want %q", event.Recurrence, tt.rule)
+			}
+			switch {
+			case tt.occursUntil == nil && event.OccursUntil != nil:
+				t.Errorf("occurs until %v, want no end", *event.OccursUntil)
+			case tt.occursUntil != nil && (event.OccursUntil == nil || !event.OccursUntil.Equal(*tt.occursUntil)):
+				t.Errorf("occurs until %v, want %v", event.OccursUntil, *tt.occursUntil)
+			}
+		})
+	}
+}
+
+func ptrTime(t time.Time) *time.Time {
+	return &t
+}
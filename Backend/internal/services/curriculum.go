@@ -0,0 +1,78 @@
+package services
+
+import (
+	"errors"
+	"school-erp-backend/internal/models"
+	"school-erp-backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+var ErrNotInCurriculum = errors.New("subject is not in the curriculum of the class for this academic year")
+
+// CurriculumEntry returns the curriculum entry of a subject in a class for an
+// academic year. Classes without a curriculum for the year accept every
+// subject, in which case the entry is nil. ErrNotInCurriculum is returned
+// when the class has a curriculum that lacks the subject.
+func CurriculumEntry(repo *repository.CurriculumRepository, classID, subjectID uint, academicYear string) (*models.CurriculumSubject, error) {
+	entry, err := repo.FindEntry(classID, subjectID, academicYear)
+	if err == nil {
+		return entry, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	defined, err := repo.HasCurriculum(classID, academicYear)
+	if err != nil {
+		return nil, err
+	}
+	if defined {
+		return nil, ErrNotInCurriculum
+	}
+	return nil, nil
+}
+
+// CopyCurriculum copies the curriculum of one academic year into another,
+// for one class or, when classID is 0, for every class. Subjects already in
+// the target year are left unchanged and counted as skipped.
+func CopyCurriculum(repo *repository.CurriculumRepository, fromYear, toYear string, classID uint) (copied, skipped int, err error) {
+	source, err := repo.FindByYear(fromYear)
+	if err != nil {
+		return 0, 0, err
+	}
+	target, err := repo.FindByYear(toYear)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	type key struct{ classID, subjectID uint }
+	existing := make(map[key]bool, len(target))
+	for _, entry := range target {
+		existing[key{entry.ClassID, entry.SubjectID}] = true
+	}
+
+	var entries []models.CurriculumSubject
+	for _, entry := range source {
+		if classID != 0 && entry.ClassID != classID {
+			continue
+		}
+		if existing[key{entry.ClassID, entry.SubjectID}] {
+			skipped++
+			continue
+		}
+		entries = append(entries, models.CurriculumSubject{
+			ClassID:       entry.ClassID,
+			SubjectID:     entry.SubjectID,
+			AcademicYear:  toYear,
+			IsElective:    entry.IsElective,
+			WeeklyPeriods: entry.WeeklyPeriods,
+			MaxMarks:      entry.MaxMarks,
+			Capacity:      entry.Capacity,
+		})
+	}
+
+	if err := repo.CreateBatch(entries); err != nil {
+		return 0, 0, err
+	}
+	return len(entries), skipped, nil
+}
@@ -0,0 +1,100 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"school-erp-backend/internal/models"
+	"school-erp-backend/internal/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrNotElective = errors.New("subject is not an elective of the class for this academic year")
+
+// ElectiveFullError is returned when enrolling students would exceed the
+// capacity of an elective.
+type ElectiveFullError struct {
+	Capacity  int
+	Available int
+}
+
+func (e *ElectiveFullError) Error() string {
+	return fmt.Sprintf("elective has %d of %d seats available", e.Available, e.Capacity)
+}
+
+// EnrollStudents enrolls students in an elective of their class. Students
+// already enrolled are skipped. The capacity is checked with the curriculum
+// entry locked, and a batch that does not fit is rejected as a whole.
+func EnrollStudents(db *gorm.DB, elective *models.CurriculumSubject, studentIDs []uint, enrolledBy uint) (enrolled []models.ElectiveEnrollment, skipped int, err error) {
+	if !elective.IsElective {
+		return nil, 0, ErrNotElective
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		var entry models.CurriculumSubject
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&entry, elective.ID).Error; err != nil {
+			return err
+		}
+
+		var existing []models.ElectiveEnrollment
+		err := tx.Where("class_id = ? AND subject_id = ? AND academic_year = ?", entry.ClassID, entry.SubjectID, entry.AcademicYear).
+			Find(&existing).Error
+		if err != nil {
+			return err
+		}
+		taken := make(map[uint]bool, len(existing))
+		for _, enrollment := range existing {
+			taken[enrollment.StudentID] = true
+		}
+
+		for _, studentID := range studentIDs {
+			if taken[studentID] {
+				skipped++
+				continue
+			}
+			taken[studentID] = true
+			enrolled = append(enrolled, models.ElectiveEnrollment{
+				StudentID:    studentID,
+				ClassID:      entry.ClassID,
+				SubjectID:    entry.SubjectID,
+				AcademicYear: entry.AcademicYear,
+				EnrolledBy:   enrolledBy,
+			})
+		}
+		if entry.Capacity > 0 && len(existing)+len(enrolled) > entry.Capacity {
+			available := entry.Capacity - len(existing)
+			if available < 0 {
+				available = 0
+			}
+			return &ElectiveFullError{Capacity: entry.Capacity, Available: available}
+		}
+
+		if len(enrolled) == 0 {
+			return nil
+		}
+		return tx.Omit(clause.Associations).Create(&enrolled).Error
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return enrolled, skipped, nil
+}
+
+// SubjectRoster returns the students of a class who take a subject, limited
+// to one section unless sectionID is 0. Electives are taken by the enrolled
+// students only, every other subject by the whole class.
+func SubjectRoster(db *gorm.DB, classID, sectionID, subjectID uint, academicYear string) ([]models.Student, error) {
+	entry, err := repository.NewCurriculumRepository(db).FindEntry(classID, subjectID, academicYear)
+	if err == nil && entry.IsElective {
+		return repository.NewElectiveRepository(db).EnrolledStudents(classID, sectionID, subjectID, academicYear)
+	}
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	studentRepo := repository.NewStudentRepository(db)
+	if sectionID != 0 {
+		return studentRepo.FindByClassAndSection(classID, sectionID)
+	}
+	return studentRepo.FindByClass(classID)
+}
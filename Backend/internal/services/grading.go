@@ -0,0 +1,156 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"school-erp-backend/internal/models"
+	"school-erp-backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+// Percentage returns obtained/total as a percentage rounded to two decimals.
+func Percentage(obtained, total float64) float64 {
+	if total <= 0 {
+		return 0
+	}
+	return math.Round(obtained/total*10000) / 100
+}
+
+// DefaultGradeBands is used for classes that have no grading scale assigned
+// for the academic year.
+func DefaultGradeBands() []models.GradeBand {
+	return []models.GradeBand{
+		{MinPercentage: 90, MaxPercentage: 100, Grade: "A+", GradePoint: 10},
+		{MinPercentage: 80, MaxPercentage: 89.99, Grade: "A", GradePoint: 9},
+		{MinPercentage: 70, MaxPercentage: 79.99, Grade: "B+", GradePoint: 8},
+		{MinPercentage: 60, MaxPercentage: 69.99, Grade: "B", GradePoint: 7},
+		{MinPercentage: 50, MaxPercentage: 59.99, Grade: "C", GradePoint: 6},
+		{MinPercentage: 40, MaxPercentage: 49.99, Grade: "D", GradePoint: 5},
+		{MinPercentage: 0, MaxPercentage: 39.99, Grade: "F", GradePoint: 0},
+	}
+}
+
+// ValidateGradeBands checks that bands lie within 0-100, do not overlap and
+// that the lowest band starts at 0 so every percentage maps to a grade.
+func ValidateGradeBands(bands []models.GradeBand) error {
+	if len(bands) == 0 {
+		return errors.New("at least one grade band is required")
+	}
+
+	sorted := make([]models.GradeBand, len(bands))
+	copy(sorted, bands)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].MinPercentage < sorted[j].MinPercentage
+	})
+
+	for i, band := range sorted {
+		if band.Grade == "" {
+			return errors.New("every grade band needs a grade")
+		}
+		if band.MinPercentage < 0 || band.MaxPercentage > 100 || band.MinPercentage > band.MaxPercentage {
+			return fmt.Errorf("grade %s has an invalid range %.2f-%.2f", band.Grade, band.MinPercentage, band.MaxPercentage)
+		}
+		if i > 0 && band.MinPercentage <= sorted[i-1].MaxPercentage {
+			return fmt.Errorf("grade %s overlaps grade %s", band.Grade, sorted[i-1].Grade)
+		}
+	}
+
+	if sorted[0].MinPercentage != 0 {
+		return errors.New("the lowest grade band must start at 0")
+	}
+	return nil
+}
+
+// GradeFor returns the grade and grade point of the band with the highest
+// minimum percentage not above the given percentage.
+func GradeFor(bands []models.GradeBand, percentage float64) (string, float64) {
+	var match *models.GradeBand
+	for i := range bands {
+		band := &bands[i]
+		if band.MinPercentage <= percentage && (match == nil || band.MinPercentage > match.MinPercentage) {
+			match = band
+		}
+	}
+	if match == nil {
+		return "", 0
+	}
+	return match.Grade, match.GradePoint
+}
+
+// GradeBandsFor returns the bands of the scale assigned to the class for the
+// academic year, falling back to DefaultGradeBands.
+func GradeBandsFor(scaleRepo *repository.GradingScaleRepository, classID uint, academicYear string) ([]models.GradeBand, error) {
+	assignment, err := scaleRepo.FindAssignment(classID, academicYear)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return DefaultGradeBands(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if assignment.GradingScale.Status != "active" || len(assignment.GradingScale.Bands) == 0 {
+		return DefaultGradeBands(), nil
+	}
+	return assignment.GradingScale.Bands, nil
+}
+
+type GradeRecomputeResult struct {
+	ClassID          uint   `json:"class_id"`
+	AcademicYear     string `json:"academic_year"`
+	Updated          int    `json:"updated"`
+	Unchanged        int    `json:"unchanged"`
+	SkippedPublished int    `json:"skipped_published"`
+}
+
+// RecomputeGrades regrades every mark of a class and academic year with the
+// currently active scale. Marks of published exams are left alone unless
+// includePublished is set.
+func RecomputeGrades(db *gorm.DB, classID uint, academicYear string, includePublished bool) (*GradeRecomputeResult, error) {
+	bands, err := GradeBandsFor(repository.NewGradingScaleRepository(db), classID, academicYear)
+	if err != nil {
+		return nil, err
+	}
+
+	var exams []models.Exam
+	if err := db.Where("class_id = ? AND academic_year = ?", classID, academicYear).Find(&exams).Error; err != nil {
+		return nil, err
+	}
+
+	result := &GradeRecomputeResult{ClassID: classID, AcademicYear: academicYear}
+	err = db.Transaction(func(tx *gorm.DB) error {
+		for _, exam := range exams {
+			var marks []models.Mark
+			if err := tx.Where("exam_id = ?", exam.ID).Find(&marks).Error; err != nil {
+				return err
+			}
+			if exam.Status == "published" && !includePublished {
+				result.SkippedPublished += len(marks)
+				continue
+			}
+
+			for _, mark := range marks {
+				percentage := Percentage(mark.MarksObtained, mark.TotalMarks)
+				grade, gradePoint := GradeFor(bands, percentage)
+				if mark.Percentage == percentage && mark.Grade == grade && mark.GradePoint == gradePoint {
+					result.Unchanged++
+					continue
+				}
+				err := tx.Model(&models.Mark{}).Where("id = ?", mark.ID).Updates(map[string]interface{}{
+					"percentage":  percentage,
+					"grade":       grade,
+					"grade_point": gradePoint,
+				}).Error
+				if err != nil {
+					return err
+				}
+				result.Updated++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
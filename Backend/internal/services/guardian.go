@@ -0,0 +1,187 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"school-erp-backend/internal/models"
+	"school-erp-backend/internal/repository"
+	"school-erp-backend/pkg/utils"
+	"gorm.io/gorm"
+)
+
+// RoleParent is the primary role of guardian users
+const RoleParent = "parent"
+
+// minPhoneDigits is the length below which a phone number is taken as junk
+const minPhoneDigits = 7
+
+// GuardianImport is the outcome of creating guardians from the parent phone
+// numbers of students
+type GuardianImport struct {
+	Created []ImportedGuardian `json:"created"`
+	Linked  []uint             `json:"linked"` // students newly linked to guardians that already existed
+	Skipped []SkippedStudent   `json:"skipped"`
+}
+
+// ImportedGuardian is a guardian created with a new user. The temporary
+// password is only ever shown here.
+type ImportedGuardian struct {
+	Guardian          models.Guardian `json:"guardian"`
+	Email             string          `json:"email"`
+	TemporaryPassword string          `json:"temporary_password"`
+	StudentIDs        []uint          `json:"student_ids"`
+}
+
+type SkippedStudent struct {
+	StudentID uint   `json:"student_id"`
+	Reason    string `json:"reason"`
+}
+
+// NormalizePhone keeps the digits of a phone number and a leading +, so the
+// same number written differently matches. Numbers too short to be real
+// give "".
+func NormalizePhone(phone string) string {
+	phone = strings.TrimSpace(phone)
+	var normalized strings.Builder
+	digits := 0
+	for i, r := range phone {
+		switch {
+		case r >= '0' && r <= '9':
+			normalized.WriteRune(r)
+			digits++
+		case r == '+' && i == 0:
+			normalized.WriteRune(r)
+		}
+	}
+	if digits < minPhoneDigits {
+		return ""
+	}
+	return normalized.String()
+}
+
+// ImportGuardians creates a guardian with a parent user for every distinct
+// ParentPhone of the students and links the students to them, so siblings
+// share one guardian. Students whose parent phone already belongs to a
+// guardian are linked to that guardian instead. The login email of a new
+// guardian is taken from emails, keyed by phone number, or made up from the
+// phone number and emailDomain.
+func ImportGuardians(db *gorm.DB, students []models.Student, emails map[string]string, emailDomain string) (*GuardianImport, error) {
+	emailByPhone := make(map[string]string, len(emails))
+	for phone, email := range emails {
+		if phone = NormalizePhone(phone); phone != "" {
+			emailByPhone[phone] = strings.ToLower(strings.TrimSpace(email))
+		}
+	}
+
+	result := &GuardianImport{
+		Created: []ImportedGuardian{},
+		Linked:  []uint{},
+		Skipped: []SkippedStudent{},
+	}
+	var phones []string
+	byPhone := make(map[string][]models.Student)
+	for _, student := range students {
+		phone := NormalizePhone(student.ParentPhone)
+		if phone == "" {
+			result.Skipped = append(result.Skipped, SkippedStudent{StudentID: student.ID, Reason: "no valid parent phone"})
+			continue
+		}
+		if _, ok := byPhone[phone]; !ok {
+			phones = append(phones, phone)
+		}
+		byPhone[phone] = append(byPhone[phone], student)
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		guardianRepo := repository.NewGuardianRepository(tx)
+		userRepo := repository.NewUserRepository(tx)
+
+		for _, phone := range phones {
+			children := byPhone[phone]
+
+			guardian, err := guardianRepo.FindByPhone(phone)
+			if err == nil {
+				for _, child := range children {
+					_, err := guardianRepo.FindLink(guardian.ID, child.ID)
+					if err == nil {
+						continue
+					}
+					if !errors.Is(err, gorm.ErrRecordNotFound) {
+						return err
+					}
+					if err := guardianRepo.Link(&models.StudentGuardian{GuardianID: guardian.ID, StudentID: child.ID}); err != nil {
+						return err
+					}
+					result.Linked = append(result.Linked, child.ID)
+				}
+				continue
+			}
+			if !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+
+			email := emailByPhone[phone]
+			if email == "" && emailDomain != "" {
+				email = fmt.Sprintf("parent.%s@%s", strings.TrimPrefix(phone, "+"), emailDomain)
+			}
+			if email == "" {
+				skipStudents(result, children, "no email for the parent phone")
+				continue
+			}
+			if _, err := userRepo.FindByEmail(email); err == nil {
+				skipStudents(result, children, "email "+email+" is already in use")
+				continue
+			} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+
+			password, err := utils.GenerateRandomToken(9)
+			if err != nil {
+				return err
+			}
+			passwordHash, err := utils.HashPassword(password)
+			if err != nil {
+				return err
+			}
+			user := &models.User{Email: email, PasswordHash: passwordHash, Role: RoleParent, Status: "active"}
+			if err := userRepo.Create(user); err != nil {
+				return err
+			}
+
+			created := models.Guardian{UserID: user.ID, Name: guardianName(children), Phone: phone}
+			if err := guardianRepo.Create(&created); err != nil {
+				return err
+			}
+			imported := ImportedGuardian{Guardian: created, Email: email, TemporaryPassword: password}
+			for _, child := range children {
+				if err := guardianRepo.Link(&models.StudentGuardian{GuardianID: created.ID, StudentID: child.ID}); err != nil {
+					return err
+				}
+				imported.StudentIDs = append(imported.StudentIDs, child.ID)
+			}
+			result.Created = append(result.Created, imported)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// guardianName takes the first parent name recorded for the children
+func guardianName(children []models.Student) string {
+	for _, child := range children {
+		if name := strings.TrimSpace(child.ParentName); name != "" {
+			return name
+		}
+	}
+	return "Parent of " + children[0].FirstName + " " + children[0].LastName
+}
+
+func skipStudents(result *GuardianImport, students []models.Student, reason string) {
+	for _, student := range students {
+		result.Skipped = append(result.Skipped, SkippedStudent{StudentID: student.ID, Reason: reason})
+	}
+}
@@ -0,0 +1,58 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+	"school-erp-backend/internal/models"
+	"school-erp-backend/internal/repository"
+)
+
+// StartJob records the job as queued and runs fn in a background goroutine.
+// The value returned by fn is stored as the job's JSON result.
+func StartJob(jobRepo *repository.JobRepository, job *models.Job, fn func() (interface{}, error)) error {
+	job.Status = "queued"
+	if err := jobRepo.Create(job); err != nil {
+		return err
+	}
+
+	// Work on a copy so the caller can keep using job while it runs
+	running := *job
+	go func() {
+		startedAt := time.Now()
+		running.Status = "running"
+		running.StartedAt = &startedAt
+		if err := jobRepo.Update(&running); err != nil {
+			log.Printf("job %d: failed to mark running: %v", running.ID, err)
+		}
+
+		result, err := runJob(fn)
+
+		finishedAt := time.Now()
+		running.FinishedAt = &finishedAt
+		if err != nil {
+			running.Status = "failed"
+			running.Error = err.Error()
+		} else {
+			running.Status = "completed"
+			if encoded, err := json.Marshal(result); err == nil {
+				running.Result = string(encoded)
+			}
+		}
+		if err := jobRepo.Update(&running); err != nil {
+			log.Printf("job %d: failed to save result: %v", running.ID, err)
+		}
+	}()
+
+	return nil
+}
+
+func runJob(fn func() (interface{}, error)) (result interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+	return fn()
+}
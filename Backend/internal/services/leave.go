@@ -0,0 +1,242 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+	"school-erp-backend/internal/models"
+	"school-erp-backend/internal/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrNoEntitlement       = errors.New("no annual entitlement is configured for this leave type")
+	ErrLeaveOverlap        = errors.New("leave overlaps another pending or approved request")
+	ErrNoWorkingDays       = errors.New("leave covers no working days")
+	ErrLeaveSpansYears     = errors.New("leave spans two academic years, apply for each year separately")
+	ErrInsufficientBalance = errors.New("not enough leave left of this type")
+	ErrLeaveNotPending     = errors.New("leave request is no longer pending")
+)
+
+// LeaveBalance is the leave of one type a teacher has in an academic year,
+// in working days. Pending requests are not yet deducted.
+type LeaveBalance struct {
+	LeaveType string `json:"leave_type"`
+	Entitled  int    `json:"entitled"`
+	Taken     int    `json:"taken"`
+	Pending   int    `json:"pending"`
+	Remaining int    `json:"remaining"`
+}
+
+// LeavePolicy applies for and decides teacher leave against the annual
+// entitlements, counting only the days the school calendar has the school
+// open.
+type LeavePolicy struct {
+	db         *gorm.DB
+	school     *SchoolCalendar
+	startMonth int
+}
+
+// NewLeavePolicy returns the leave policy of a school whose academic years
+// start in startMonth
+func NewLeavePolicy(db *gorm.DB, school *SchoolCalendar, startMonth int) *LeavePolicy {
+	return &LeavePolicy{db: db, school: school, startMonth: startMonth}
+}
+
+// Apply checks a new leave request and stores it as pending. The request
+// must have an entitled type, fall within one academic year, cover working
+// days, not overlap the teacher's other requests and fit in the balance left
+// after their pending requests. The checks and the insert run with the
+// teacher locked, so concurrent requests cannot overlap or overdraw.
+func (p *LeavePolicy) Apply(request *models.LeaveRequest) error {
+	if _, err := repository.NewLeaveEntitlementRepository(p.db).FindByType(request.LeaveType); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrNoEntitlement
+		}
+		return err
+	}
+
+	request.AcademicYear = CurrentAcademicYear(request.StartDate, p.startMonth)
+	if CurrentAcademicYear(request.EndDate, p.startMonth) != request.AcademicYear {
+		return ErrLeaveSpansYears
+	}
+	if err := p.countWorkingDays(request); err != nil {
+		return err
+	}
+
+	return p.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockTeacher(tx, request.TeacherID); err != nil {
+			return err
+		}
+
+		requestRepo := repository.NewLeaveRequestRepository(tx)
+		overlapping, err := requestRepo.FindOverlapping(request.TeacherID, request.StartDate, request.EndDate, 0)
+		if err != nil {
+			return err
+		}
+		if len(overlapping) > 0 {
+			return fmt.Errorf("%w (request %d)", ErrLeaveOverlap, overlapping[0].ID)
+		}
+
+		balance, err := p.within(tx).balance(request.TeacherID, request.AcademicYear, request.LeaveType)
+		if err != nil {
+			return err
+		}
+		if request.WorkingDays > balance.Remaining-balance.Pending {
+			return fmt.Errorf("%w: %d working days requested, %d left after pending requests",
+				ErrInsufficientBalance, request.WorkingDays, balance.Remaining-balance.Pending)
+		}
+
+		request.Status = "pending"
+		request.ApprovedBy = nil
+		request.DecidedAt = nil
+		return requestRepo.Create(request)
+	})
+}
+
+// Decide approves or rejects a pending request on behalf of deciderID. The
+// working days are counted again on approval, since holidays may have been
+// added since the request was made, and must still fit in the balance,
+// which is checked with the teacher locked.
+func (p *LeavePolicy) Decide(request *models.LeaveRequest, approve bool, deciderID uint, comment string, at time.Time) error {
+	if request.Status != "pending" {
+		return ErrLeaveNotPending
+	}
+
+	status := "rejected"
+	if approve {
+		status = "approved"
+		if err := p.countWorkingDays(request); err != nil {
+			return err
+		}
+	}
+
+	err := p.db.Transaction(func(tx *gorm.DB) error {
+		if approve {
+			if err := lockTeacher(tx, request.TeacherID); err != nil {
+				return err
+			}
+			balance, err := p.within(tx).balance(request.TeacherID, request.AcademicYear, request.LeaveType)
+			if err != nil {
+				return err
+			}
+			if request.WorkingDays > balance.Remaining {
+				return fmt.Errorf("%w: %d working days requested, %d left",
+					ErrInsufficientBalance, request.WorkingDays, balance.Remaining)
+			}
+		}
+
+		decided, err := repository.NewLeaveRequestRepository(tx).Decide(request, status, &deciderID, comment, at)
+		if err != nil {
+			return err
+		}
+		if !decided {
+			return ErrLeaveNotPending
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	request.Status = status
+	request.ApprovedBy = &deciderID
+	request.DecidedAt = &at
+	request.DecisionComment = comment
+	return nil
+}
+
+// Cancel withdraws a pending request of the teacher who made it
+func (p *LeavePolicy) Cancel(request *models.LeaveRequest, at time.Time) error {
+	if request.Status != "pending" {
+		return ErrLeaveNotPending
+	}
+	cancelled, err := repository.NewLeaveRequestRepository(p.db).Decide(request, "cancelled", nil, "", at)
+	if err != nil {
+		return err
+	}
+	if !cancelled {
+		return ErrLeaveNotPending
+	}
+	request.Status = "cancelled"
+	request.DecidedAt = &at
+	return nil
+}
+
+// Balances returns the leave of every entitled type a teacher has in an
+// academic year, and of types no longer entitled that they took leave of.
+func (p *LeavePolicy) Balances(teacherID uint, academicYear string) ([]LeaveBalance, error) {
+	entitlements, err := repository.NewLeaveEntitlementRepository(p.db).FindAll()
+	if err != nil {
+		return nil, err
+	}
+	used, err := repository.NewLeaveRequestRepository(p.db).SumWorkingDays(teacherID, academicYear)
+	if err != nil {
+		return nil, err
+	}
+
+	balances := make(map[string]*LeaveBalance, len(entitlements))
+	for _, entitlement := range entitlements {
+		balances[entitlement.LeaveType] = &LeaveBalance{LeaveType: entitlement.LeaveType, Entitled: entitlement.DaysPerYear}
+	}
+	for _, days := range used {
+		balance, ok := balances[days.LeaveType]
+		if !ok {
+			balance = &LeaveBalance{LeaveType: days.LeaveType}
+			balances[days.LeaveType] = balance
+		}
+		switch days.Status {
+		case "approved":
+			balance.Taken += days.Days
+		case "pending":
+			balance.Pending += days.Days
+		}
+	}
+
+	result := make([]LeaveBalance, 0, len(balances))
+	for _, balance := range balances {
+		balance.Remaining = balance.Entitled - balance.Taken
+		result = append(result, *balance)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].LeaveType < result[j].LeaveType })
+	return result, nil
+}
+
+func (p *LeavePolicy) balance(teacherID uint, academicYear, leaveType string) (LeaveBalance, error) {
+	balances, err := p.Balances(teacherID, academicYear)
+	if err != nil {
+		return LeaveBalance{}, err
+	}
+	for _, balance := range balances {
+		if balance.LeaveType == leaveType {
+			return balance, nil
+		}
+	}
+	return LeaveBalance{LeaveType: leaveType}, nil
+}
+
+// within returns the policy reading through tx
+func (p *LeavePolicy) within(tx *gorm.DB) *LeavePolicy {
+	return &LeavePolicy{db: tx, school: p.school, startMonth: p.startMonth}
+}
+
+// lockTeacher locks the row of a teacher until tx ends, so the leave of one
+// teacher is checked and written by one transaction at a time
+func lockTeacher(tx *gorm.DB, teacherID uint) error {
+	var teacher models.Teacher
+	return tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&teacher, teacherID).Error
+}
+
+// countWorkingDays sets the working days of a request from the calendar
+func (p *LeavePolicy) countWorkingDays(request *models.LeaveRequest) error {
+	days, err := p.school.SchoolDays(request.StartDate, request.EndDate)
+	if err != nil {
+		return err
+	}
+	if len(days) == 0 {
+		return ErrNoWorkingDays
+	}
+	request.WorkingDays = len(days)
+	return nil
+}
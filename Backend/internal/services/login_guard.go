@@ -0,0 +1,125 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	"school-erp-backend/internal/models"
+	"school-erp-backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+const (
+	LockoutScopeEmail = "email"
+	LockoutScopeIP    = "ip"
+
+	// Each failure doubles the wait before the next attempt, from
+	// loginBaseDelay up to loginMaxDelay
+	loginBaseDelay = time.Second
+	loginMaxDelay  = 30 * time.Second
+)
+
+// LoginPolicy limits failed logins per email address and per IP address.
+// Failures are counted over LockoutDuration, and reaching a limit locks the
+// email or IP address for LockoutDuration.
+type LoginPolicy struct {
+	MaxFailures      int
+	MaxFailuresPerIP int
+	LockoutDuration  time.Duration
+}
+
+// LoginThrottledError is returned while an email or IP address has to wait
+// before trying again, either for the delay after a failure or for a lockout
+// to end.
+type LoginThrottledError struct {
+	RetryAfter time.Duration
+	Locked     bool
+}
+
+func (e *LoginThrottledError) Error() string {
+	if e.Locked {
+		return fmt.Sprintf("too many failed login attempts, locked for %s", e.RetryAfter.Round(time.Second))
+	}
+	return fmt.Sprintf("login attempted too soon after a failure, retry in %s", e.RetryAfter.Round(time.Second))
+}
+
+// CheckLogin returns a *LoginThrottledError when a login for the email from
+// the IP address may not be attempted yet.
+func CheckLogin(repo *repository.AccountLockoutRepository, policy LoginPolicy, email, ip string, now time.Time) error {
+	var wait *LoginThrottledError
+	for _, key := range []struct{ scope, identifier string }{{LockoutScopeEmail, email}, {LockoutScopeIP, ip}} {
+		lockout, err := repo.Find(key.scope, key.identifier)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		throttled := loginWait(lockout, policy, now)
+		if throttled != nil && (wait == nil || throttled.RetryAfter > wait.RetryAfter) {
+			wait = throttled
+		}
+	}
+	if wait != nil {
+		return wait
+	}
+	return nil
+}
+
+// RecordLoginFailure counts a failed login against the email and the IP
+// address, locking either once it reaches its limit.
+func RecordLoginFailure(repo *repository.AccountLockoutRepository, policy LoginPolicy, email, ip string, now time.Time) error {
+	limits := []struct {
+		scope, identifier string
+		max               int
+	}{
+		{LockoutScopeEmail, email, policy.MaxFailures},
+		{LockoutScopeIP, ip, policy.MaxFailuresPerIP},
+	}
+	for _, limit := range limits {
+		lockout, err := repo.RecordFailure(limit.scope, limit.identifier, now, policy.LockoutDuration)
+		if err != nil {
+			return err
+		}
+		if limit.max > 0 && lockout.FailedAttempts >= limit.max {
+			if err := repo.Lock(lockout.ID, now.Add(policy.LockoutDuration)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// RecordLoginSuccess forgets the failures of the email address. Failures of
+// the IP address are kept, as one valid login must not hide guessing at
+// other accounts.
+func RecordLoginSuccess(repo *repository.AccountLockoutRepository, email string) error {
+	return repo.Clear(LockoutScopeEmail, email)
+}
+
+// IsLocked reports whether a lockout is in force
+func IsLocked(lockout *models.AccountLockout, now time.Time) bool {
+	return lockout.LockedUntil != nil && lockout.LockedUntil.After(now)
+}
+
+func loginWait(lockout *models.AccountLockout, policy LoginPolicy, now time.Time) *LoginThrottledError {
+	if IsLocked(lockout, now) {
+		return &LoginThrottledError{RetryAfter: lockout.LockedUntil.Sub(now), Locked: true}
+	}
+	if lockout.FailedAttempts == 0 || now.Sub(lockout.LastFailedAt) >= policy.LockoutDuration {
+		return nil
+	}
+
+	delay := loginBaseDelay
+	for i := 1; i < lockout.FailedAttempts && delay < loginMaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > loginMaxDelay {
+		delay = loginMaxDelay
+	}
+	if ready := lockout.LastFailedAt.Add(delay); now.Before(ready) {
+		return &LoginThrottledError{RetryAfter: ready.Sub(now)}
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package services
+
+import (
+	"errors"
+	"school-erp-backend/internal/models"
+	"school-erp-backend/internal/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrExamPublished = errors.New("exam results are published and locked")
+
+// SaveExamMarks stores the marks of an exam. The exam is read again with its
+// row locked, so results published after the caller checked the exam are
+// not changed.
+func SaveExamMarks(db *gorm.DB, examID uint, marks []models.Mark) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		var exam models.Exam
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&exam, examID).Error; err != nil {
+			return err
+		}
+		if exam.Status == "published" {
+			return ErrExamPublished
+		}
+		return repository.NewMarkRepository(tx).UpsertBulk(marks)
+	})
+}
@@ -0,0 +1,154 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"school-erp-backend/internal/models"
+	"school-erp-backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+// NoticePriorities lists the priorities of notices, most pressing first
+var NoticePriorities = []string{"urgent", "high", "normal", "low"}
+
+var ErrEmptyAudience = errors.New("notice must be published to everyone or to at least one target")
+
+// NoticeAudience is who a notice is published to. Everyone overrides the
+// targets; otherwise the notice reaches the users matching any target.
+type NoticeAudience struct {
+	Everyone   bool     `json:"everyone"`
+	Roles      []string `json:"roles"`
+	ClassIDs   []uint   `json:"class_ids"`
+	SectionIDs []uint   `json:"section_ids"` // reach the students of the section and their guardians
+	UserIDs    []uint   `json:"user_ids"`
+}
+
+// IsNoticePriority reports whether a priority is known
+func IsNoticePriority(priority string) bool {
+	for _, known := range NoticePriorities {
+		if priority == known {
+			return true
+		}
+	}
+	return false
+}
+
+// SetNoticeAudience checks that every target of an audience exists and
+// stores it on the notice as its visibility and targets.
+func SetNoticeAudience(db *gorm.DB, notice *models.Notice, audience NoticeAudience) error {
+	if audience.Everyone {
+		notice.VisibilityType = "all"
+		notice.Targets = []models.NoticeTarget{}
+		return nil
+	}
+
+	targets := []models.NoticeTarget{}
+	if len(audience.Roles) > 0 {
+		roles, err := repository.NewRoleRepository(db).FindByNames(audience.Roles)
+		if err != nil {
+			return err
+		}
+		for _, role := range roles {
+			targets = append(targets, models.NoticeTarget{TargetType: "role", Role: role.Name})
+		}
+		if len(roles) != len(uniqueStrings(audience.Roles)) {
+			return fmt.Errorf("%w in audience", ErrUnknownRole)
+		}
+	}
+
+	for _, group := range []struct {
+		targetType string
+		model      interface{}
+		ids        []uint
+	}{
+		{"class", &models.Class{}, audience.ClassIDs},
+		{"section", &models.Section{}, audience.SectionIDs},
+		{"user", &models.User{}, audience.UserIDs},
+	} {
+		ids := uniqueIDs(group.ids)
+		if len(ids) == 0 {
+			continue
+		}
+		var found int64
+		if err := db.Model(group.model).Where("id IN ?", ids).Count(&found).Error; err != nil {
+			return err
+		}
+		if found != int64(len(ids)) {
+			return fmt.Errorf("unknown %s in audience", group.targetType)
+		}
+		for _, id := range ids {
+			targets = append(targets, models.NoticeTarget{TargetType: group.targetType, TargetID: id})
+		}
+	}
+
+	if len(targets) == 0 {
+		return ErrEmptyAudience
+	}
+	notice.VisibilityType = "targeted"
+	notice.Targets = targets
+	return nil
+}
+
+// NoticeReaderOf returns who a user reads notices as: all their roles, and
+// the class and section of their student record and of the children they
+// are the guardian of.
+func NoticeReaderOf(db *gorm.DB, userID uint, primaryRole string) (repository.NoticeReader, error) {
+	reader := repository.NoticeReader{UserID: userID}
+	roles, err := UserRoleNames(repository.NewRoleRepository(db), userID, primaryRole)
+	if err != nil {
+		return reader, err
+	}
+	reader.Roles = roles
+
+	var students []models.Student
+	student, err := repository.NewStudentRepository(db).FindByUserID(userID)
+	switch {
+	case err == nil:
+		students = append(students, *student)
+	case !errors.Is(err, gorm.ErrRecordNotFound):
+		return reader, err
+	}
+
+	guardianRepo := repository.NewGuardianRepository(db)
+	guardian, err := guardianRepo.FindByUserID(userID)
+	switch {
+	case err == nil:
+		children, err := guardianRepo.Children(guardian.ID)
+		if err != nil {
+			return reader, err
+		}
+		students = append(students, children...)
+	case !errors.Is(err, gorm.ErrRecordNotFound):
+		return reader, err
+	}
+
+	for _, student := range students {
+		reader.ClassIDs = append(reader.ClassIDs, student.ClassID)
+		reader.SectionIDs = append(reader.SectionIDs, student.SectionID)
+	}
+	return reader, nil
+}
+
+func uniqueIDs(ids []uint) []uint {
+	seen := make(map[uint]bool, len(ids))
+	unique := make([]uint, 0, len(ids))
+	for _, id := range ids {
+		if id != 0 && !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
+
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	unique := make([]string, 0, len(values))
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			unique = append(unique, value)
+		}
+	}
+	return unique
+}
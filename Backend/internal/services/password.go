@@ -0,0 +1,189 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+	"school-erp-backend/internal/models"
+	"school-erp-backend/internal/repository"
+	"school-erp-backend/pkg/mailer"
+	"school-erp-backend/pkg/utils"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrWrongPassword     = errors.New("current password is incorrect")
+	ErrInvalidResetToken = errors.New("invalid or expired password reset token")
+)
+
+const (
+	ResetScopeEmail = "reset_email"
+	ResetScopeIP    = "reset_ip"
+)
+
+// PasswordResetLimit bounds the reset requests per email address and per IP
+// address. Requests are counted until none was made for Window.
+type PasswordResetLimit struct {
+	MaxPerEmail int
+	MaxPerIP    int
+	Window      time.Duration
+}
+
+// PasswordResetOptions configures the reset links mailed to users
+type PasswordResetOptions struct {
+	URL    string // page of the frontend the link opens, the token is added as a query parameter
+	Expiry time.Duration
+}
+
+// RequestPasswordReset mails a reset link to the user with the given email.
+// Unknown and inactive accounts are silently ignored, so callers must not
+// reveal the outcome. Earlier links of the user stop working.
+func RequestPasswordReset(ctx context.Context, db *gorm.DB, sender mailer.Sender, email string, options PasswordResetOptions) error {
+	user, err := repository.NewUserRepository(db).FindByEmail(email)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if user.Status != "active" {
+		return nil
+	}
+
+	token, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return err
+	}
+	link, err := url.Parse(options.URL)
+	if err != nil {
+		return err
+	}
+	query := link.Query()
+	query.Set("token", token)
+	link.RawQuery = query.Encode()
+
+	resetRepo := repository.NewPasswordResetRepository(db)
+	now := time.Now()
+	if err := resetRepo.InvalidateUser(user.ID, now); err != nil {
+		return err
+	}
+	err = resetRepo.Create(&models.PasswordResetToken{
+		UserID:    user.ID,
+		Token:     utils.HashToken(token),
+		ExpiresAt: now.Add(options.Expiry),
+	})
+	if err != nil {
+		return err
+	}
+
+	return sender.Send(ctx, mailer.Message{
+		To:      []string{user.Email},
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("A password reset was requested for your account.\n\n"+
+			"Open the link below within %d minutes to choose a new password:\n\n%s\n\n"+
+			"If you did not request this, you can ignore this email. Your password will not change.\n",
+			int(options.Expiry.Minutes()), link.String()),
+	})
+}
+
+// AllowPasswordReset counts a reset request against the email and the IP
+// address and reports whether both are still within their limit. The count
+// is kept whether or not an account with the email exists.
+func AllowPasswordReset(repo *repository.AccountLockoutRepository, limit PasswordResetLimit, email, ip string, now time.Time) (bool, error) {
+	limits := []struct {
+		scope, identifier string
+		max               int
+	}{
+		{ResetScopeEmail, email, limit.MaxPerEmail},
+		{ResetScopeIP, ip, limit.MaxPerIP},
+	}
+	allowed := true
+	for _, l := range limits {
+		counted, err := repo.RecordFailure(l.scope, l.identifier, now, limit.Window)
+		if err != nil {
+			return false, err
+		}
+		if l.max > 0 && counted.FailedAttempts > l.max {
+			allowed = false
+		}
+	}
+	return allowed, nil
+}
+
+// ResetPassword sets a new password with a mailed reset token. The token is
+// used up and the user is signed out of every session.
+func ResetPassword(db *gorm.DB, token, newPassword string) error {
+	passwordHash, err := utils.HashPassword(newPassword)
+	if err != nil {
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		resetRepo := repository.NewPasswordResetRepository(tx)
+		reset, err := resetRepo.FindByToken(utils.HashToken(token))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrInvalidResetToken
+		}
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if reset.UsedAt != nil || now.After(reset.ExpiresAt) {
+			return ErrInvalidResetToken
+		}
+		used, err := resetRepo.MarkUsed(reset.ID, now)
+		if err != nil {
+			return err
+		}
+		if !used {
+			return ErrInvalidResetToken
+		}
+
+		userRepo := repository.NewUserRepository(tx)
+		user, err := userRepo.FindByID(reset.UserID)
+		if err != nil || user.Status != "active" {
+			return ErrInvalidResetToken
+		}
+		user.PasswordHash = passwordHash
+		if err := userRepo.Update(user); err != nil {
+			return err
+		}
+
+		return repository.NewSessionRepository(tx).RevokeUser(user.ID, now)
+	})
+}
+
+// ChangePassword replaces the password of a user who knows the current one.
+// Outstanding reset links stop working and every other session of the user
+// is revoked, keeping the session the change was made from.
+func ChangePassword(db *gorm.DB, user *models.User, sessionID uint, currentPassword, newPassword string) error {
+	if !utils.CheckPasswordHash(currentPassword, user.PasswordHash) {
+		return ErrWrongPassword
+	}
+	passwordHash, err := utils.HashPassword(newPassword)
+	if err != nil {
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		user.PasswordHash = passwordHash
+		if err := repository.NewUserRepository(tx).Update(user); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if err := repository.NewPasswordResetRepository(tx).InvalidateUser(user.ID, now); err != nil {
+			return err
+		}
+
+		sessionRepo := repository.NewSessionRepository(tx)
+		session, err := sessionRepo.FindByID(sessionID)
+		if err != nil {
+			return err
+		}
+		return sessionRepo.RevokeUserExcept(user.ID, session.FamilyID, now)
+	})
+}
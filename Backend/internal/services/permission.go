@@ -0,0 +1,225 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"school-erp-backend/internal/models"
+	"school-erp-backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+// RoleAdmin is the role with every permission. Its permissions are implied
+// rather than stored, so they cannot be edited away.
+const RoleAdmin = "admin"
+
+var (
+	ErrUnknownPermission = errors.New("unknown permission")
+	ErrUnknownRole       = errors.New("unknown role")
+	ErrSystemRole        = errors.New("system role cannot be changed")
+	ErrRoleInUse         = errors.New("role is the primary role of users")
+)
+
+// PermissionCatalogue lists every permission that can be granted. It is
+// stored at startup by SeedPermissions.
+var PermissionCatalogue = []models.Permission{
+	{Name: "users.read", Description: "View user accounts"},
+	{Name: "users.write", Description: "Create, update and delete user accounts"},
+	{Name: "roles.manage", Description: "Edit roles and the permissions they grant"},
+	{Name: "security.manage", Description: "Review logins, clear lockouts, sign users out and reset two-factor authentication"},
+	{Name: "students.read", Description: "View students"},
+	{Name: "students.write", Description: "Create, update and delete students"},
+	{Name: "guardians.read", Description: "View guardians and the students they are linked to"},
+	{Name: "guardians.write", Description: "Create guardians and link them to students"},
+	{Name: "teachers.read", Description: "View teachers"},
+	{Name: "teachers.write", Description: "Create, update and delete teachers"},
+	{Name: "classes.read", Description: "View classes, sections and subjects"},
+	{Name: "classes.write", Description: "Manage classes, sections and subjects"},
+	{Name: "curriculum.read", Description: "View the curriculum, electives and teaching assignments"},
+	{Name: "curriculum.write", Description: "Manage the curriculum, elective enrollments and teaching assignments"},
+	{Name: "attendance.read", Description: "View attendance and attendance reports"},
+	{Name: "attendance.mark", Description: "Mark attendance"},
+	{Name: "exams.read", Description: "View exams"},
+	{Name: "exams.write", Description: "Create, update and delete exams"},
+	{Name: "marks.read", Description: "View exam marks"},
+	{Name: "marks.publish", Description: "Publish and unpublish exam results"},
+	{Name: "assignments.read", Description: "View assignments and download submission files"},
+	{Name: "grading.read", Description: "View grading scales"},
+	{Name: "grading.write", Description: "Manage grading scales and recompute grades"},
+	{Name: "report_cards.read", Description: "View report cards and remarks"},
+	{Name: "report_cards.write", Description: "Write report card remarks"},
+	{Name: "timetable.read", Description: "View timetables"},
+	{Name: "timetable.write", Description: "Edit and generate timetables"},
+	{Name: "leave.read", Description: "View teacher leave requests and balances"},
+	{Name: "leave.approve", Description: "Approve and reject teacher leave requests"},
+	{Name: "leave.configure", Description: "Set the annual leave entitlements"},
+	{Name: "student_leave.read", Description: "View student leave requests and their attachments"},
+	{Name: "student_leave.approve", Description: "Approve and reject student leave requests of any section"},
+	{Name: "substitutions.read", Description: "View periods affected by leave and the substitution sheet"},
+	{Name: "substitutions.write", Description: "Assign and remove substitute teachers"},
+	{Name: "calendar.read", Description: "View every calendar event"},
+	{Name: "calendar.write", Description: "Manage calendar events and holidays"},
+	{Name: "notices.read", Description: "View every notice and who has read it"},
+	{Name: "notices.write", Description: "Publish notices to anyone"},
+	{Name: "jobs.read", Description: "View background jobs"},
+}
+
+// defaultRoles are created at startup when missing. Admins may change the
+// permissions of all but the admin role afterwards.
+var defaultRoles = []struct {
+	name        string
+	description string
+	system      bool
+	permissions []string
+}{
+	{RoleAdmin, "Full access to everything", true, nil},
+	{"teacher", "Teacher portal", true, nil},
+	{"student", "Student portal", true, nil},
+	{RoleParent, "Parent portal, read-only access to the data of linked children", true, nil},
+	{"principal", "Oversees academics and publishes results", false, []string{
+		"users.read", "students.read", "guardians.read", "teachers.read", "classes.read", "curriculum.read",
+		"attendance.read", "exams.read", "marks.read", "marks.publish", "assignments.read", "grading.read",
+		"report_cards.read", "report_cards.write", "timetable.read", "leave.read", "leave.approve",
+		"student_leave.read", "student_leave.approve", "substitutions.read", "substitutions.write",
+		"calendar.read", "calendar.write", "notices.read", "notices.write", "jobs.read",
+	}},
+	{"accountant", "Handles fees and accounts", false, []string{
+		"students.read", "classes.read",
+	}},
+	{"librarian", "Runs the library", false, []string{
+		"students.read", "teachers.read", "classes.read",
+	}},
+	{"office_clerk", "Keeps student records at the front office", false, []string{
+		"users.read", "students.read", "students.write", "guardians.read", "guardians.write",
+		"teachers.read", "classes.read", "attendance.read", "timetable.read", "leave.read", "student_leave.read", "substitutions.read", "calendar.read", "calendar.write", "notices.read",
+	}},
+}
+
+// IsPermission reports whether a permission is in the catalogue
+func IsPermission(name string) bool {
+	for _, permission := range PermissionCatalogue {
+		if permission.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// SeedPermissions stores the permission catalogue and creates the default
+// roles that do not exist yet. Existing roles are left as admins edited them.
+func SeedPermissions(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		permissionRepo := repository.NewPermissionRepository(tx)
+		for _, permission := range PermissionCatalogue {
+			permission := permission
+			if err := permissionRepo.Ensure(&permission); err != nil {
+				return err
+			}
+		}
+
+		roleRepo := repository.NewRoleRepository(tx)
+		for _, defaultRole := range defaultRoles {
+			_, err := roleRepo.FindByName(defaultRole.name)
+			if err == nil {
+				continue
+			}
+			if !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+
+			role := &models.Role{Name: defaultRole.name, Description: defaultRole.description, IsSystem: defaultRole.system}
+			if err := roleRepo.Create(role); err != nil {
+				return err
+			}
+			if len(defaultRole.permissions) == 0 {
+				continue
+			}
+			permissions, err := permissionRepo.FindByNames(defaultRole.permissions)
+			if err != nil {
+				return err
+			}
+			if err := roleRepo.SetPermissions(role, permissions); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// FindPermissions looks up permissions by name, failing with
+// ErrUnknownPermission for names outside the catalogue.
+func FindPermissions(repo *repository.PermissionRepository, names []string) ([]models.Permission, error) {
+	for _, name := range names {
+		if !IsPermission(name) {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownPermission, name)
+		}
+	}
+	if len(names) == 0 {
+		return []models.Permission{}, nil
+	}
+	return repo.FindByNames(names)
+}
+
+// UserRoleNames returns the primary role of a user followed by the roles they
+// have been given besides it.
+func UserRoleNames(repo *repository.RoleRepository, userID uint, primaryRole string) ([]string, error) {
+	roles, err := repo.UserRoles(userID)
+	if err != nil {
+		return nil, err
+	}
+	names := []string{primaryRole}
+	for _, role := range roles {
+		if role.Name != primaryRole {
+			names = append(names, role.Name)
+		}
+	}
+	return names, nil
+}
+
+// EffectivePermissions returns every permission a user holds through any of
+// their roles.
+func EffectivePermissions(repo *repository.RoleRepository, userID uint, primaryRole string) ([]string, error) {
+	roleNames, err := UserRoleNames(repo, userID, primaryRole)
+	if err != nil {
+		return nil, err
+	}
+	return RolePermissions(repo, roleNames)
+}
+
+// RolePermissions returns the permissions granted by a set of roles
+func RolePermissions(repo *repository.RoleRepository, roleNames []string) ([]string, error) {
+	for _, name := range roleNames {
+		if name == RoleAdmin {
+			names := make([]string, 0, len(PermissionCatalogue))
+			for _, permission := range PermissionCatalogue {
+				names = append(names, permission.Name)
+			}
+			return names, nil
+		}
+	}
+	if len(roleNames) == 0 {
+		return []string{}, nil
+	}
+	return repo.PermissionNames(roleNames)
+}
+
+// HasPermission reports whether a permission is among those held
+func HasPermission(held []string, name string) bool {
+	for _, permission := range held {
+		if permission == name {
+			return true
+		}
+	}
+	return false
+}
+
+// CanGrant reports whether someone holding the held permissions may hand out
+// the granted ones. Nobody may grant a permission they do not have, so roles
+// cannot be used to escalate privileges.
+func CanGrant(held, granted []string) bool {
+	for _, name := range granted {
+		if !HasPermission(held, name) {
+			return false
+		}
+	}
+	return true
+}
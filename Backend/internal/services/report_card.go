@@ -0,0 +1,141 @@
+package services
+
+import (
+	"errors"
+	"sort"
+	"school-erp-backend/internal/models"
+	"school-erp-backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+// ErrNoResults is returned when a student has no published marks for the
+// requested academic year and exam type.
+var ErrNoResults = errors.New("no published results for this academic year and exam type")
+
+type ReportCardSubject struct {
+	SubjectID     uint    `json:"subject_id"`
+	SubjectName   string  `json:"subject_name"`
+	SubjectCode   string  `json:"subject_code"`
+	MarksObtained float64 `json:"marks_obtained"`
+	TotalMarks    float64 `json:"total_marks"`
+	PassingMarks  float64 `json:"passing_marks"`
+	Percentage    float64 `json:"percentage"`
+	Grade         string  `json:"grade"`
+	GradePoint    float64 `json:"grade_point"`
+	Passed        bool    `json:"passed"`
+	Elective      bool    `json:"elective"`
+}
+
+type ReportCard struct {
+	Student       models.Student      `json:"student"`
+	AcademicYear  string              `json:"academic_year"`
+	ExamType      string              `json:"exam_type"`
+	Subjects      []ReportCardSubject `json:"subjects"`
+	MarksObtained float64             `json:"marks_obtained"`
+	TotalMarks    float64             `json:"total_marks"`
+	Percentage    float64             `json:"percentage"`
+	Grade         string              `json:"grade"`
+	GradePoint    float64             `json:"grade_point"`
+	Result        string              `json:"result"` // pass, fail
+	Attendance    AttendanceSummary   `json:"attendance"`
+	Remarks       string              `json:"remarks"`
+}
+
+// BuildReportCard aggregates the student's marks of published exams of the
+// given type, the attendance over the whole academic year and the class
+// teacher's remarks. Several exams of the same type for one subject are
+// added up into a single subject row. Core subjects of the class curriculum
+// are listed before electives. The student must have Class and Section
+// loaded.
+func BuildReportCard(db *gorm.DB, student models.Student, academicYear, examType string, yearStartMonth int) (*ReportCard, error) {
+	marks, err := repository.NewMarkRepository(db).FindByStudent(student.ID, academicYear)
+	if err != nil {
+		return nil, err
+	}
+
+	bands, err := GradeBandsFor(repository.NewGradingScaleRepository(db), student.ClassID, academicYear)
+	if err != nil {
+		return nil, err
+	}
+
+	curriculum, err := repository.NewCurriculumRepository(db).FindByClass(student.ClassID, academicYear)
+	if err != nil {
+		return nil, err
+	}
+	electives := make(map[uint]bool)
+	for _, entry := range curriculum {
+		electives[entry.SubjectID] = entry.IsElective
+	}
+
+	card := &ReportCard{
+		Student:      student,
+		AcademicYear: academicYear,
+		ExamType:     examType,
+		Result:       "pass",
+	}
+
+	index := make(map[uint]int)
+	for _, mark := range marks {
+		if mark.ExamType != examType || mark.Exam.Status != "published" {
+			continue
+		}
+		i, ok := index[mark.SubjectID]
+		if !ok {
+			i = len(card.Subjects)
+			index[mark.SubjectID] = i
+			card.Subjects = append(card.Subjects, ReportCardSubject{
+				SubjectID:   mark.SubjectID,
+				SubjectName: mark.Subject.Name,
+				SubjectCode: mark.Subject.Code,
+				Elective:    electives[mark.SubjectID],
+			})
+		}
+		subject := &card.Subjects[i]
+		subject.MarksObtained += mark.MarksObtained
+		subject.TotalMarks += mark.TotalMarks
+		subject.PassingMarks += mark.Exam.PassingMarks
+	}
+	if len(card.Subjects) == 0 {
+		return nil, ErrNoResults
+	}
+
+	sort.Slice(card.Subjects, func(i, j int) bool {
+		if card.Subjects[i].Elective != card.Subjects[j].Elective {
+			return !card.Subjects[i].Elective
+		}
+		return card.Subjects[i].SubjectName < card.Subjects[j].SubjectName
+	})
+
+	for i := range card.Subjects {
+		subject := &card.Subjects[i]
+		subject.Percentage = Percentage(subject.MarksObtained, subject.TotalMarks)
+		subject.Grade, subject.GradePoint = GradeFor(bands, subject.Percentage)
+		subject.Passed = subject.MarksObtained >= subject.PassingMarks
+		if !subject.Passed {
+			card.Result = "fail"
+		}
+		card.MarksObtained += subject.MarksObtained
+		card.TotalMarks += subject.TotalMarks
+	}
+	card.Percentage = Percentage(card.MarksObtained, card.TotalMarks)
+	card.Grade, card.GradePoint = GradeFor(bands, card.Percentage)
+
+	from, to, err := AcademicYearRange(academicYear, yearStartMonth)
+	if err != nil {
+		return nil, err
+	}
+	records, err := repository.NewAttendanceRepository(db).FindByStudent(student.ID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	card.Attendance = SummarizeAttendance(records)
+
+	remark, err := repository.NewReportCardRemarkRepository(db).Find(student.ID, academicYear, examType)
+	if err == nil {
+		card.Remarks = remark.Remarks
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	return card, nil
+}
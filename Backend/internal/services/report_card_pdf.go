@@ -0,0 +1,214 @@
+package services
+
+import (
+	"fmt"
+	"school-erp-backend/pkg/pdf"
+	"strings"
+)
+
+// School identifies the school in the report card header
+type School struct {
+	Name    string
+	Address string
+}
+
+const (
+	cardLeft      = 50.0
+	cardRight     = pdf.PageWidth - 50
+	cardRowHeight = 20.0
+	cardBottom    = pdf.PageHeight - 60
+)
+
+// Subject table columns: the subject name is left aligned, numbers are right
+// aligned at the given x and grade/result are centred on it.
+var reportCardColumns = []struct {
+	title string
+	x     float64
+}{
+	{"Subject", cardLeft + 6},
+	{"Max Marks", 290},
+	{"Obtained", 360},
+	{"Percentage", 440},
+	{"Grade", 480},
+	{"Result", 525},
+}
+
+// RenderReportCardPDF lays out a report card on an A4 page, continuing the
+// subject table on further pages when it does not fit.
+func RenderReportCardPDF(card *ReportCard, school School) []byte {
+	doc := pdf.New()
+	page := doc.AddPage()
+
+	y := 60.0
+	page.SetFont(pdf.HelveticaBold, 18)
+	page.TextCenter(pdf.PageWidth/2, y, school.Name)
+	if school.Address != "" {
+		y += 18
+		page.SetFont(pdf.Helvetica, 10)
+		page.TextCenter(pdf.PageWidth/2, y, school.Address)
+	}
+	y += 12
+	page.SetLineWidth(1)
+	page.Line(cardLeft, y, cardRight, y)
+
+	y += 26
+	page.SetFont(pdf.HelveticaBold, 14)
+	page.TextCenter(pdf.PageWidth/2, y, "REPORT CARD")
+	y += 17
+	page.SetFont(pdf.Helvetica, 11)
+	page.TextCenter(pdf.PageWidth/2, y, fmt.Sprintf("Academic Year %s  -  %s", card.AcademicYear, capitalize(card.ExamType)))
+
+	student := card.Student
+	y += 30
+	drawField(page, cardLeft, y, "Name", student.FirstName+" "+student.LastName)
+	drawField(page, 320, y, "Admission No.", student.AdmissionNumber)
+	y += 16
+	drawField(page, cardLeft, y, "Class", student.Class.Name)
+	drawField(page, 320, y, "Section", student.Section.Name)
+	if !student.DateOfBirth.IsZero() {
+		y += 16
+		drawField(page, cardLeft, y, "Date of Birth", student.DateOfBirth.Format("02 Jan 2006"))
+	}
+
+	y += 24
+	y = drawTableHeader(page, y)
+	page.SetFont(pdf.Helvetica, 10)
+	for _, subject := range card.Subjects {
+		if y+cardRowHeight > cardBottom {
+			page = doc.AddPage()
+			y = drawTableHeader(page, 60)
+			page.SetFont(pdf.Helvetica, 10)
+		}
+		result := "Pass"
+		if !subject.Passed {
+			result = "Fail"
+		}
+		drawRow(page, y, subject.SubjectName, subject.TotalMarks, subject.MarksObtained, subject.Percentage, subject.Grade, result)
+		y += cardRowHeight
+	}
+
+	page.SetFont(pdf.HelveticaBold, 10)
+	drawRow(page, y, "Total", card.TotalMarks, card.MarksObtained, card.Percentage, card.Grade, capitalize(card.Result))
+	y += cardRowHeight
+
+	// Summary, remarks and signatures are kept together on one page
+	remarkLines := wrapText(page, card.Remarks, cardRight-cardLeft-12)
+	if y+150+float64(len(remarkLines))*13 > cardBottom {
+		page = doc.AddPage()
+		y = 40
+	}
+
+	y += 28
+	attended := card.Attendance.Present + card.Attendance.Late
+	counted := attended + card.Attendance.Absent
+	drawField(page, cardLeft, y, "Overall", fmt.Sprintf("%.2f%%  (Grade %s, %.1f points)", card.Percentage, card.Grade, card.GradePoint))
+	drawField(page, 320, y, "Result", capitalize(card.Result))
+	y += 16
+	drawField(page, cardLeft, y, "Attendance", fmt.Sprintf("%d of %d days  (%.2f%%)", attended, counted, card.Attendance.Percentage))
+
+	y += 28
+	page.SetFont(pdf.HelveticaBold, 10)
+	page.Text(cardLeft, y, "Class Teacher's Remarks")
+	y += 8
+	boxHeight := float64(len(remarkLines))*13 + 14
+	if boxHeight < 50 {
+		boxHeight = 50
+	}
+	page.SetLineWidth(0.5)
+	page.Rect(cardLeft, y, cardRight-cardLeft, boxHeight, false)
+	page.SetFont(pdf.Helvetica, 10)
+	for i, line := range remarkLines {
+		page.Text(cardLeft+6, y+16+float64(i)*13, line)
+	}
+
+	y += boxHeight + 60
+	signatures := []string{"Class Teacher", "Principal", "Parent / Guardian"}
+	width := (cardRight - cardLeft) / float64(len(signatures))
+	for i, title := range signatures {
+		left := cardLeft + float64(i)*width
+		page.Line(left+15, y, left+width-15, y)
+		page.TextCenter(left+width/2, y+14, title)
+	}
+
+	return doc.Bytes()
+}
+
+func drawField(page *pdf.Page, x, y float64, label, value string) {
+	page.SetFont(pdf.HelveticaBold, 10)
+	page.Text(x, y, label+":")
+	offset := page.TextWidth(label + ": ")
+	page.SetFont(pdf.Helvetica, 10)
+	page.Text(x+offset, y, value)
+}
+
+func drawTableHeader(page *pdf.Page, y float64) float64 {
+	page.SetFillGray(0.88)
+	page.Rect(cardLeft, y, cardRight-cardLeft, cardRowHeight, true)
+	page.SetFillGray(0)
+	page.SetLineWidth(0.5)
+	page.Rect(cardLeft, y, cardRight-cardLeft, cardRowHeight, false)
+
+	page.SetFont(pdf.HelveticaBold, 10)
+	for i, column := range reportCardColumns {
+		switch {
+		case i == 0:
+			page.Text(column.x, y+14, column.title)
+		case i < 4:
+			page.TextRight(column.x, y+14, column.title)
+		default:
+			page.TextCenter(column.x, y+14, column.title)
+		}
+	}
+	return y + cardRowHeight
+}
+
+func drawRow(page *pdf.Page, y float64, name string, total, obtained, percentage float64, grade, result string) {
+	page.Rect(cardLeft, y, cardRight-cardLeft, cardRowHeight, false)
+	columns := reportCardColumns
+	page.Text(columns[0].x, y+14, name)
+	page.TextRight(columns[1].x, y+14, formatMarks(total))
+	page.TextRight(columns[2].x, y+14, formatMarks(obtained))
+	page.TextRight(columns[3].x, y+14, fmt.Sprintf("%.2f", percentage))
+	page.TextCenter(columns[4].x, y+14, grade)
+	page.TextCenter(columns[5].x, y+14, result)
+}
+
+// formatMarks drops the decimals of whole marks
+func formatMarks(value float64) string {
+	if value == float64(int64(value)) {
+		return fmt.Sprintf("%d", int64(value))
+	}
+	return fmt.Sprintf("%.2f", value)
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+// wrapText splits text into lines no wider than width in 10pt Helvetica.
+func wrapText(page *pdf.Page, text string, width float64) []string {
+	page.SetFont(pdf.Helvetica, 10)
+
+	var lines []string
+	for _, paragraph := range strings.Split(text, "\n") {
+		line := ""
+		for _, word := range strings.Fields(paragraph) {
+			candidate := word
+			if line != "" {
+				candidate = line + " " + word
+			}
+			if line != "" && page.TextWidth(candidate) > width {
+				lines = append(lines, line)
+				candidate = word
+			}
+			line = candidate
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
@@ -0,0 +1,143 @@
+package services
+
+import (
+	"errors"
+	"time"
+	"school-erp-backend/internal/models"
+	"school-erp-backend/internal/repository"
+	"school-erp-backend/pkg/jwt"
+	"school-erp-backend/pkg/utils"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+	ErrRefreshTokenReused  = errors.New("refresh token has already been used")
+	ErrAccountInactive     = errors.New("account is inactive")
+)
+
+// sessionActivityInterval limits how often the last activity of a session
+// is written
+const sessionActivityInterval = time.Minute
+
+// TokenPair is handed to a client when a session starts or is refreshed
+type TokenPair struct {
+	AccessToken  string
+	RefreshToken string
+}
+
+// SessionClient describes the device a session is used from
+type SessionClient struct {
+	UserAgent string
+	IPAddress string
+}
+
+// StartSession starts a new session family for a user who has just logged
+// in.
+func StartSession(repo *repository.SessionRepository, user *models.User, client SessionClient, refreshExpiry time.Duration) (*TokenPair, error) {
+	familyID, err := utils.GenerateRandomToken(16)
+	if err != nil {
+		return nil, err
+	}
+	return issueTokens(repo, user, familyID, time.Now(), client, refreshExpiry)
+}
+
+// RefreshSession exchanges a refresh token for a new token pair. The old
+// refresh token is rotated and cannot be used again: presenting it a second
+// time means it has leaked, so the whole family is revoked and every client
+// holding one of its tokens has to log in again.
+func RefreshSession(repo *repository.SessionRepository, userRepo *repository.UserRepository, refreshToken string, client SessionClient, refreshExpiry time.Duration) (*TokenPair, error) {
+	session, err := repo.FindByToken(utils.HashToken(refreshToken))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if session.RevokedAt != nil || now.After(session.ExpiresAt) {
+		return nil, ErrInvalidRefreshToken
+	}
+	if session.RotatedAt != nil {
+		if err := repo.RevokeFamily(session.FamilyID, now); err != nil {
+			return nil, err
+		}
+		return nil, ErrRefreshTokenReused
+	}
+
+	user, err := userRepo.FindByID(session.UserID)
+	if err != nil || user.Status != "active" {
+		if err := repo.RevokeFamily(session.FamilyID, now); err != nil {
+			return nil, err
+		}
+		return nil, ErrAccountInactive
+	}
+
+	rotated, err := repo.MarkRotated(session.ID, now)
+	if err != nil {
+		return nil, err
+	}
+	if !rotated {
+		if err := repo.RevokeFamily(session.FamilyID, now); err != nil {
+			return nil, err
+		}
+		return nil, ErrRefreshTokenReused
+	}
+
+	return issueTokens(repo, user, session.FamilyID, session.StartedAt, client, refreshExpiry)
+}
+
+// EndSession revokes the family of a session, logging its client out
+func EndSession(repo *repository.SessionRepository, sessionID uint) error {
+	session, err := repo.FindByID(sessionID)
+	if err != nil {
+		return err
+	}
+	return repo.RevokeFamily(session.FamilyID, time.Now())
+}
+
+// RevokeUserSessions signs a user out on every device. Their access tokens
+// stop working at once, and they have to log in again.
+func RevokeUserSessions(repo *repository.SessionRepository, userID uint) error {
+	return repo.RevokeUser(userID, time.Now())
+}
+
+// TouchSession records that a session has just been used, at most once per
+// sessionActivityInterval.
+func TouchSession(repo *repository.SessionRepository, session *models.Session) error {
+	now := time.Now()
+	if now.Sub(session.LastSeenAt) < sessionActivityInterval {
+		return nil
+	}
+	return repo.Touch(session, now)
+}
+
+func issueTokens(repo *repository.SessionRepository, user *models.User, familyID string, startedAt time.Time, client SessionClient, refreshExpiry time.Duration) (*TokenPair, error) {
+	refreshToken, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	session := &models.Session{
+		UserID:     user.ID,
+		FamilyID:   familyID,
+		Token:      utils.HashToken(refreshToken),
+		UserAgent:  client.UserAgent,
+		IPAddress:  client.IPAddress,
+		StartedAt:  startedAt,
+		LastSeenAt: now,
+		ExpiresAt:  now.Add(refreshExpiry),
+	}
+	if err := repo.Create(session); err != nil {
+		return nil, err
+	}
+
+	accessToken, err := jwt.GenerateToken(user.ID, user.Email, user.Role, session.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TokenPair{AccessToken: accessToken, RefreshToken: refreshToken}, nil
+}
@@ -0,0 +1,159 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	"school-erp-backend/internal/models"
+	"school-erp-backend/internal/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrStudentLeaveOverlap = errors.New("leave overlaps another pending or approved request of the student")
+
+// StudentLeavePolicy applies for and decides student absences. Approving a
+// request excuses the absences already marked on its days; absences marked
+// later are excused when attendance is taken.
+type StudentLeavePolicy struct {
+	db         *gorm.DB
+	school     *SchoolCalendar
+	startMonth int
+}
+
+// NewStudentLeavePolicy returns the student leave policy of a school whose
+// academic years start in startMonth
+func NewStudentLeavePolicy(db *gorm.DB, school *SchoolCalendar, startMonth int) *StudentLeavePolicy {
+	return &StudentLeavePolicy{db: db, school: school, startMonth: startMonth}
+}
+
+// Apply checks a new request and stores it as pending. The request must fall
+// within one academic year, cover school days and not overlap the student's
+// other requests, which is checked with the student locked.
+func (p *StudentLeavePolicy) Apply(request *models.StudentLeaveRequest) error {
+	request.AcademicYear = CurrentAcademicYear(request.StartDate, p.startMonth)
+	if CurrentAcademicYear(request.EndDate, p.startMonth) != request.AcademicYear {
+		return ErrLeaveSpansYears
+	}
+	if err := p.countSchoolDays(request); err != nil {
+		return err
+	}
+	if request.SchoolDays == 0 {
+		return ErrNoWorkingDays
+	}
+
+	return p.db.Transaction(func(tx *gorm.DB) error {
+		var student models.Student
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&student, request.StudentID).Error; err != nil {
+			return err
+		}
+
+		requestRepo := repository.NewStudentLeaveRequestRepository(tx)
+		overlapping, err := requestRepo.FindOverlapping(request.StudentID, request.StartDate, request.EndDate)
+		if err != nil {
+			return err
+		}
+		if len(overlapping) > 0 {
+			return fmt.Errorf("%w (request %d)", ErrStudentLeaveOverlap, overlapping[0].ID)
+		}
+
+		request.Status = "pending"
+		request.DecidedBy = nil
+		request.DecidedAt = nil
+		return requestRepo.Create(request)
+	})
+}
+
+// Decide approves or rejects a pending request on behalf of deciderID and
+// returns how many absences the approval excused.
+func (p *StudentLeavePolicy) Decide(request *models.StudentLeaveRequest, approve bool, deciderID uint, comment string, at time.Time) (int64, error) {
+	if request.Status != "pending" {
+		return 0, ErrLeaveNotPending
+	}
+
+	status := "rejected"
+	if approve {
+		status = "approved"
+		if err := p.countSchoolDays(request); err != nil {
+			return 0, err
+		}
+	}
+
+	var excused int64
+	err := p.db.Transaction(func(tx *gorm.DB) error {
+		decided, err := repository.NewStudentLeaveRequestRepository(tx).Decide(request, status, &deciderID, comment, at)
+		if err != nil {
+			return err
+		}
+		if !decided {
+			return ErrLeaveNotPending
+		}
+		if approve {
+			excused, err = repository.NewAttendanceRepository(tx).ExcuseAbsences(request.StudentID, request.StartDate, request.EndDate)
+		}
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	request.Status = status
+	request.DecidedBy = &deciderID
+	request.DecidedAt = &at
+	request.DecisionComment = comment
+	return excused, nil
+}
+
+// Cancel withdraws a pending request
+func (p *StudentLeavePolicy) Cancel(request *models.StudentLeaveRequest, at time.Time) error {
+	if request.Status != "pending" {
+		return ErrLeaveNotPending
+	}
+	cancelled, err := repository.NewStudentLeaveRequestRepository(p.db).Decide(request, "cancelled", nil, "", at)
+	if err != nil {
+		return err
+	}
+	if !cancelled {
+		return ErrLeaveNotPending
+	}
+	request.Status = "cancelled"
+	request.DecidedAt = &at
+	return nil
+}
+
+// ExcuseOnLeave marks the absences among attendance records of one date as
+// excused for the students with approved leave that day.
+func ExcuseOnLeave(db *gorm.DB, records []models.Attendance) error {
+	if len(records) == 0 {
+		return nil
+	}
+	studentIDs := make([]uint, 0, len(records))
+	for _, record := range records {
+		studentIDs = append(studentIDs, record.StudentID)
+	}
+
+	onLeave, err := repository.NewStudentLeaveRequestRepository(db).StudentsOnLeave(studentIDs, records[0].Date)
+	if err != nil {
+		return err
+	}
+	excused := make(map[uint]bool, len(onLeave))
+	for _, id := range onLeave {
+		excused[id] = true
+	}
+	for i := range records {
+		if records[i].Status == "absent" && excused[records[i].StudentID] {
+			records[i].Status = "excused"
+		}
+	}
+	return nil
+}
+
+// countSchoolDays sets the school days of a request from the calendar
+func (p *StudentLeavePolicy) countSchoolDays(request *models.StudentLeaveRequest) error {
+	days, err := p.school.SchoolDays(request.StartDate, request.EndDate)
+	if err != nil {
+		return err
+	}
+	request.SchoolDays = len(days)
+	return nil
+}
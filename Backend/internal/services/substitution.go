@@ -0,0 +1,345 @@
+package services
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"school-erp-backend/internal/models"
+	"school-erp-backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrPeriodNotAffected = errors.New("the teacher of this period is not on approved leave on this school day")
+	ErrPeriodCovered     = errors.New("period already has a substitute on this date")
+	ErrSubstituteNotFree = errors.New("substitute is not free for this period")
+)
+
+// AffectedPeriod is a timetable period on a school day whose teacher is on
+// approved leave.
+type AffectedPeriod struct {
+	Date           string               `json:"date"`
+	Slot           models.Timetable     `json:"slot"`
+	LeaveRequestID uint                 `json:"leave_request_id"`
+	Substitution   *models.Substitution `json:"substitution"` // nil while the period is uncovered
+}
+
+// SubstituteSuggestion is a teacher free to take an affected period
+type SubstituteSuggestion struct {
+	Teacher     models.Teacher `json:"teacher"`
+	SameSubject bool           `json:"same_subject"` // the specialization of the teacher is the subject of the period
+	Periods     int            `json:"periods"`      // periods the teacher already has that day, substitutions included
+}
+
+// TeacherDaySchedule is what a teacher teaches on one date: their own
+// periods, unless they are on leave, and the periods they cover for others.
+type TeacherDaySchedule struct {
+	TeacherID uint              `json:"teacher_id"`
+	Date      string            `json:"date"`
+	Day       string            `json:"day"`
+	SchoolDay bool              `json:"school_day"`
+	OnLeave   bool              `json:"on_leave"`
+	Periods   []ScheduledPeriod `json:"periods"`
+}
+
+type ScheduledPeriod struct {
+	Slot         models.Timetable     `json:"slot"`
+	Substitution *models.Substitution `json:"substitution,omitempty"` // set on the periods covered for another teacher
+}
+
+// SubstitutePlanner finds the periods left uncovered by approved teacher
+// leave and assigns them to free teachers.
+type SubstitutePlanner struct {
+	db         *gorm.DB
+	school     *SchoolCalendar
+	startMonth int
+}
+
+// NewSubstitutePlanner returns the planner of a school whose academic years
+// start in startMonth
+func NewSubstitutePlanner(db *gorm.DB, school *SchoolCalendar, startMonth int) *SubstitutePlanner {
+	return &SubstitutePlanner{db: db, school: school, startMonth: startMonth}
+}
+
+// AffectedPeriods returns the periods from from to to, both included, that
+// teachers on approved leave would have taught, by date and time. A non-zero
+// teacherID keeps the periods of that teacher.
+func (p *SubstitutePlanner) AffectedPeriods(from, to time.Time, teacherID uint) ([]AffectedPeriod, error) {
+	from, to = dateOnly(from), dateOnly(to)
+	leaves, err := repository.NewLeaveRequestRepository(p.db).FindAll(models.LeaveRequest{TeacherID: teacherID, Status: "approved"}, from, to)
+	if err != nil {
+		return nil, err
+	}
+	days, err := p.school.SchoolDays(from, to)
+	if err != nil {
+		return nil, err
+	}
+	substitutions, err := repository.NewSubstitutionRepository(p.db).FindAll(models.Substitution{AbsentTeacherID: teacherID}, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	covered := make(map[string]*models.Substitution, len(substitutions))
+	for i := range substitutions {
+		covered[periodKey(substitutions[i].Date, substitutions[i].TimetableID)] = &substitutions[i]
+	}
+
+	timetableRepo := repository.NewTimetableRepository(p.db)
+	teacherSlots := make(map[string][]models.Timetable)
+	periods := []AffectedPeriod{}
+	for _, day := range days {
+		academicYear := CurrentAcademicYear(day, p.startMonth)
+		for _, leave := range leaves {
+			if day.Before(dateOnly(leave.StartDate)) || day.After(dateOnly(leave.EndDate)) {
+				continue
+			}
+
+			key := academicYear + "/" + strconv.FormatUint(uint64(leave.TeacherID), 10)
+			slots, ok := teacherSlots[key]
+			if !ok {
+				if slots, err = timetableRepo.FindByTeacher(leave.TeacherID, academicYear); err != nil {
+					return nil, err
+				}
+				teacherSlots[key] = slots
+			}
+
+			for _, slot := range slots {
+				if slot.Day != day.Weekday().String() {
+					continue
+				}
+				slot.Teacher = leave.Teacher
+				periods = append(periods, AffectedPeriod{
+					Date:           day.Format("2006-01-02"),
+					Slot:           slot,
+					LeaveRequestID: leave.ID,
+					Substitution:   covered[periodKey(day, slot.ID)],
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(periods, func(i, j int) bool {
+		a, b := periods[i], periods[j]
+		if a.Date != b.Date {
+			return a.Date < b.Date
+		}
+		if a.Slot.StartTime != b.Slot.StartTime {
+			return a.Slot.StartTime < b.Slot.StartTime
+		}
+		return a.Slot.PeriodNumber < b.Slot.PeriodNumber
+	})
+	return periods, nil
+}
+
+// Suggestions returns the teachers free to take an affected period on a date:
+// active, not on leave and without a period of their own or a substitution
+// at that time. Teachers specialized in the subject of the period come first,
+// then those with the fewest periods that day.
+func (p *SubstitutePlanner) Suggestions(date time.Time, timetableID uint) ([]SubstituteSuggestion, error) {
+	slot, _, err := p.affectedPeriod(date, timetableID)
+	if err != nil {
+		return nil, err
+	}
+	return p.suggest(dateOnly(date), slot)
+}
+
+// Assign records a substitute for an uncovered affected period. The
+// substitute must be one of the suggested teachers.
+func (p *SubstitutePlanner) Assign(substitution *models.Substitution) error {
+	date := dateOnly(substitution.Date)
+	slot, leave, err := p.affectedPeriod(date, substitution.TimetableID)
+	if err != nil {
+		return err
+	}
+
+	substitutionRepo := repository.NewSubstitutionRepository(p.db)
+	if _, err := substitutionRepo.FindByPeriod(date, slot.ID); err == nil {
+		return ErrPeriodCovered
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	suggestions, err := p.suggest(date, slot)
+	if err != nil {
+		return err
+	}
+	free := false
+	for _, suggestion := range suggestions {
+		if suggestion.Teacher.ID == substitution.SubstituteTeacherID {
+			free = true
+			break
+		}
+	}
+	if !free {
+		return ErrSubstituteNotFree
+	}
+
+	substitution.Date = date
+	substitution.AbsentTeacherID = slot.TeacherID
+	substitution.LeaveRequestID = &leave.ID
+	return substitutionRepo.Create(substitution)
+}
+
+// DaySchedule returns what a teacher teaches on a date, by time
+func (p *SubstitutePlanner) DaySchedule(teacherID uint, date time.Time) (*TeacherDaySchedule, error) {
+	date = dateOnly(date)
+	schedule := &TeacherDaySchedule{
+		TeacherID: teacherID,
+		Date:      date.Format("2006-01-02"),
+		Day:       date.Weekday().String(),
+		Periods:   []ScheduledPeriod{},
+	}
+
+	open, err := p.school.IsSchoolDay(date)
+	if err != nil {
+		return nil, err
+	}
+	schedule.SchoolDay = open
+	if !open {
+		return schedule, nil
+	}
+
+	leaves, err := repository.NewLeaveRequestRepository(p.db).FindAll(models.LeaveRequest{TeacherID: teacherID, Status: "approved"}, date, date)
+	if err != nil {
+		return nil, err
+	}
+	schedule.OnLeave = len(leaves) > 0
+	if !schedule.OnLeave {
+		slots, err := repository.NewTimetableRepository(p.db).FindByTeacher(teacherID, CurrentAcademicYear(date, p.startMonth))
+		if err != nil {
+			return nil, err
+		}
+		for _, slot := range slots {
+			if slot.Day == schedule.Day {
+				schedule.Periods = append(schedule.Periods, ScheduledPeriod{Slot: slot})
+			}
+		}
+	}
+
+	substitutions, err := repository.NewSubstitutionRepository(p.db).FindAll(models.Substitution{SubstituteTeacherID: teacherID}, date, date)
+	if err != nil {
+		return nil, err
+	}
+	for i := range substitutions {
+		schedule.Periods = append(schedule.Periods, ScheduledPeriod{Slot: substitutions[i].Timetable, Substitution: &substitutions[i]})
+	}
+
+	sort.SliceStable(schedule.Periods, func(i, j int) bool {
+		a, b := schedule.Periods[i].Slot, schedule.Periods[j].Slot
+		if a.StartTime != b.StartTime {
+			return a.StartTime < b.StartTime
+		}
+		return a.PeriodNumber < b.PeriodNumber
+	})
+	return schedule, nil
+}
+
+// affectedPeriod loads a timetable slot and the approved leave of its teacher
+// on a date, failing with ErrPeriodNotAffected when the slot is not taught
+// that day or its teacher is not on leave.
+func (p *SubstitutePlanner) affectedPeriod(date time.Time, timetableID uint) (*models.Timetable, *models.LeaveRequest, error) {
+	date = dateOnly(date)
+	slot, err := repository.NewTimetableRepository(p.db).FindByID(timetableID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if slot.Day != date.Weekday().String() || slot.AcademicYear != CurrentAcademicYear(date, p.startMonth) {
+		return nil, nil, ErrPeriodNotAffected
+	}
+
+	open, err := p.school.IsSchoolDay(date)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !open {
+		return nil, nil, ErrPeriodNotAffected
+	}
+
+	leaves, err := repository.NewLeaveRequestRepository(p.db).FindAll(models.LeaveRequest{TeacherID: slot.TeacherID, Status: "approved"}, date, date)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(leaves) == 0 {
+		return nil, nil, ErrPeriodNotAffected
+	}
+	return slot, &leaves[0], nil
+}
+
+func (p *SubstitutePlanner) suggest(date time.Time, slot *models.Timetable) ([]SubstituteSuggestion, error) {
+	daySlots, err := repository.NewTimetableRepository(p.db).FindByDay(slot.AcademicYear, slot.Day)
+	if err != nil {
+		return nil, err
+	}
+	substitutions, err := repository.NewSubstitutionRepository(p.db).FindAll(models.Substitution{}, date, date)
+	if err != nil {
+		return nil, err
+	}
+	onLeave, err := repository.NewLeaveRequestRepository(p.db).FindAll(models.LeaveRequest{Status: "approved"}, date, date)
+	if err != nil {
+		return nil, err
+	}
+	teachers, err := repository.NewTeacherRepository(p.db).FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	busy := make(map[uint]bool)
+	periods := make(map[uint]int)
+	for _, other := range daySlots {
+		periods[other.TeacherID]++
+		if periodsOverlap(*slot, other) {
+			busy[other.TeacherID] = true
+		}
+	}
+	for _, substitution := range substitutions {
+		periods[substitution.SubstituteTeacherID]++
+		if periodsOverlap(*slot, substitution.Timetable) {
+			busy[substitution.SubstituteTeacherID] = true
+		}
+	}
+	for _, leave := range onLeave {
+		busy[leave.TeacherID] = true
+	}
+
+	suggestions := []SubstituteSuggestion{}
+	for _, teacher := range teachers {
+		if teacher.ID == slot.TeacherID || busy[teacher.ID] || teacher.Status != "active" {
+			continue
+		}
+		suggestions = append(suggestions, SubstituteSuggestion{
+			Teacher:     teacher,
+			SameSubject: specializedIn(teacher.SubjectSpecialization, slot.Subject),
+			Periods:     periods[teacher.ID],
+		})
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		a, b := suggestions[i], suggestions[j]
+		if a.SameSubject != b.SameSubject {
+			return a.SameSubject
+		}
+		if a.Periods != b.Periods {
+			return a.Periods < b.Periods
+		}
+		return a.Teacher.FirstName+" "+a.Teacher.LastName < b.Teacher.FirstName+" "+b.Teacher.LastName
+	})
+	return suggestions, nil
+}
+
+// specializedIn reports whether a specialization, which may list several
+// subjects separated by commas or slashes, names a subject or its code.
+func specializedIn(specialization string, subject models.Subject) bool {
+	for _, name := range strings.FieldsFunc(specialization, func(r rune) bool { return r == ',' || r == '/' }) {
+		name = strings.TrimSpace(name)
+		if name != "" && (strings.EqualFold(name, subject.Name) || strings.EqualFold(name, subject.Code)) {
+			return true
+		}
+	}
+	return false
+}
+
+func periodKey(date time.Time, timetableID uint) string {
+	return dateOnly(date).Format("2006-01-02") + "/" + strconv.FormatUint(uint64(timetableID), 10)
+}
@@ -0,0 +1,140 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"school-erp-backend/internal/models"
+)
+
+// Weekdays in timetable order
+var Weekdays = []string{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday", "Sunday"}
+
+type TimetableClash struct {
+	Type string           `json:"type"` // teacher, room, section
+	Slot models.Timetable `json:"slot"`
+}
+
+type TimetableDay struct {
+	Day     string             `json:"day"`
+	Periods []models.Timetable `json:"periods"`
+}
+
+// NormalizeDay returns the canonical spelling of a weekday name ("monday" -> "Monday").
+func NormalizeDay(day string) (string, bool) {
+	for _, weekday := range Weekdays {
+		if strings.EqualFold(strings.TrimSpace(day), weekday) {
+			return weekday, true
+		}
+	}
+	return "", false
+}
+
+// ParseClock converts "HH:MM" to minutes after midnight.
+func ParseClock(value string) (int, error) {
+	parsed, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q. Use HH:MM", value)
+	}
+	return parsed.Hour()*60 + parsed.Minute(), nil
+}
+
+// ValidateTimetableSlot normalizes the day and room of a slot and checks its period and times.
+func ValidateTimetableSlot(slot *models.Timetable) error {
+	day, ok := NormalizeDay(slot.Day)
+	if !ok {
+		return fmt.Errorf("invalid day %q", slot.Day)
+	}
+	slot.Day = day
+	slot.RoomNumber = strings.TrimSpace(slot.RoomNumber)
+
+	if slot.PeriodNumber < 1 {
+		return errors.New("period number must be at least 1")
+	}
+	start, err := ParseClock(slot.StartTime)
+	if err != nil {
+		return err
+	}
+	end, err := ParseClock(slot.EndTime)
+	if err != nil {
+		return err
+	}
+	if start >= end {
+		return errors.New("start time must be before end time")
+	}
+	return nil
+}
+
+// FindTimetableClashes returns the existing slots that cannot run alongside
+// slot: those of the same academic year and day that share the period number
+// or overlap in time, and have the same teacher, room or section. Existing
+// slots with the ID of slot are ignored so an update does not clash with itself.
+func FindTimetableClashes(slot models.Timetable, existing []models.Timetable) []TimetableClash {
+	var clashes []TimetableClash
+	for _, other := range existing {
+		if other.ID == slot.ID && slot.ID != 0 {
+			continue
+		}
+		if other.AcademicYear != slot.AcademicYear || other.Day != slot.Day || !periodsOverlap(slot, other) {
+			continue
+		}
+
+		if other.TeacherID == slot.TeacherID {
+			clashes = append(clashes, TimetableClash{Type: "teacher", Slot: other})
+		}
+		if slot.RoomNumber != "" && strings.EqualFold(other.RoomNumber, slot.RoomNumber) {
+			clashes = append(clashes, TimetableClash{Type: "room", Slot: other})
+		}
+		if other.SectionID == slot.SectionID {
+			clashes = append(clashes, TimetableClash{Type: "section", Slot: other})
+		}
+	}
+	return clashes
+}
+
+// periodsOverlap reports whether two slots of the same day share the period
+// number or overlap in time.
+func periodsOverlap(a, b models.Timetable) bool {
+	if a.PeriodNumber == b.PeriodNumber {
+		return true
+	}
+	aStart, err1 := ParseClock(a.StartTime)
+	aEnd, err2 := ParseClock(a.EndTime)
+	bStart, err3 := ParseClock(b.StartTime)
+	bEnd, err4 := ParseClock(b.EndTime)
+	if err1 != nil || err2 != nil || err3 != nil || err4 != nil {
+		return false
+	}
+	return aStart < bEnd && bStart < aEnd
+}
+
+// WeekGrid groups slots by weekday, in weekday order and by period within a
+// day. Every weekday from Monday to Saturday is present, Sunday only when it
+// has slots.
+func WeekGrid(slots []models.Timetable) []TimetableDay {
+	byDay := make(map[string][]models.Timetable)
+	for _, slot := range slots {
+		byDay[slot.Day] = append(byDay[slot.Day], slot)
+	}
+
+	grid := make([]TimetableDay, 0, len(Weekdays))
+	for _, day := range Weekdays {
+		periods := byDay[day]
+		if day == "Sunday" && len(periods) == 0 {
+			continue
+		}
+		sort.Slice(periods, func(i, j int) bool {
+			if periods[i].PeriodNumber != periods[j].PeriodNumber {
+				return periods[i].PeriodNumber < periods[j].PeriodNumber
+			}
+			return periods[i].StartTime < periods[j].StartTime
+		})
+		if periods == nil {
+			periods = []models.Timetable{}
+		}
+		grid = append(grid, TimetableDay{Day: day, Periods: periods})
+	}
+	return grid
+}
@@ -0,0 +1,986 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"school-erp-backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// timetableSearchLimit bounds the number of repair steps the solver takes
+// before it gives up on a set of requirements.
+const timetableSearchLimit = 200000
+
+// TimetableRequirements describe what the generator has to schedule
+type TimetableRequirements struct {
+	AcademicYear string                `json:"academic_year" binding:"required"`
+	Days         []string              `json:"days" binding:"required,min=1"`
+	Periods      []TimetablePeriod     `json:"periods" binding:"required,min=1,dive"`
+	Sections     []SectionRequirement  `json:"sections" binding:"required,min=1,dive"`
+	Teachers     []TeacherAvailability `json:"teachers" binding:"required,min=1,dive"`
+	Rooms        []RoomRequirement     `json:"rooms" binding:"dive"`
+}
+
+type TimetablePeriod struct {
+	PeriodNumber int    `json:"period_number" binding:"required,min=1"`
+	StartTime    string `json:"start_time" binding:"required"` // HH:MM
+	EndTime      string `json:"end_time" binding:"required"`   // HH:MM
+}
+
+type SectionRequirement struct {
+	ClassID    uint                 `json:"class_id" binding:"required"`
+	SectionID  uint                 `json:"section_id" binding:"required"`
+	RoomNumber string               `json:"room_number"`             // home room, used by subjects without dedicated rooms
+	Subjects   []SubjectRequirement `json:"subjects" binding:"dive"` // defaults to the core subjects of the class curriculum
+}
+
+type SubjectRequirement struct {
+	SubjectID      uint `json:"subject_id" binding:"required"`
+	PeriodsPerWeek int  `json:"periods_per_week" binding:"required,min=1"`
+	TeacherID      uint `json:"teacher_id"` // optional, otherwise an eligible teacher is chosen
+}
+
+type TeacherAvailability struct {
+	TeacherID        uint               `json:"teacher_id" binding:"required"`
+	SubjectIDs       []uint             `json:"subject_ids"`
+	MaxPeriodsPerDay int                `json:"max_periods_per_day" binding:"gte=0"` // 0 for no limit
+	Unavailable      []TimetableSlotRef `json:"unavailable" binding:"dive"`
+}
+
+type TimetableSlotRef struct {
+	Day          string `json:"day" binding:"required"`
+	PeriodNumber int    `json:"period_number" binding:"required"`
+}
+
+// RoomRequirement is a dedicated room such as a lab. Lessons of its subjects
+// are only held in dedicated rooms.
+type RoomRequirement struct {
+	RoomNumber string `json:"room_number" binding:"required"`
+	SubjectIDs []uint `json:"subject_ids" binding:"required,min=1"`
+}
+
+type TimetableGenerationResult struct {
+	AcademicYear string             `json:"academic_year"`
+	SectionIDs   []uint             `json:"section_ids"`
+	Feasible     bool               `json:"feasible"`
+	Problems     []string           `json:"problems,omitempty"`
+	Steps        int                `json:"steps"`
+	Slots        []models.Timetable `json:"slots"`
+}
+
+var ErrSectionsHaveTimetable = errors.New("some sections already have a timetable for this academic year")
+
+// TimetableClashError is returned by CommitGeneratedTimetable when the
+// timetable changed after generation and a generated slot now clashes.
+type TimetableClashError struct {
+	Clashes []TimetableClash
+}
+
+func (e *TimetableClashError) Error() string {
+	return fmt.Sprintf("generated timetable clashes with %d existing slots", len(e.Clashes))
+}
+
+// ValidateTimetableRequirements normalizes day names and checks the
+// requirements for inconsistencies such as unknown periods or duplicates.
+func ValidateTimetableRequirements(req *TimetableRequirements) error {
+	seenDays := make(map[string]bool)
+	for i, value := range req.Days {
+		day, ok := NormalizeDay(value)
+		if !ok {
+			return fmt.Errorf("invalid day %q", value)
+		}
+		if seenDays[day] {
+			return fmt.Errorf("day %s is listed twice", day)
+		}
+		seenDays[day] = true
+		req.Days[i] = day
+	}
+
+	periods := make(map[int]bool)
+	for _, period := range req.Periods {
+		if periods[period.PeriodNumber] {
+			return fmt.Errorf("period %d is listed twice", period.PeriodNumber)
+		}
+		periods[period.PeriodNumber] = true
+		err := ValidateTimetableSlot(&models.Timetable{Day: "Monday", PeriodNumber: period.PeriodNumber, StartTime: period.StartTime, EndTime: period.EndTime})
+		if err != nil {
+			return fmt.Errorf("period %d: %v", period.PeriodNumber, err)
+		}
+	}
+
+	sections := make(map[uint]bool)
+	for _, section := range req.Sections {
+		if sections[section.SectionID] {
+			return fmt.Errorf("section %d is listed twice", section.SectionID)
+		}
+		sections[section.SectionID] = true
+		if len(section.Subjects) == 0 {
+			return fmt.Errorf("section %d has no subjects", section.SectionID)
+		}
+
+		subjects := make(map[uint]bool)
+		for _, subject := range section.Subjects {
+			if subjects[subject.SubjectID] {
+				return fmt.Errorf("subject %d is listed twice for section %d", subject.SubjectID, section.SectionID)
+			}
+			subjects[subject.SubjectID] = true
+		}
+	}
+
+	teachers := make(map[uint]bool)
+	for i, teacher := range req.Teachers {
+		if teachers[teacher.TeacherID] {
+			return fmt.Errorf("teacher %d is listed twice", teacher.TeacherID)
+		}
+		teachers[teacher.TeacherID] = true
+
+		for j, slot := range teacher.Unavailable {
+			day, ok := NormalizeDay(slot.Day)
+			if !ok {
+				return fmt.Errorf("teacher %d: invalid day %q", teacher.TeacherID, slot.Day)
+			}
+			if !periods[slot.PeriodNumber] {
+				return fmt.Errorf("teacher %d: unknown period %d", teacher.TeacherID, slot.PeriodNumber)
+			}
+			req.Teachers[i].Unavailable[j].Day = day
+		}
+	}
+
+	rooms := make(map[string]bool)
+	for i, room := range req.Rooms {
+		number := strings.TrimSpace(room.RoomNumber)
+		if rooms[strings.ToLower(number)] {
+			return fmt.Errorf("room %s is listed twice", number)
+		}
+		rooms[strings.ToLower(number)] = true
+		req.Rooms[i].RoomNumber = number
+	}
+
+	for _, section := range req.Sections {
+		for _, subject := range section.Subjects {
+			if subject.TeacherID != 0 && !teachers[subject.TeacherID] {
+				return fmt.Errorf("section %d: teacher %d of subject %d is not in the teachers list", section.SectionID, subject.TeacherID, subject.SubjectID)
+			}
+		}
+	}
+	return nil
+}
+
+// lessonGroup is the set of weekly lessons of one subject in one section
+type lessonGroup struct {
+	section   int // index into sections
+	subjectID uint
+	teacher   int // index into teachers
+	lessons   int
+	homeRoom  int   // index into rooms, -1 for none
+	rooms     []int // dedicated rooms, empty to use the home room
+	perDayCap int
+}
+
+// Kinds of constraint terms scored by the solver
+const (
+	termTeacherSlot = iota // a teacher teaches two lessons at once
+	termTeacherDay         // a teacher exceeds the daily maximum
+	termGroupDay           // a subject has too many lessons on one day
+	termRoomSlot           // a room is used twice at once
+	termDedicated          // dedicated rooms cannot host all lessons of a slot
+)
+
+type solverTerm struct {
+	kind, a, b int
+}
+
+// timetableSolver places the lessons of every section into the section's
+// slots of the week and repairs clashes by local search. A state is scored by
+// its constraint violations; a timetable with no violations is a solution.
+type timetableSolver struct {
+	req      *TimetableRequirements
+	days     int
+	periods  []TimetablePeriod
+	groups   []lessonGroup
+	teachers []TeacherAvailability
+	rooms    []string
+
+	cells        [][]int // section x slot, group index or -1 when free
+	blocked      [][]bool
+	teacherCount [][]int // teacher x slot, including blocked slots
+	teacherDay   [][]int
+	groupDay     [][]int
+	roomBase     [][]int // room x slot, rooms used by existing slots
+	roomUse      [][]int // roomBase plus the home rooms of lessons
+	dedicated    []int   // violation of termDedicated per slot
+	isDedicated  []bool
+
+	roomIndex map[string]int
+	termBuf   []solverTerm
+	steps     int
+}
+
+// GenerateTimetable schedules the lessons described by req. Slots in
+// existing that belong to sections outside req are kept and block their
+// teachers and rooms. Requirements that cannot be met are reported in
+// Problems with Feasible set to false.
+func GenerateTimetable(req *TimetableRequirements, existing []models.Timetable) *TimetableGenerationResult {
+	result := &TimetableGenerationResult{AcademicYear: req.AcademicYear, Slots: []models.Timetable{}}
+	for _, section := range req.Sections {
+		result.SectionIDs = append(result.SectionIDs, section.SectionID)
+	}
+
+	solver := newTimetableSolver(req, existing)
+	if problems := solver.assignTeachers(); len(problems) > 0 {
+		result.Problems = problems
+		return result
+	}
+
+	solved := solver.search()
+	result.Steps = solver.steps
+	if !solved {
+		result.Problems = solver.violations()
+		return result
+	}
+
+	result.Feasible = true
+	result.Slots = solver.slots()
+	return result
+}
+
+func newTimetableSolver(req *TimetableRequirements, existing []models.Timetable) *timetableSolver {
+	periods := make([]TimetablePeriod, len(req.Periods))
+	copy(periods, req.Periods)
+	sort.Slice(periods, func(i, j int) bool { return periods[i].PeriodNumber < periods[j].PeriodNumber })
+
+	s := &timetableSolver{
+		req:       req,
+		days:      len(req.Days),
+		periods:   periods,
+		teachers:  req.Teachers,
+		roomIndex: make(map[string]int),
+	}
+	slots := s.slotCount()
+
+	s.blocked = make([][]bool, len(req.Teachers))
+	for i, teacher := range req.Teachers {
+		s.blocked[i] = make([]bool, slots)
+		for _, unavailable := range teacher.Unavailable {
+			if slot, ok := s.slotOf(unavailable.Day, unavailable.PeriodNumber); ok {
+				s.blocked[i][slot] = true
+			}
+		}
+	}
+
+	for _, section := range req.Sections {
+		if section.RoomNumber != "" {
+			s.room(section.RoomNumber)
+		}
+	}
+	for _, room := range req.Rooms {
+		s.room(room.RoomNumber)
+	}
+
+	// Existing slots of other sections keep their teachers and rooms busy
+	generated := make(map[uint]bool)
+	for _, section := range req.Sections {
+		generated[section.SectionID] = true
+	}
+	teacherIndex := make(map[uint]int)
+	for i, teacher := range req.Teachers {
+		teacherIndex[teacher.TeacherID] = i
+	}
+	var busyRooms [][2]int
+	for _, slot := range existing {
+		if generated[slot.SectionID] || slot.AcademicYear != req.AcademicYear {
+			continue
+		}
+		for _, index := range s.overlappingSlots(slot) {
+			if t, ok := teacherIndex[slot.TeacherID]; ok {
+				s.blocked[t][index] = true
+			}
+			if slot.RoomNumber != "" {
+				busyRooms = append(busyRooms, [2]int{s.room(slot.RoomNumber), index})
+			}
+		}
+	}
+
+	s.roomBase = make([][]int, len(s.rooms))
+	for i := range s.roomBase {
+		s.roomBase[i] = make([]int, slots)
+	}
+	for _, busy := range busyRooms {
+		s.roomBase[busy[0]][busy[1]] = 1
+	}
+
+	s.isDedicated = make([]bool, len(s.rooms))
+	for _, room := range req.Rooms {
+		s.isDedicated[s.room(room.RoomNumber)] = true
+	}
+	return s
+}
+
+func (s *timetableSolver) slotCount() int {
+	return s.days * len(s.periods)
+}
+
+// slotOf returns the slot index of a day and period number.
+func (s *timetableSolver) slotOf(day string, periodNumber int) (int, bool) {
+	for d, name := range s.req.Days {
+		if name != day {
+			continue
+		}
+		for p, period := range s.periods {
+			if period.PeriodNumber == periodNumber {
+				return d*len(s.periods) + p, true
+			}
+		}
+	}
+	return 0, false
+}
+
+func (s *timetableSolver) slotName(slot int) string {
+	return fmt.Sprintf("%s period %d", s.req.Days[slot/len(s.periods)], s.periods[slot%len(s.periods)].PeriodNumber)
+}
+
+// overlappingSlots returns the slot indexes that an existing timetable slot
+// occupies: periods with its number or overlapping its time on its day.
+func (s *timetableSolver) overlappingSlots(slot models.Timetable) []int {
+	start, err1 := ParseClock(slot.StartTime)
+	end, err2 := ParseClock(slot.EndTime)
+
+	var indexes []int
+	for d, day := range s.req.Days {
+		if day != slot.Day {
+			continue
+		}
+		for p, period := range s.periods {
+			periodStart, _ := ParseClock(period.StartTime)
+			periodEnd, _ := ParseClock(period.EndTime)
+			if period.PeriodNumber == slot.PeriodNumber || (err1 == nil && err2 == nil && start < periodEnd && periodStart < end) {
+				indexes = append(indexes, d*len(s.periods)+p)
+			}
+		}
+	}
+	return indexes
+}
+
+// room returns the index of a room, adding it when it is new.
+func (s *timetableSolver) room(number string) int {
+	key := strings.ToLower(number)
+	index, ok := s.roomIndex[key]
+	if !ok {
+		index = len(s.rooms)
+		s.roomIndex[key] = index
+		s.rooms = append(s.rooms, number)
+	}
+	return index
+}
+
+func (s *timetableSolver) freeSlots(teacher int) int {
+	free := 0
+	for _, blocked := range s.blocked[teacher] {
+		if !blocked {
+			free++
+		}
+	}
+	if limit := s.teachers[teacher].MaxPeriodsPerDay; limit > 0 && limit*s.days < free {
+		free = limit * s.days
+	}
+	return free
+}
+
+// assignTeachers picks a teacher for every subject of every section, most
+// constrained subjects first, giving each to the eligible teacher with the
+// most spare capacity. It also checks the counts that make a timetable
+// impossible regardless of placement.
+func (s *timetableSolver) assignTeachers() []string {
+	var problems []string
+	slotsPerWeek := s.slotCount()
+
+	eligible := make(map[uint][]int)
+	for i, teacher := range s.teachers {
+		for _, subjectID := range teacher.SubjectIDs {
+			eligible[subjectID] = append(eligible[subjectID], i)
+		}
+	}
+	teacherIndex := make(map[uint]int)
+	for i, teacher := range s.teachers {
+		teacherIndex[teacher.TeacherID] = i
+	}
+	dedicated := make(map[uint][]int)
+	for _, room := range s.req.Rooms {
+		for _, subjectID := range room.SubjectIDs {
+			dedicated[subjectID] = append(dedicated[subjectID], s.room(room.RoomNumber))
+		}
+	}
+
+	capacity := make([]int, len(s.teachers))
+	for i := range s.teachers {
+		capacity[i] = s.freeSlots(i)
+	}
+
+	type pending struct {
+		section int
+		subject SubjectRequirement
+	}
+	var queue []pending
+	for i, section := range s.req.Sections {
+		total := 0
+		for _, subject := range section.Subjects {
+			total += subject.PeriodsPerWeek
+			queue = append(queue, pending{section: i, subject: subject})
+		}
+		if total > slotsPerWeek {
+			problems = append(problems, fmt.Sprintf("Section %d needs %d periods a week but the week only has %d", section.SectionID, total, slotsPerWeek))
+		}
+	}
+
+	candidates := func(p pending) []int {
+		if p.subject.TeacherID != 0 {
+			return []int{teacherIndex[p.subject.TeacherID]}
+		}
+		return eligible[p.subject.SubjectID]
+	}
+	sort.SliceStable(queue, func(i, j int) bool {
+		ci, cj := len(candidates(queue[i])), len(candidates(queue[j]))
+		if ci != cj {
+			return ci < cj
+		}
+		return queue[i].subject.PeriodsPerWeek > queue[j].subject.PeriodsPerWeek
+	})
+
+	for _, p := range queue {
+		section := s.req.Sections[p.section]
+		options := candidates(p)
+		if len(options) == 0 {
+			problems = append(problems, fmt.Sprintf("Section %d: no teacher can teach subject %d", section.SectionID, p.subject.SubjectID))
+			continue
+		}
+
+		best := -1
+		for _, t := range options {
+			if best == -1 || capacity[t] > capacity[best] {
+				best = t
+			}
+		}
+		if capacity[best] < p.subject.PeriodsPerWeek {
+			ids := make([]string, len(options))
+			for i, t := range options {
+				ids[i] = fmt.Sprintf("%d", s.teachers[t].TeacherID)
+			}
+			problems = append(problems, fmt.Sprintf("Section %d: subject %d needs %d periods a week but its eligible teachers (%s) have at most %d free periods left",
+				section.SectionID, p.subject.SubjectID, p.subject.PeriodsPerWeek, strings.Join(ids, ", "), capacity[best]))
+			continue
+		}
+		capacity[best] -= p.subject.PeriodsPerWeek
+
+		group := lessonGroup{
+			section:   p.section,
+			subjectID: p.subject.SubjectID,
+			teacher:   best,
+			lessons:   p.subject.PeriodsPerWeek,
+			homeRoom:  -1,
+			rooms:     dedicated[p.subject.SubjectID],
+			perDayCap: (p.subject.PeriodsPerWeek + s.days - 1) / s.days,
+		}
+		if len(group.rooms) == 0 && section.RoomNumber != "" {
+			group.homeRoom = s.room(section.RoomNumber)
+		}
+		s.groups = append(s.groups, group)
+	}
+
+	// Dedicated rooms must have enough free periods for their subjects
+	demand := make(map[uint]int)
+	for _, group := range s.groups {
+		if len(group.rooms) > 0 {
+			demand[group.subjectID] += group.lessons
+		}
+	}
+	for subjectID, lessons := range demand {
+		free := 0
+		for _, room := range dedicated[subjectID] {
+			for _, used := range s.roomBase[room] {
+				if used == 0 {
+					free++
+				}
+			}
+		}
+		if lessons > free {
+			problems = append(problems, fmt.Sprintf("Subject %d needs its dedicated rooms for %d periods a week but they only have %d free periods", subjectID, lessons, free))
+		}
+	}
+
+	return problems
+}
+
+// search fills the week of every section with its lessons and then moves
+// lessons within their section until no constraint is violated. Each step
+// takes a lesson involved in a violation and swaps it with the slot of its
+// section that lowers the violations most, avoiding recently undone moves.
+// It returns false when timetableSearchLimit steps do not find a solution,
+// leaving the best timetable found in place.
+func (s *timetableSolver) search() bool {
+	slots := s.slotCount()
+	s.cells = make([][]int, len(s.req.Sections))
+	for i := range s.cells {
+		s.cells[i] = make([]int, slots)
+		for slot := range s.cells[i] {
+			s.cells[i][slot] = -1
+		}
+	}
+	next := make([]int, len(s.req.Sections))
+	for g, group := range s.groups {
+		for n := 0; n < group.lessons; n++ {
+			s.cells[group.section][next[group.section]] = g
+			next[group.section]++
+		}
+	}
+
+	s.rebuild()
+	cost := s.cost()
+	best, bestCells := cost, s.copyCells()
+
+	// A fixed seed makes the same requirements give the same timetable
+	random := rand.New(rand.NewSource(1))
+	tabu := make([][]int, len(s.groups))
+	for g := range tabu {
+		tabu[g] = make([]int, slots)
+	}
+
+	var conflicted [][2]int
+	for cost > 0 && s.steps < timetableSearchLimit {
+		s.steps++
+
+		conflicted = conflicted[:0]
+		for section := range s.cells {
+			for slot, g := range s.cells[section] {
+				if g >= 0 && s.conflicted(g, slot) {
+					conflicted = append(conflicted, [2]int{section, slot})
+				}
+			}
+		}
+		if len(conflicted) == 0 {
+			break
+		}
+		pick := conflicted[random.Intn(len(conflicted))]
+		section, from := pick[0], pick[1]
+		moving := s.cells[section][from]
+
+		to, delta, ties := -1, 0, 0
+		if random.Intn(50) == 0 {
+			to = random.Intn(slots)
+			if to != from && s.cells[section][to] != moving {
+				delta = s.swapDelta(section, from, to)
+			} else {
+				to = -1
+			}
+		} else {
+			for slot := 0; slot < slots; slot++ {
+				other := s.cells[section][slot]
+				if slot == from || other == moving {
+					continue
+				}
+				d := s.swapDelta(section, from, slot)
+				isTabu := tabu[moving][slot] > s.steps || (other >= 0 && tabu[other][from] > s.steps)
+				if isTabu && cost+d >= best {
+					continue
+				}
+				if to == -1 || d < delta {
+					to, delta, ties = slot, d, 1
+				} else if d == delta {
+					ties++
+					if random.Intn(ties) == 0 {
+						to = slot
+					}
+				}
+			}
+		}
+		if to == -1 {
+			continue
+		}
+
+		other := s.cells[section][to]
+		s.swap(section, from, to)
+		cost += delta
+		tenure := 10 + random.Intn(10)
+		tabu[moving][from] = s.steps + tenure
+		if other >= 0 {
+			tabu[other][to] = s.steps + tenure
+		}
+
+		if cost < best {
+			best, bestCells = cost, s.copyCells()
+		}
+	}
+
+	if cost > 0 {
+		s.cells = bestCells
+		s.rebuild()
+	}
+	return best == 0
+}
+
+func (s *timetableSolver) copyCells() [][]int {
+	cells := make([][]int, len(s.cells))
+	for i := range s.cells {
+		cells[i] = append([]int(nil), s.cells[i]...)
+	}
+	return cells
+}
+
+// rebuild recomputes the counters from the cells.
+func (s *timetableSolver) rebuild() {
+	slots := s.slotCount()
+	s.teacherCount = make([][]int, len(s.teachers))
+	s.teacherDay = make([][]int, len(s.teachers))
+	for t := range s.teachers {
+		s.teacherCount[t] = make([]int, slots)
+		s.teacherDay[t] = make([]int, s.days)
+		for slot, blocked := range s.blocked[t] {
+			if blocked {
+				s.teacherCount[t][slot] = 1
+			}
+		}
+	}
+	s.groupDay = make([][]int, len(s.groups))
+	for g := range s.groups {
+		s.groupDay[g] = make([]int, s.days)
+	}
+	s.roomUse = make([][]int, len(s.rooms))
+	for r := range s.roomBase {
+		s.roomUse[r] = append([]int(nil), s.roomBase[r]...)
+	}
+
+	for _, row := range s.cells {
+		for slot, g := range row {
+			if g >= 0 {
+				s.add(g, slot, 1)
+			}
+		}
+	}
+
+	s.dedicated = make([]int, slots)
+	for slot := range s.dedicated {
+		s.dedicated[slot] = s.dedicatedPenalty(slot)
+	}
+}
+
+// add counts lesson group g in slot, or removes it when delta is -1.
+func (s *timetableSolver) add(g, slot, delta int) {
+	group := s.groups[g]
+	day := slot / len(s.periods)
+	s.teacherCount[group.teacher][slot] += delta
+	s.teacherDay[group.teacher][day] += delta
+	s.groupDay[g][day] += delta
+	if group.homeRoom >= 0 {
+		s.roomUse[group.homeRoom][slot] += delta
+	}
+}
+
+// swap exchanges the contents of two slots of a section.
+func (s *timetableSolver) swap(section, a, b int) {
+	row := s.cells[section]
+	ga, gb := row[a], row[b]
+	if ga >= 0 {
+		s.add(ga, a, -1)
+		s.add(ga, b, 1)
+	}
+	if gb >= 0 {
+		s.add(gb, b, -1)
+		s.add(gb, a, 1)
+	}
+	row[a], row[b] = gb, ga
+
+	if s.affectsDedicated(ga) || s.affectsDedicated(gb) {
+		s.dedicated[a] = s.dedicatedPenalty(a)
+		s.dedicated[b] = s.dedicatedPenalty(b)
+	}
+}
+
+// affectsDedicated reports whether moving group g can change which
+// dedicated rooms are free.
+func (s *timetableSolver) affectsDedicated(g int) bool {
+	if g < 0 {
+		return false
+	}
+	group := s.groups[g]
+	return len(group.rooms) > 0 || (group.homeRoom >= 0 && s.isDedicated[group.homeRoom])
+}
+
+// swapDelta returns the change in violations if two slots of a section
+// were swapped.
+func (s *timetableSolver) swapDelta(section, a, b int) int {
+	terms := s.termBuf[:0]
+	for _, g := range []int{s.cells[section][a], s.cells[section][b]} {
+		if g < 0 {
+			continue
+		}
+		group := s.groups[g]
+		for _, slot := range []int{a, b} {
+			day := slot / len(s.periods)
+			terms = appendTerm(terms, solverTerm{termTeacherSlot, group.teacher, slot})
+			terms = appendTerm(terms, solverTerm{termTeacherDay, group.teacher, day})
+			terms = appendTerm(terms, solverTerm{termGroupDay, g, day})
+			if group.homeRoom >= 0 {
+				terms = appendTerm(terms, solverTerm{termRoomSlot, group.homeRoom, slot})
+			}
+			if s.affectsDedicated(g) {
+				terms = appendTerm(terms, solverTerm{termDedicated, slot, 0})
+			}
+		}
+	}
+	s.termBuf = terms
+
+	before := 0
+	for _, term := range terms {
+		before += s.violation(term)
+	}
+	s.swap(section, a, b)
+	after := 0
+	for _, term := range terms {
+		after += s.violation(term)
+	}
+	s.swap(section, a, b)
+	return after - before
+}
+
+func appendTerm(terms []solverTerm, term solverTerm) []solverTerm {
+	for _, existing := range terms {
+		if existing == term {
+			return terms
+		}
+	}
+	return append(terms, term)
+}
+
+// violation returns by how much a term is violated.
+func (s *timetableSolver) violation(term solverTerm) int {
+	excess := 0
+	switch term.kind {
+	case termTeacherSlot:
+		excess = s.teacherCount[term.a][term.b] - 1
+	case termTeacherDay:
+		if limit := s.teachers[term.a].MaxPeriodsPerDay; limit > 0 {
+			excess = s.teacherDay[term.a][term.b] - limit
+		}
+	case termGroupDay:
+		excess = s.groupDay[term.a][term.b] - s.groups[term.a].perDayCap
+	case termRoomSlot:
+		excess = s.roomUse[term.a][term.b] - 1
+	case termDedicated:
+		excess = s.dedicated[term.a]
+	}
+	if excess < 0 {
+		return 0
+	}
+	return excess
+}
+
+// allTerms lists every constraint term of the week.
+func (s *timetableSolver) allTerms() []solverTerm {
+	var terms []solverTerm
+	for slot := 0; slot < s.slotCount(); slot++ {
+		for t := range s.teachers {
+			terms = append(terms, solverTerm{termTeacherSlot, t, slot})
+		}
+		for r := range s.rooms {
+			terms = append(terms, solverTerm{termRoomSlot, r, slot})
+		}
+		terms = append(terms, solverTerm{termDedicated, slot, 0})
+	}
+	for day := 0; day < s.days; day++ {
+		for t := range s.teachers {
+			terms = append(terms, solverTerm{termTeacherDay, t, day})
+		}
+		for g := range s.groups {
+			terms = append(terms, solverTerm{termGroupDay, g, day})
+		}
+	}
+	return terms
+}
+
+func (s *timetableSolver) cost() int {
+	cost := 0
+	for _, term := range s.allTerms() {
+		cost += s.violation(term)
+	}
+	return cost
+}
+
+// conflicted reports whether the lesson of group g in slot takes part in a
+// violated term.
+func (s *timetableSolver) conflicted(g, slot int) bool {
+	group := s.groups[g]
+	day := slot / len(s.periods)
+	limit := s.teachers[group.teacher].MaxPeriodsPerDay
+	return s.teacherCount[group.teacher][slot] > 1 ||
+		(limit > 0 && s.teacherDay[group.teacher][day] > limit) ||
+		s.groupDay[g][day] > group.perDayCap ||
+		(group.homeRoom >= 0 && s.roomUse[group.homeRoom][slot] > 1) ||
+		(len(group.rooms) > 0 && s.dedicated[slot] > 0)
+}
+
+// matchRooms assigns free dedicated rooms to the lessons of a slot that
+// need one. It returns the room of every section's lesson, -1 when the
+// lesson has none, and the number of lessons left without a room.
+func (s *timetableSolver) matchRooms(slot int) ([]int, int) {
+	roomOf := make([]int, len(s.cells))
+	owner := make([]int, len(s.rooms))
+	for i := range roomOf {
+		roomOf[i] = -1
+	}
+	for r := range owner {
+		owner[r] = -1
+	}
+
+	var try func(section int, seen []bool) bool
+	try = func(section int, seen []bool) bool {
+		for _, r := range s.groups[s.cells[section][slot]].rooms {
+			if seen[r] || s.roomUse[r][slot] > 0 {
+				continue
+			}
+			seen[r] = true
+			if owner[r] == -1 || try(owner[r], seen) {
+				owner[r] = section
+				roomOf[section] = r
+				return true
+			}
+		}
+		return false
+	}
+
+	unmatched := 0
+	for section, row := range s.cells {
+		if g := row[slot]; g >= 0 && len(s.groups[g].rooms) > 0 {
+			if !try(section, make([]bool, len(s.rooms))) {
+				unmatched++
+			}
+		}
+	}
+	return roomOf, unmatched
+}
+
+func (s *timetableSolver) dedicatedPenalty(slot int) int {
+	_, unmatched := s.matchRooms(slot)
+	return unmatched
+}
+
+// violations describes the violated terms of the best timetable found.
+func (s *timetableSolver) violations() []string {
+	problems := []string{fmt.Sprintf("No timetable meeting every requirement was found in %d steps. The closest one found still has these problems:", s.steps)}
+	for _, term := range s.allTerms() {
+		excess := s.violation(term)
+		if excess == 0 {
+			continue
+		}
+		if len(problems) > 20 {
+			problems = append(problems, "...")
+			break
+		}
+
+		switch term.kind {
+		case termTeacherSlot:
+			teacher := s.teachers[term.a].TeacherID
+			if s.blocked[term.a][term.b] {
+				problems = append(problems, fmt.Sprintf("Teacher %d is unavailable or teaching another section on %s", teacher, s.slotName(term.b)))
+			} else {
+				problems = append(problems, fmt.Sprintf("Teacher %d is double-booked on %s", teacher, s.slotName(term.b)))
+			}
+		case termTeacherDay:
+			problems = append(problems, fmt.Sprintf("Teacher %d has %d periods on %s, more than the maximum of %d",
+				s.teachers[term.a].TeacherID, s.teacherDay[term.a][term.b], s.req.Days[term.b], s.teachers[term.a].MaxPeriodsPerDay))
+		case termGroupDay:
+			group := s.groups[term.a]
+			problems = append(problems, fmt.Sprintf("Subject %d of section %d has %d periods on %s, more than %d",
+				group.subjectID, s.req.Sections[group.section].SectionID, s.groupDay[term.a][term.b], s.req.Days[term.b], group.perDayCap))
+		case termRoomSlot:
+			problems = append(problems, fmt.Sprintf("Room %s is double-booked on %s", s.rooms[term.a], s.slotName(term.b)))
+		case termDedicated:
+			problems = append(problems, fmt.Sprintf("%d lessons on %s have no free dedicated room", excess, s.slotName(term.a)))
+		}
+	}
+	return problems
+}
+
+func (s *timetableSolver) slots() []models.Timetable {
+	var slots []models.Timetable
+	periodsPerDay := len(s.periods)
+	for slot := 0; slot < s.slotCount(); slot++ {
+		roomOf, _ := s.matchRooms(slot)
+		for i, row := range s.cells {
+			g := row[slot]
+			if g < 0 {
+				continue
+			}
+			group := s.groups[g]
+			section := s.req.Sections[i]
+			period := s.periods[slot%periodsPerDay]
+
+			room := section.RoomNumber
+			if len(group.rooms) > 0 {
+				room = s.rooms[roomOf[i]]
+			}
+			slots = append(slots, models.Timetable{
+				ClassID:      section.ClassID,
+				SectionID:    section.SectionID,
+				Day:          s.req.Days[slot/periodsPerDay],
+				PeriodNumber: period.PeriodNumber,
+				SubjectID:    group.subjectID,
+				TeacherID:    s.teachers[group.teacher].TeacherID,
+				StartTime:    period.StartTime,
+				EndTime:      period.EndTime,
+				RoomNumber:   room,
+				AcademicYear: s.req.AcademicYear,
+			})
+		}
+	}
+
+	sort.SliceStable(slots, func(i, j int) bool { return slots[i].SectionID < slots[j].SectionID })
+	return slots
+}
+
+// CommitGeneratedTimetable saves a feasible generation result. Existing slots
+// of the generated sections are replaced when replace is set, otherwise
+// ErrSectionsHaveTimetable is returned. Slots that clash with the remaining
+// timetable, which may have changed since generation, abort the commit with
+// a *TimetableClashError.
+func CommitGeneratedTimetable(db *gorm.DB, result *TimetableGenerationResult, replace bool) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		err := tx.Model(&models.Timetable{}).
+			Where("academic_year = ? AND section_id IN ?", result.AcademicYear, result.SectionIDs).
+			Count(&count).Error
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			if !replace {
+				return ErrSectionsHaveTimetable
+			}
+			err := tx.Where("academic_year = ? AND section_id IN ?", result.AcademicYear, result.SectionIDs).
+				Delete(&models.Timetable{}).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		var existing []models.Timetable
+		if err := tx.Where("academic_year = ?", result.AcademicYear).Find(&existing).Error; err != nil {
+			return err
+		}
+
+		var clashes []TimetableClash
+		for _, slot := range result.Slots {
+			clashes = append(clashes, FindTimetableClashes(slot, existing)...)
+		}
+		if len(clashes) > 0 {
+			return &TimetableClashError{Clashes: clashes}
+		}
+
+		if len(result.Slots) == 0 {
+			return nil
+		}
+		return tx.Omit(clause.Associations).Create(&result.Slots).Error
+	})
+}
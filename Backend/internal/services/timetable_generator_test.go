@@ -0,0 +1,224 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"school-erp-backend/internal/models"
+)
+
+// generatorFixture is a week of two days with three periods each, two
+// sections and a lab that science lessons must use.
+func generatorFixture() *TimetableRequirements {
+	return &TimetableRequirements{
+		AcademicYear: "2024-2025",
+		Days:         []string{"Monday", "Tuesday"},
+		Periods: []TimetablePeriod{
+			{PeriodNumber: 1, StartTime: "09:00", EndTime: "09:45"},
+			{PeriodNumber: 2, StartTime: "09:45", EndTime: "10:30"},
+			{PeriodNumber: 3, StartTime: "10:45", EndTime: "11:30"},
+		},
+		Sections: []SectionRequirement{
+			{ClassID: 1, SectionID: 1, RoomNumber: "R1", Subjects: []SubjectRequirement{
+				{SubjectID: 1, PeriodsPerWeek: 3},
+				{SubjectID: 2, PeriodsPerWeek: 2},
+				{SubjectID: 3, PeriodsPerWeek: 1, TeacherID: 12},
+			}},
+			{ClassID: 1, SectionID: 2, RoomNumber: "R2", Subjects: []SubjectRequirement{
+				{SubjectID: 1, PeriodsPerWeek: 2},
+				{SubjectID: 2, PeriodsPerWeek: 2},
+			}},
+		},
+		Teachers: []TeacherAvailability{
+			{TeacherID: 10, SubjectIDs: []uint{1}, MaxPeriodsPerDay: 2},
+			{TeacherID: 11, SubjectIDs: []uint{2}, Unavailable: []TimetableSlotRef{{Day: "Tuesday", PeriodNumber: 3}}},
+			{TeacherID: 12, SubjectIDs: []uint{1, 3}},
+		},
+		Rooms: []RoomRequirement{{RoomNumber: "Lab", SubjectIDs: []uint{2}}},
+	}
+}
+
+func TestGenerateTimetableFeasible(t *testing.T) {
+	req := generatorFixture()
+	if err := ValidateTimetableRequirements(req); err != nil {
+		t.Fatal(err)
+	}
+	// Another section uses the lab and teacher 11 in the first period on Monday
+	existing := []models.Timetable{
+		{ID: 1, ClassID: 2, SectionID: 9, Day: "Monday", PeriodNumber: 1, SubjectID: 2, TeacherID: 11,
+			StartTime: "09:00", EndTime: "09:45", RoomNumber: "lab", AcademicYear: "2024-2025"},
+	}
+
+	result := GenerateTimetable(req, existing)
+	if !result.Feasible {
+		t.Fatalf("GenerateTimetable found no timetable: %v", result.Problems)
+	}
+	if len(result.SectionIDs) != 2 {
+		t.Errorf("section IDs = %v, want [1 2]", result.SectionIDs)
+	}
+
+	eligible := make(map[uint]map[uint]bool)
+	for _, teacher := range req.Teachers {
+		eligible[teacher.TeacherID] = make(map[uint]bool)
+		for _, subjectID := range teacher.SubjectIDs {
+			eligible[teacher.TeacherID][subjectID] = true
+		}
+	}
+	lessons := make(map[[2]uint]int)
+	perTeacherDay := make(map[uint]map[string]int)
+	for i, slot := range result.Slots {
+		if clashes := FindTimetableClashes(slot, append(existing, result.Slots[:i]...)); len(clashes) > 0 {
+			t.Errorf("slot %+v clashes with %+v", slot, clashes)
+		}
+		if !eligible[slot.TeacherID][slot.SubjectID] {
+			t.Errorf("teacher %d teaches subject %d", slot.TeacherID, slot.SubjectID)
+		}
+		if slot.SubjectID == 2 && slot.RoomNumber != "Lab" {
+			t.Errorf("science lesson in room %q, want Lab", slot.RoomNumber)
+		}
+		if slot.SubjectID != 2 && slot.RoomNumber != map[uint]string{1: "R1", 2: "R2"}[slot.SectionID] {
+			t.Errorf("section %d lesson in room %q, want its home room", slot.SectionID, slot.RoomNumber)
+		}
+		if slot.TeacherID == 11 && slot.Day == "Tuesday" && slot.PeriodNumber == 3 {
+			t.Error("teacher 11 teaches while unavailable")
+		}
+		lessons[[2]uint{slot.SectionID, slot.SubjectID}]++
+		if perTeacherDay[slot.TeacherID] == nil {
+			perTeacherDay[slot.TeacherID] = make(map[string]int)
+		}
+		perTeacherDay[slot.TeacherID][slot.Day]++
+	}
+
+	for _, section := range req.Sections {
+		for _, subject := range section.Subjects {
+			if got := lessons[[2]uint{section.SectionID, subject.SubjectID}]; got != subject.PeriodsPerWeek {
+				t.Errorf("section %d has %d lessons of subject %d, want %d", section.SectionID, got, subject.SubjectID, subject.PeriodsPerWeek)
+			}
+		}
+	}
+	for day, count := range perTeacherDay[10] {
+		if count > 2 {
+			t.Errorf("teacher 10 has %d periods on %s, more than the maximum of 2", count, day)
+		}
+	}
+}
+
+func TestGenerateTimetableInfeasible(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(req *TimetableRequirements)
+		problem string
+	}{
+		{
+			name: "more lessons than periods",
+			modify: func(req *TimetableRequirements) {
+				req.Sections[0].Subjects[0].PeriodsPerWeek = 4
+			},
+			problem: "Section 1 needs 7 periods a week but the week only has 6",
+		},
+		{
+			name: "no teacher for a subject",
+			modify: func(req *TimetableRequirements) {
+				req.Sections[1].Subjects = append(req.Sections[1].Subjects, SubjectRequirement{SubjectID: 4, PeriodsPerWeek: 1})
+			},
+			problem: "Section 2: no teacher can teach subject 4",
+		},
+		{
+			name: "teacher without capacity",
+			modify: func(req *TimetableRequirements) {
+				req.Teachers[1].Unavailable = []TimetableSlotRef{{Day: "Monday", PeriodNumber: 1}, {Day: "Monday", PeriodNumber: 2}, {Day: "Monday", PeriodNumber: 3}}
+			},
+			// Section 1 takes two of the three periods left to teacher 11
+			problem: "Section 2: subject 2 needs 2 periods a week but its eligible teachers (11) have at most 1 free periods left",
+		},
+		{
+			name: "lab too busy",
+			modify: func(req *TimetableRequirements) {
+				req.Teachers = append(req.Teachers, TeacherAvailability{TeacherID: 13, SubjectIDs: []uint{2}})
+				req.Sections[0].Subjects[0].PeriodsPerWeek = 1
+				req.Sections[0].Subjects[1].PeriodsPerWeek = 4
+				req.Sections[1].Subjects[1].PeriodsPerWeek = 3
+			},
+			problem: "Subject 2 needs its dedicated rooms for 7 periods a week but they only have 6 free periods",
+		},
+		{
+			name: "lessons of a subject spread over too few days",
+			modify: func(req *TimetableRequirements) {
+				// Teacher 10 can only teach section 1 on Tuesday, which
+				// cannot hold more than two of its three lessons
+				req.Sections[1].Subjects[0].TeacherID = 12
+				req.Teachers[0].MaxPeriodsPerDay = 0
+				req.Teachers[0].Unavailable = []TimetableSlotRef{{Day: "Monday", PeriodNumber: 1}, {Day: "Monday", PeriodNumber: 2}, {Day: "Monday", PeriodNumber: 3}}
+				req.Teachers[2].SubjectIDs = []uint{3}
+			},
+			problem: "No timetable meeting every requirement was found in 200000 steps",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := generatorFixture()
+			tt.modify(req)
+			if err := ValidateTimetableRequirements(req); err != nil {
+				t.Fatal(err)
+			}
+
+			result := GenerateTimetable(req, nil)
+			if result.Feasible {
+				t.Fatalf("GenerateTimetable found a timetable: %+v", result.Slots)
+			}
+			if len(result.Slots) != 0 {
+				t.Errorf("infeasible result has %d slots, want none", len(result.Slots))
+			}
+			found := false
+			for _, problem := range result.Problems {
+				found = found || strings.Contains(problem, tt.problem)
+			}
+			if !found {
+				t.Errorf("problems = %q, want one containing %q", result.Problems, tt.problem)
+			}
+		})
+	}
+}
+
+func TestValidateTimetableRequirements(t *testing.T) {
+	req := generatorFixture()
+	req.Days = []string{"monday", " TUESDAY "}
+	req.Teachers[1].Unavailable[0].Day = "tuesday"
+	req.Rooms[0].RoomNumber = " Lab "
+	if err := ValidateTimetableRequirements(req); err != nil {
+		t.Fatal(err)
+	}
+	if req.Days[0] != "Monday" || req.Days[1] != "Tuesday" || req.Teachers[1].Unavailable[0].Day != "Tuesday" || req.Rooms[0].RoomNumber != "Lab" {
+		t.Errorf("requirements not normalized: days %v, unavailable %v, room %q", req.Days, req.Teachers[1].Unavailable, req.Rooms[0].RoomNumber)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(req *TimetableRequirements)
+		err    string
+	}{
+		{"unknown day", func(req *TimetableRequirements) { req.Days[1] = "Someday" }, `invalid day "Someday"`},
+		{"day twice", func(req *TimetableRequirements) { req.Days[1] = "monday" }, "day Monday is listed twice"},
+		{"period twice", func(req *TimetableRequirements) { req.Periods[1].PeriodNumber = 1 }, "period 1 is listed twice"},
+		{"period ends before it starts", func(req *TimetableRequirements) { req.Periods[0].EndTime = "08:00" }, "period 1: start time must be before end time"},
+		{"section twice", func(req *TimetableRequirements) { req.Sections[1].SectionID = 1 }, "section 1 is listed twice"},
+		{"section without subjects", func(req *TimetableRequirements) { req.Sections[1].Subjects = nil }, "section 2 has no subjects"},
+		{"subject twice", func(req *TimetableRequirements) { req.Sections[1].Subjects[1].SubjectID = 1 }, "subject 1 is listed twice for section 2"},
+		{"teacher twice", func(req *TimetableRequirements) { req.Teachers[1].TeacherID = 10 }, "teacher 10 is listed twice"},
+		{"unavailable in an unknown period", func(req *TimetableRequirements) { req.Teachers[1].Unavailable[0].PeriodNumber = 9 }, "teacher 11: unknown period 9"},
+		{"room twice", func(req *TimetableRequirements) {
+			req.Rooms = append(req.Rooms, RoomRequirement{RoomNumber: "LAB", SubjectIDs: []uint{1}})
+		}, "room LAB is listed twice"},
+		{"fixed teacher not listed", func(req *TimetableRequirements) { req.Sections[0].Subjects[2].TeacherID = 99 }, "section 1: teacher 99 of subject 3 is not in the teachers list"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := generatorFixture()
+			tt.modify(req)
+			err := ValidateTimetableRequirements(req)
+			if err == nil || err.Error() != tt.err {
+				t.Errorf("error = %v, want %q", err, tt.err)
+			}
+		})
+	}
+}
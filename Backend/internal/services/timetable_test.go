@@ -0,0 +1,115 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+
+	"school-erp-backend/internal/models"
+)
+
+func TestFindTimetableClashes(t *testing.T) {
+	slot := models.Timetable{
+		ID:           0,
+		SectionID:    1,
+		TeacherID:    10,
+		AcademicYear: "2024-2025",
+		Day:          "Monday",
+		PeriodNumber: 2,
+		StartTime:    "09:00",
+		EndTime:      "09:45",
+		RoomNumber:   "R1",
+	}
+	other := func(id, sectionID, teacherID uint, period int, start, end, room string) models.Timetable {
+		return models.Timetable{
+			ID:           id,
+			SectionID:    sectionID,
+			TeacherID:    teacherID,
+			AcademicYear: "2024-2025",
+			Day:          "Monday",
+			PeriodNumber: period,
+			StartTime:    start,
+			EndTime:      end,
+			RoomNumber:   room,
+		}
+	}
+
+	tests := []struct {
+		name     string
+		slot     models.Timetable
+		existing models.Timetable
+		want     []string
+	}{
+		{"same period, same teacher", slot, other(1, 2, 10, 2, "09:00", "09:45", "R2"), []string{"teacher"}},
+		{"same period, room in other case", slot, other(1, 2, 11, 2, "09:00", "09:45", "r1"), []string{"room"}},
+		{"same period, same section", slot, other(1, 1, 11, 2, "09:00", "09:45", "R2"), []string{"section"}},
+		{"overlapping times of another period", slot, other(1, 2, 10, 3, "09:30", "10:15", "R2"), []string{"teacher"}},
+		{"everything shared", slot, other(1, 1, 10, 2, "09:00", "09:45", "R1"), []string{"teacher", "room", "section"}},
+		{"adjacent periods", slot, other(1, 1, 10, 3, "09:45", "10:30", "R1"), nil},
+		{"same period, nothing shared", slot, other(1, 2, 11, 2, "09:00", "09:45", "R2"), nil},
+		{"no room on either slot", withRoom(slot, ""), other(1, 2, 11, 2, "09:00", "09:45", ""), nil},
+		{"same period number, unparsable times", slot, other(1, 2, 10, 2, "9", "10", "R2"), []string{"teacher"}},
+		{"other day", slot, withDay(other(1, 1, 10, 2, "09:00", "09:45", "R1"), "Tuesday"), nil},
+		{"other academic year", slot, withYear(other(1, 1, 10, 2, "09:00", "09:45", "R1"), "2023-2024"), nil},
+		{"the slot being updated", withID(slot, 1), other(1, 1, 10, 2, "09:00", "09:45", "R1"), nil},
+		{"another slot with the ID of a new one", slot, other(0, 1, 10, 2, "09:00", "09:45", "R2"), []string{"teacher", "section"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, clash := range FindTimetableClashes(tt.slot, []models.Timetable{tt.existing}) {
+				if clash.Slot.ID != tt.existing.ID {
+					t.Errorf("clash with slot %d, want %d", clash.Slot.ID, tt.existing.ID)
+				}
+				got = append(got, clash.Type)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("clashes = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateTimetableSlot(t *testing.T) {
+	slot := models.Timetable{Day: " friday ", PeriodNumber: 1, StartTime: "08:00", EndTime: "08:45", RoomNumber: " Lab 2 "}
+	if err := ValidateTimetableSlot(&slot); err != nil {
+		t.Fatal(err)
+	}
+	if slot.Day != "Friday" || slot.RoomNumber != "Lab 2" {
+		t.Errorf("normalized day and room = %q, %q, want Friday, Lab 2", slot.Day, slot.RoomNumber)
+	}
+
+	invalid := []models.Timetable{
+		{Day: "Funday", PeriodNumber: 1, StartTime: "08:00", EndTime: "08:45"},
+		{Day: "Monday", PeriodNumber: 0, StartTime: "08:00", EndTime: "08:45"},
+		{Day: "Monday", PeriodNumber: 1, StartTime: "8am", EndTime: "08:45"},
+		{Day: "Monday", PeriodNumber: 1, StartTime: "08:00", EndTime: "24:00"},
+		{Day: "Monday", PeriodNumber: 1, StartTime: "08:45", EndTime: "08:45"},
+		{Day: "Monday", PeriodNumber: 1, StartTime: "09:00", EndTime: "08:45"},
+	}
+	for _, slot := range invalid {
+		slot := slot
+		if err := ValidateTimetableSlot(&slot); err == nil {
+			t.Errorf("ValidateTimetableSlot accepted %+v", slot)
+		}
+	}
+}
+
+func withID(slot models.Timetable, id uint) models.Timetable {
+	slot.ID = id
+	return slot
+}
+
+func withRoom(slot models.Timetable, room string) models.Timetable {
+	slot.RoomNumber = room
+	return slot
+}
+
+func withDay(slot models.Timetable, day string) models.Timetable {
+	slot.Day = day
+	return slot
+}
+
+func withYear(slot models.Timetable, academicYear string) models.Timetable {
+	slot.AcademicYear = academicYear
+	return slot
+}
@@ -0,0 +1,218 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+	"school-erp-backend/internal/models"
+	"school-erp-backend/internal/repository"
+	"school-erp-backend/pkg/totp"
+	"school-erp-backend/pkg/utils"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidTwoFactorCode    = errors.New("invalid two-factor code")
+	ErrTwoFactorNotSetUp       = errors.New("two-factor authentication has not been set up")
+	ErrTwoFactorAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+)
+
+const (
+	recoveryCodeCount = 10
+
+	// Codes of the time steps next to the current one are accepted, to allow
+	// for the clock of the phone being off by up to 30 seconds
+	totpSkew = 1
+)
+
+// TwoFactorSetup is shown to a user once, as a QR code of the URI or as the
+// secret to type into their authenticator app.
+type TwoFactorSetup struct {
+	Secret string
+	URI    string
+}
+
+// TwoFactorRequired reports whether a user holding roles, their primary role
+// and any given besides it, must use two-factor authentication.
+func TwoFactorRequired(roles []string, requiredRoles []string) bool {
+	for _, role := range roles {
+		for _, required := range requiredRoles {
+			if role == required {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// TwoFactorEnabled reports whether a user has confirmed a TOTP secret
+func TwoFactorEnabled(repo *repository.TwoFactorRepository, userID uint) (bool, error) {
+	auth, err := repo.FindByUser(userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return auth.Enabled, nil
+}
+
+// SetUpTwoFactor generates a new TOTP secret for a user. It stays pending,
+// and replaces any earlier pending one, until EnableTwoFactor confirms it.
+func SetUpTwoFactor(repo *repository.TwoFactorRepository, user *models.User, issuer string) (*TwoFactorSetup, error) {
+	auth, err := repo.FindByUser(user.ID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		auth = &models.TwoFactorAuth{UserID: user.ID}
+	} else if err != nil {
+		return nil, err
+	}
+	if auth.Enabled {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+	auth.Secret = secret
+	auth.LastUsedStep = 0
+	if err := repo.Save(auth); err != nil {
+		return nil, err
+	}
+
+	return &TwoFactorSetup{
+		Secret: secret,
+		URI:    totp.ProvisioningURI(secret, issuer, user.Email),
+	}, nil
+}
+
+// EnableTwoFactor turns on two-factor authentication once the user proves
+// their app has the pending secret. It returns the recovery codes, which are
+// stored hashed and cannot be shown again.
+func EnableTwoFactor(db *gorm.DB, userID uint, code string) ([]string, error) {
+	var codes []string
+	err := db.Transaction(func(tx *gorm.DB) error {
+		repo := repository.NewTwoFactorRepository(tx)
+		auth, err := repo.FindByUser(userID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrTwoFactorNotSetUp
+		}
+		if err != nil {
+			return err
+		}
+		if auth.Enabled {
+			return ErrTwoFactorAlreadyEnabled
+		}
+
+		if err := useTOTPCode(repo, auth, code); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		auth.Enabled = true
+		auth.EnabledAt = &now
+		if err := repo.Save(auth); err != nil {
+			return err
+		}
+
+		codes, err = replaceRecoveryCodes(repo, userID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// VerifyTwoFactor checks the second factor of a user: a TOTP code, which
+// cannot be used twice, or one of their unused recovery codes, which is then
+// used up.
+func VerifyTwoFactor(repo *repository.TwoFactorRepository, userID uint, code string) error {
+	auth, err := repo.FindByUser(userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrTwoFactorNotSetUp
+	}
+	if err != nil {
+		return err
+	}
+	if !auth.Enabled {
+		return ErrTwoFactorNotSetUp
+	}
+
+	code = normalizeTwoFactorCode(code)
+	if len(code) == totp.Digits {
+		return useTOTPCode(repo, auth, code)
+	}
+
+	used, err := repo.UseRecoveryCode(userID, utils.HashToken(code), time.Now())
+	if err != nil {
+		return err
+	}
+	if !used {
+		return ErrInvalidTwoFactorCode
+	}
+	return nil
+}
+
+// DisableTwoFactor removes the TOTP secret and recovery codes of a user
+func DisableTwoFactor(repo *repository.TwoFactorRepository, userID uint) error {
+	return repo.DeleteByUser(userID)
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes of a user, for when
+// they have used or lost them.
+func RegenerateRecoveryCodes(repo *repository.TwoFactorRepository, userID uint) ([]string, error) {
+	enabled, err := TwoFactorEnabled(repo, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !enabled {
+		return nil, ErrTwoFactorNotSetUp
+	}
+	return replaceRecoveryCodes(repo, userID)
+}
+
+func useTOTPCode(repo *repository.TwoFactorRepository, auth *models.TwoFactorAuth, code string) error {
+	step, ok := totp.Validate(auth.Secret, code, time.Now(), totpSkew)
+	if !ok {
+		return ErrInvalidTwoFactorCode
+	}
+	fresh, err := repo.UseStep(auth.ID, step)
+	if err != nil {
+		return err
+	}
+	if !fresh {
+		return ErrInvalidTwoFactorCode
+	}
+	auth.LastUsedStep = step
+	return nil
+}
+
+// replaceRecoveryCodes generates codes such as "3f9a1-c07e2" and stores their
+// hashes in place of the earlier ones.
+func replaceRecoveryCodes(repo *repository.TwoFactorRepository, userID uint) ([]string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		bytes := make([]byte, 5)
+		if _, err := rand.Read(bytes); err != nil {
+			return nil, err
+		}
+		code := hex.EncodeToString(bytes)
+		codes = append(codes, code[:5]+"-"+code[5:])
+		hashes = append(hashes, utils.HashToken(code))
+	}
+
+	if err := repo.ReplaceRecoveryCodes(userID, hashes); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// normalizeTwoFactorCode drops the spaces and dashes users type into codes
+func normalizeTwoFactorCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.NewReplacer(" ", "", "-", "").Replace(code)
+}
@@ -0,0 +1,101 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+	"school-erp-backend/pkg/storage"
+)
+
+var (
+	ErrFileTooLarge       = errors.New("file exceeds the maximum upload size")
+	ErrFileTypeNotAllowed = errors.New("file type is not allowed")
+)
+
+// allowedUploadTypes maps the content types accepted for uploads, as sniffed
+// by http.DetectContentType, to the extension used when the original file
+// name has none that fits.
+var allowedUploadTypes = map[string]string{
+	"application/pdf":           ".pdf",
+	"image/png":                 ".png",
+	"image/jpeg":                ".jpg",
+	"text/plain; charset=utf-8": ".txt",
+	"application/zip":           ".zip",
+}
+
+// Office documents are zip archives and are sniffed as application/zip
+var zipExtensions = map[string]bool{
+	".zip":  true,
+	".docx": true,
+	".xlsx": true,
+	".pptx": true,
+}
+
+// StoredUpload describes a file written by StoreUpload
+type StoredUpload struct {
+	Key         string
+	FileName    string
+	ContentType string
+	Size        int64
+}
+
+// StoreUpload checks the size and sniffed content type of an uploaded file
+// and puts it into files under a generated key starting with keyPrefix.
+func StoreUpload(ctx context.Context, files storage.Storage, header *multipart.FileHeader, keyPrefix string, maxBytes int64) (*StoredUpload, error) {
+	if header.Size > maxBytes {
+		return nil, ErrFileTooLarge
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	size, err := file.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, err
+	}
+	if size > maxBytes {
+		return nil, ErrFileTooLarge
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	sniff := make([]byte, 512)
+	n, err := io.ReadFull(file, sniff)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	contentType := http.DetectContentType(sniff[:n])
+	ext, ok := allowedUploadTypes[contentType]
+	if !ok {
+		return nil, ErrFileTypeNotAllowed
+	}
+	if original := strings.ToLower(filepath.Ext(header.Filename)); contentType == "application/zip" && zipExtensions[original] {
+		ext = original
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	key := fmt.Sprintf("%s-%d%s", keyPrefix, time.Now().UnixNano(), ext)
+	if err := files.Put(ctx, key, file, size, contentType); err != nil {
+		return nil, err
+	}
+
+	return &StoredUpload{
+		Key:         key,
+		FileName:    filepath.Base(header.Filename),
+		ContentType: contentType,
+		Size:        size,
+	}, nil
+}
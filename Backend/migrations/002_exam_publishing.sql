@@ -0,0 +1,9 @@
+-- Exam result publishing
+-- Marks of a published exam are locked until an admin unpublishes it.
+
+ALTER TABLE exams ADD COLUMN IF NOT EXISTS status VARCHAR(20) DEFAULT 'draft';
+ALTER TABLE exams ADD COLUMN IF NOT EXISTS published_at TIMESTAMP NULL;
+ALTER TABLE exams ADD COLUMN IF NOT EXISTS published_by INTEGER REFERENCES users(id);
+
+CREATE INDEX IF NOT EXISTS idx_exams_class_id ON exams(class_id);
+CREATE INDEX IF NOT EXISTS idx_marks_student_exam ON marks(student_id, exam_id);
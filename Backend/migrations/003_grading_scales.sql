@@ -0,0 +1,56 @@
+-- Grading scales and background jobs
+
+-- Grading Scales table
+CREATE TABLE IF NOT EXISTS grading_scales (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) UNIQUE NOT NULL,
+    description TEXT,
+    status VARCHAR(50) DEFAULT 'active',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL
+);
+
+-- Grade Bands table
+CREATE TABLE IF NOT EXISTS grade_bands (
+    id SERIAL PRIMARY KEY,
+    grading_scale_id INTEGER NOT NULL REFERENCES grading_scales(id) ON DELETE CASCADE,
+    min_percentage DECIMAL(5,2) NOT NULL,
+    max_percentage DECIMAL(5,2) NOT NULL,
+    grade VARCHAR(10) NOT NULL,
+    grade_point DECIMAL(4,2),
+    description VARCHAR(100),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Class Grading Scales table
+CREATE TABLE IF NOT EXISTS class_grading_scales (
+    id SERIAL PRIMARY KEY,
+    class_id INTEGER NOT NULL REFERENCES classes(id) ON DELETE CASCADE,
+    academic_year VARCHAR(20) NOT NULL,
+    grading_scale_id INTEGER NOT NULL REFERENCES grading_scales(id),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(class_id, academic_year)
+);
+
+ALTER TABLE marks ADD COLUMN IF NOT EXISTS grade_point DECIMAL(4,2);
+
+-- Jobs table
+CREATE TABLE IF NOT EXISTS jobs (
+    id SERIAL PRIMARY KEY,
+    type VARCHAR(50) NOT NULL,
+    status VARCHAR(20) DEFAULT 'queued',
+    payload TEXT,
+    result TEXT,
+    error TEXT,
+    created_by INTEGER NOT NULL REFERENCES users(id),
+    started_at TIMESTAMP NULL,
+    finished_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_grade_bands_scale_id ON grade_bands(grading_scale_id);
+CREATE INDEX IF NOT EXISTS idx_jobs_type ON jobs(type);
@@ -0,0 +1,13 @@
+-- Report card remarks
+
+CREATE TABLE IF NOT EXISTS report_card_remarks (
+    id SERIAL PRIMARY KEY,
+    student_id INTEGER NOT NULL REFERENCES students(id) ON DELETE CASCADE,
+    academic_year VARCHAR(20) NOT NULL,
+    exam_type VARCHAR(50) NOT NULL,
+    remarks TEXT,
+    created_by INTEGER NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(student_id, academic_year, exam_type)
+);
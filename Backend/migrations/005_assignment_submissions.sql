@@ -0,0 +1,10 @@
+-- Assignment submission uploads and grading
+
+ALTER TABLE assignment_submissions ADD COLUMN IF NOT EXISTS file_name VARCHAR(255);
+ALTER TABLE assignment_submissions ADD COLUMN IF NOT EXISTS content_type VARCHAR(100);
+ALTER TABLE assignment_submissions ADD COLUMN IF NOT EXISTS file_size BIGINT DEFAULT 0;
+ALTER TABLE assignment_submissions ADD COLUMN IF NOT EXISTS is_late BOOLEAN DEFAULT FALSE;
+ALTER TABLE assignment_submissions ADD COLUMN IF NOT EXISTS graded_by INTEGER REFERENCES users(id);
+ALTER TABLE assignment_submissions ADD COLUMN IF NOT EXISTS graded_at TIMESTAMP NULL;
+
+CREATE INDEX IF NOT EXISTS idx_assignment_submissions_assignment_student ON assignment_submissions(assignment_id, student_id);
@@ -0,0 +1,5 @@
+-- Indexes for timetable clash checks and week grids
+
+CREATE INDEX IF NOT EXISTS idx_timetables_teacher_year_day ON timetables(teacher_id, academic_year, day);
+CREATE INDEX IF NOT EXISTS idx_timetables_section_year_day ON timetables(section_id, academic_year, day);
+CREATE INDEX IF NOT EXISTS idx_timetables_room_year_day ON timetables(room_number, academic_year, day);
@@ -0,0 +1,24 @@
+-- Teaching assignments: which teacher teaches which subject to which section
+
+CREATE TABLE IF NOT EXISTS teaching_assignments (
+    id SERIAL PRIMARY KEY,
+    teacher_id INTEGER NOT NULL REFERENCES teachers(id) ON DELETE CASCADE,
+    class_id INTEGER NOT NULL REFERENCES classes(id) ON DELETE CASCADE,
+    section_id INTEGER NOT NULL REFERENCES sections(id) ON DELETE CASCADE,
+    subject_id INTEGER NOT NULL REFERENCES subjects(id) ON DELETE CASCADE,
+    academic_year VARCHAR(20) NOT NULL,
+    is_class_teacher BOOLEAN DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_teaching_assignments_teacher_id ON teaching_assignments(teacher_id);
+CREATE INDEX IF NOT EXISTS idx_teaching_assignments_section_id ON teaching_assignments(section_id);
+CREATE INDEX IF NOT EXISTS idx_teaching_assignments_deleted_at ON teaching_assignments(deleted_at);
+
+-- A subject of a section is taught by one teacher, and a section has one class teacher
+CREATE UNIQUE INDEX IF NOT EXISTS idx_teaching_assignments_subject
+    ON teaching_assignments(section_id, subject_id, academic_year) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_teaching_assignments_class_teacher
+    ON teaching_assignments(section_id, academic_year) WHERE is_class_teacher AND deleted_at IS NULL;
@@ -0,0 +1,19 @@
+-- Curriculum: the subjects of each class per academic year
+
+CREATE TABLE IF NOT EXISTS curriculum_subjects (
+    id SERIAL PRIMARY KEY,
+    class_id INTEGER NOT NULL REFERENCES classes(id) ON DELETE CASCADE,
+    subject_id INTEGER NOT NULL REFERENCES subjects(id) ON DELETE CASCADE,
+    academic_year VARCHAR(20) NOT NULL,
+    is_elective BOOLEAN DEFAULT FALSE,
+    weekly_periods INTEGER,
+    max_marks DECIMAL(10,2),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_curriculum_subjects_class_id ON curriculum_subjects(class_id);
+CREATE INDEX IF NOT EXISTS idx_curriculum_subjects_deleted_at ON curriculum_subjects(deleted_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_curriculum_subjects_class_subject_year
+    ON curriculum_subjects(class_id, subject_id, academic_year) WHERE deleted_at IS NULL;
@@ -0,0 +1,23 @@
+-- Electives: seat limits and the students taking each elective subject
+
+ALTER TABLE curriculum_subjects ADD COLUMN IF NOT EXISTS capacity INTEGER DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS elective_enrollments (
+    id SERIAL PRIMARY KEY,
+    student_id INTEGER NOT NULL REFERENCES students(id) ON DELETE CASCADE,
+    class_id INTEGER NOT NULL REFERENCES classes(id) ON DELETE CASCADE,
+    subject_id INTEGER NOT NULL REFERENCES subjects(id) ON DELETE CASCADE,
+    academic_year VARCHAR(20) NOT NULL,
+    enrolled_by INTEGER NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_elective_enrollments_student_id ON elective_enrollments(student_id);
+CREATE INDEX IF NOT EXISTS idx_elective_enrollments_subject_id ON elective_enrollments(subject_id);
+CREATE INDEX IF NOT EXISTS idx_elective_enrollments_deleted_at ON elective_enrollments(deleted_at);
+
+-- A student enrolls in an elective once per academic year
+CREATE UNIQUE INDEX IF NOT EXISTS idx_elective_enrollments_student_subject_year
+    ON elective_enrollments(student_id, subject_id, academic_year) WHERE deleted_at IS NULL;
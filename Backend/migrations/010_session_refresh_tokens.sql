@@ -0,0 +1,9 @@
+-- Sessions: rotating refresh tokens, stored hashed, grouped into families
+
+ALTER TABLE sessions ADD COLUMN IF NOT EXISTS family_id VARCHAR(64) NOT NULL DEFAULT '';
+ALTER TABLE sessions ADD COLUMN IF NOT EXISTS rotated_at TIMESTAMP NULL;
+ALTER TABLE sessions ADD COLUMN IF NOT EXISTS revoked_at TIMESTAMP NULL;
+
+CREATE INDEX IF NOT EXISTS idx_sessions_user_id ON sessions(user_id);
+CREATE INDEX IF NOT EXISTS idx_sessions_family_id ON sessions(family_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_sessions_token ON sessions(token);
@@ -0,0 +1,6 @@
+-- Sessions: client details shown in the list of signed-in devices
+
+ALTER TABLE sessions ADD COLUMN IF NOT EXISTS user_agent TEXT;
+ALTER TABLE sessions ADD COLUMN IF NOT EXISTS ip_address VARCHAR(45);
+ALTER TABLE sessions ADD COLUMN IF NOT EXISTS started_at TIMESTAMP;
+ALTER TABLE sessions ADD COLUMN IF NOT EXISTS last_seen_at TIMESTAMP;
@@ -0,0 +1,30 @@
+-- Login security: attempt history and lockouts after repeated failures
+
+CREATE TABLE IF NOT EXISTS login_events (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    email VARCHAR(255),
+    ip_address VARCHAR(45),
+    user_agent TEXT,
+    success BOOLEAN NOT NULL DEFAULT FALSE,
+    reason VARCHAR(50),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_login_events_user_id ON login_events(user_id);
+CREATE INDEX IF NOT EXISTS idx_login_events_email ON login_events(email);
+CREATE INDEX IF NOT EXISTS idx_login_events_ip_address ON login_events(ip_address);
+CREATE INDEX IF NOT EXISTS idx_login_events_created_at ON login_events(created_at);
+
+CREATE TABLE IF NOT EXISTS account_lockouts (
+    id SERIAL PRIMARY KEY,
+    scope VARCHAR(10) NOT NULL CHECK (scope IN ('email', 'ip')),
+    identifier VARCHAR(255) NOT NULL,
+    failed_attempts INTEGER DEFAULT 0,
+    last_failed_at TIMESTAMP,
+    locked_until TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_account_lockouts_scope_identifier ON account_lockouts(scope, identifier);
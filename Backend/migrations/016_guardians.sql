@@ -0,0 +1,27 @@
+-- Guardians: parents log in with users of the parent role and are linked to
+-- one or more students. The parent role is created by the server at startup
+-- (services.SeedPermissions).
+
+CREATE TABLE IF NOT EXISTS guardians (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL UNIQUE REFERENCES users(id),
+    name VARCHAR(255) NOT NULL,
+    phone VARCHAR(20),
+    address TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_guardians_phone ON guardians(phone);
+CREATE INDEX IF NOT EXISTS idx_guardians_deleted_at ON guardians(deleted_at);
+
+CREATE TABLE IF NOT EXISTS student_guardians (
+    guardian_id INTEGER NOT NULL REFERENCES guardians(id) ON DELETE CASCADE,
+    student_id INTEGER NOT NULL REFERENCES students(id) ON DELETE CASCADE,
+    relationship VARCHAR(50),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (guardian_id, student_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_student_guardians_student_id ON student_guardians(student_id);
@@ -0,0 +1,39 @@
+-- Notice board: the audience of a notice moves from the JSON string in
+-- notices.target_audience to notice_targets rows, so feeds can be resolved in
+-- SQL. visibility_type becomes 'all' or 'targeted'. Reads are tracked per user.
+
+CREATE TABLE IF NOT EXISTS notice_targets (
+    id SERIAL PRIMARY KEY,
+    notice_id INTEGER NOT NULL REFERENCES notices(id) ON DELETE CASCADE,
+    target_type VARCHAR(20) NOT NULL,
+    role VARCHAR(50),
+    target_id INTEGER
+);
+
+CREATE INDEX IF NOT EXISTS idx_notice_targets_notice_id ON notice_targets(notice_id);
+
+CREATE TABLE IF NOT EXISTS notice_reads (
+    notice_id INTEGER NOT NULL REFERENCES notices(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    read_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (notice_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_notice_reads_user_id ON notice_reads(user_id);
+CREATE INDEX IF NOT EXISTS idx_notices_published_at ON notices(published_at);
+
+-- Carry over the roles and class IDs listed in target_audience
+INSERT INTO notice_targets (notice_id, target_type, role)
+SELECT notices.id, 'role', audience.value
+FROM notices, jsonb_array_elements_text(notices.target_audience::jsonb) AS audience(value)
+WHERE notices.visibility_type = 'role' AND notices.target_audience LIKE '[%';
+
+INSERT INTO notice_targets (notice_id, target_type, target_id)
+SELECT notices.id, 'class', audience.value::integer
+FROM notices, jsonb_array_elements_text(notices.target_audience::jsonb) AS audience(value)
+WHERE notices.visibility_type = 'class' AND notices.target_audience LIKE '[%';
+
+UPDATE notices SET visibility_type = 'targeted' WHERE visibility_type IN ('role', 'class');
+UPDATE notices SET published_at = created_at WHERE published_at IS NULL;
+
+ALTER TABLE notices DROP COLUMN IF EXISTS target_audience;
@@ -0,0 +1,24 @@
+-- School calendar: events may be all-day or timed and may repeat by an RRULE.
+-- occurs_until is the end of the last occurrence so range queries need not
+-- expand the rule; it stays NULL for events repeating forever.
+
+ALTER TABLE calendar_events ADD COLUMN IF NOT EXISTS all_day BOOLEAN DEFAULT TRUE;
+ALTER TABLE calendar_events ADD COLUMN IF NOT EXISTS recurrence VARCHAR(255);
+ALTER TABLE calendar_events ADD COLUMN IF NOT EXISTS occurs_until TIMESTAMP NULL;
+
+UPDATE calendar_events SET end_date = start_date WHERE end_date IS NULL OR end_date < start_date;
+UPDATE calendar_events SET occurs_until = end_date WHERE recurrence IS NULL OR recurrence = '';
+ALTER TABLE calendar_events ALTER COLUMN end_date SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_calendar_events_start_date ON calendar_events(start_date);
+CREATE INDEX IF NOT EXISTS idx_calendar_events_occurs_until ON calendar_events(occurs_until);
+CREATE INDEX IF NOT EXISTS idx_calendar_events_deleted_at ON calendar_events(deleted_at);
+
+-- Secret tokens of the iCalendar feed URLs, stored as SHA-256
+CREATE TABLE IF NOT EXISTS calendar_feed_tokens (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL UNIQUE REFERENCES users(id) ON DELETE CASCADE,
+    token VARCHAR(64) NOT NULL UNIQUE,
+    last_used_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
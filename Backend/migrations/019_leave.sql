@@ -0,0 +1,19 @@
+-- Teacher leave: requests record the academic year and the working days they
+-- take from the balance, and who decided them, when and why. Annual
+-- entitlements are configured per leave type.
+
+ALTER TABLE leave_requests ADD COLUMN IF NOT EXISTS academic_year VARCHAR(20);
+ALTER TABLE leave_requests ADD COLUMN IF NOT EXISTS working_days INTEGER DEFAULT 0;
+ALTER TABLE leave_requests ADD COLUMN IF NOT EXISTS decided_at TIMESTAMP NULL;
+ALTER TABLE leave_requests ADD COLUMN IF NOT EXISTS decision_comment TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_leave_requests_academic_year ON leave_requests(academic_year);
+CREATE INDEX IF NOT EXISTS idx_leave_requests_deleted_at ON leave_requests(deleted_at);
+
+CREATE TABLE IF NOT EXISTS leave_entitlements (
+    id SERIAL PRIMARY KEY,
+    leave_type VARCHAR(50) NOT NULL UNIQUE,
+    days_per_year INTEGER NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,19 @@
+-- Substitutions: the timetable periods of teachers on leave handed to other
+-- teachers, at most one substitute per period and date.
+
+CREATE TABLE IF NOT EXISTS substitutions (
+    id SERIAL PRIMARY KEY,
+    date DATE NOT NULL,
+    timetable_id INTEGER NOT NULL REFERENCES timetables(id) ON DELETE CASCADE,
+    absent_teacher_id INTEGER NOT NULL REFERENCES teachers(id),
+    substitute_teacher_id INTEGER NOT NULL REFERENCES teachers(id),
+    leave_request_id INTEGER REFERENCES leave_requests(id) ON DELETE SET NULL,
+    note TEXT,
+    assigned_by INTEGER REFERENCES users(id),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_substitution_period ON substitutions(date, timetable_id);
+CREATE INDEX IF NOT EXISTS idx_substitutions_absent_teacher_id ON substitutions(absent_teacher_id);
+CREATE INDEX IF NOT EXISTS idx_substitutions_substitute_teacher_id ON substitutions(substitute_teacher_id);
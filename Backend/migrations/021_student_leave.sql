@@ -0,0 +1,33 @@
+-- Student leave: absences applied for by students or their guardians and
+-- decided by the class teacher. Approval marks the absences of the leave
+-- days as excused.
+
+CREATE TABLE IF NOT EXISTS student_leave_requests (
+    id SERIAL PRIMARY KEY,
+    student_id INTEGER NOT NULL REFERENCES students(id),
+    class_id INTEGER NOT NULL REFERENCES classes(id),
+    section_id INTEGER NOT NULL REFERENCES sections(id),
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    academic_year VARCHAR(20),
+    school_days INTEGER DEFAULT 0,
+    reason TEXT NOT NULL,
+    attachment_path VARCHAR(500),
+    attachment_name VARCHAR(255),
+    attachment_type VARCHAR(100),
+    attachment_size BIGINT DEFAULT 0,
+    applied_by INTEGER NOT NULL REFERENCES users(id),
+    status VARCHAR(20) DEFAULT 'pending' CHECK (status IN ('pending', 'approved', 'rejected', 'cancelled')),
+    decided_by INTEGER REFERENCES users(id),
+    decided_at TIMESTAMP NULL,
+    decision_comment TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_student_leave_requests_student_id ON student_leave_requests(student_id);
+CREATE INDEX IF NOT EXISTS idx_student_leave_requests_section ON student_leave_requests(class_id, section_id);
+CREATE INDEX IF NOT EXISTS idx_student_leave_requests_status ON student_leave_requests(status);
+CREATE INDEX IF NOT EXISTS idx_student_leave_requests_academic_year ON student_leave_requests(academic_year);
+CREATE INDEX IF NOT EXISTS idx_student_leave_requests_deleted_at ON student_leave_requests(deleted_at);
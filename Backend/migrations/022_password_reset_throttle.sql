@@ -0,0 +1,7 @@
+-- Password reset throttling counts requests per email address and per IP
+-- address in account_lockouts under scopes of their own
+
+ALTER TABLE account_lockouts ALTER COLUMN scope TYPE VARCHAR(20);
+ALTER TABLE account_lockouts DROP CONSTRAINT IF EXISTS account_lockouts_scope_check;
+ALTER TABLE account_lockouts ADD CONSTRAINT account_lockouts_scope_check
+    CHECK (scope IN ('email', 'ip', 'reset_email', 'reset_ip'));
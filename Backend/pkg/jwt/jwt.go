@@ -8,20 +8,59 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 )
 
+const (
+	// TokenTypeAccess marks tokens that authenticate API requests
+	TokenTypeAccess = "access"
+
+	// TokenTypeTwoFactor marks the challenge handed out by a login with a
+	// correct password, to be exchanged for a session with a TOTP code
+	TokenTypeTwoFactor = "2fa"
+
+	// TokenTypeTwoFactorEnroll marks the challenge of a user who has to set up
+	// two-factor authentication before they can log in
+	TokenTypeTwoFactorEnroll = "2fa_enroll"
+)
+
 type Claims struct {
-	UserID uint   `json:"user_id"`
-	Email  string `json:"email"`
-	Role   string `json:"role"`
+	UserID    uint   `json:"user_id"`
+	Email     string `json:"email"`
+	Role      string `json:"role"`
+	SessionID uint   `json:"sid"`
+	Type      string `json:"typ"`
 	jwt.RegisteredClaims
 }
 
-func GenerateToken(userID uint, email, role string) (string, error) {
+// GenerateToken issues a short-lived access token bound to a session, so it
+// stops working once the session is revoked.
+func GenerateToken(userID uint, email, role string, sessionID uint) (string, error) {
+	claims := &Claims{
+		UserID:    userID,
+		Email:     email,
+		Role:      role,
+		SessionID: sessionID,
+		Type:      TokenTypeAccess,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute * time.Duration(config.AppConfig.AccessTokenExpiry))),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			NotBefore: jwt.NewNumericDate(time.Now()),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(config.AppConfig.JWTSecret))
+}
+
+// GenerateChallengeToken issues a short-lived token that proves the password
+// of a user was checked. It is not bound to a session and is not accepted as
+// an access token.
+func GenerateChallengeToken(userID uint, email, role, tokenType string, expiry time.Duration) (string, error) {
 	claims := &Claims{
 		UserID: userID,
 		Email:  email,
 		Role:   role,
+		Type:   tokenType,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour * time.Duration(config.AppConfig.JWTExpiry))),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(expiry)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			NotBefore: jwt.NewNumericDate(time.Now()),
 		},
@@ -0,0 +1,22 @@
+package mailer
+
+import (
+	"context"
+	"log"
+	"strings"
+)
+
+// LogSender writes email to the server log instead of sending it. It is
+// meant for development, where reset links can be copied from the log.
+type LogSender struct {
+	from string
+}
+
+func NewLogSender(from string) *LogSender {
+	return &LogSender{from: from}
+}
+
+func (s *LogSender) Send(ctx context.Context, msg Message) error {
+	log.Printf("Mail from %s to %s: %s\n%s", s.from, strings.Join(msg.To, ", "), msg.Subject, msg.Body)
+	return nil
+}
@@ -0,0 +1,55 @@
+package mailer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"school-erp-backend/config"
+)
+
+// Message is a plain text email
+type Message struct {
+	To      []string
+	Subject string
+	Body    string
+}
+
+// Sender delivers email
+type Sender interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+var Mail Sender
+
+// Init selects the mail backend configured in config.AppConfig.
+func Init() error {
+	cfg := config.AppConfig
+
+	switch cfg.MailBackend {
+	case "log":
+		// Messages carry password reset links, which must not end up in
+		// production logs
+		if cfg.Environment != "development" {
+			return errors.New("the log mail backend is only allowed in development, set MAIL_BACKEND=smtp")
+		}
+		Mail = NewLogSender(cfg.MailFrom)
+	case "smtp":
+		if cfg.SMTPHost == "" {
+			return errors.New("SMTP mail requires SMTP_HOST")
+		}
+		smtp, err := NewSMTPSender(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.MailFrom)
+		if err != nil {
+			return err
+		}
+		Mail = smtp
+	case "":
+		return errors.New("MAIL_BACKEND must be set outside development")
+	default:
+		return fmt.Errorf("unsupported mail backend: %s", cfg.MailBackend)
+	}
+
+	log.Printf("Mail sender initialized (%s)", cfg.MailBackend)
+	return nil
+}
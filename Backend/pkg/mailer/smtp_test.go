@@ -0,0 +1,182 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"io"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeSMTP is an SMTP server for a single connection that records the
+// commands and message it receives. It offers AUTH PLAIN but not STARTTLS.
+type fakeSMTP struct {
+	listener  net.Listener
+	rcptReply string
+	done      chan struct{}
+	commands  []string
+	data      []byte
+}
+
+func startFakeSMTP(t *testing.T, rcptReply string) *fakeSMTP {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &fakeSMTP{listener: listener, rcptReply: rcptReply, done: make(chan struct{})}
+	t.Cleanup(func() { listener.Close() })
+	go s.serve()
+	return s
+}
+
+func (s *fakeSMTP) port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (s *fakeSMTP) serve() {
+	defer close(s.done)
+	conn, err := s.listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(10 * time.Second))
+
+	tp := textproto.NewConn(conn)
+	tp.PrintfLine("220 localhost ESMTP")
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+		s.commands = append(s.commands, line)
+		verb, _, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(verb) {
+		case "EHLO":
+			tp.PrintfLine("250-localhost")
+			tp.PrintfLine("250 AUTH PLAIN")
+		case "AUTH":
+			tp.PrintfLine("235 2.7.0 Authentication successful")
+		case "MAIL":
+			tp.PrintfLine("250 2.1.0 OK")
+		case "RCPT":
+			tp.PrintfLine("%s", s.rcptReply)
+		case "DATA":
+			tp.PrintfLine("354 End data with <CR><LF>.<CR><LF>")
+			if s.data, err = tp.ReadDotBytes(); err != nil {
+				return
+			}
+			tp.PrintfLine("250 2.0.0 Queued")
+		case "QUIT":
+			tp.PrintfLine("221 2.0.0 Bye")
+			return
+		default:
+			tp.PrintfLine("502 5.5.2 Command not recognized")
+		}
+	}
+}
+
+// wait returns once the client has closed the connection
+func (s *fakeSMTP) wait(t *testing.T) {
+	t.Helper()
+	select {
+	case <-s.done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("SMTP session did not end")
+	}
+}
+
+func TestSMTPSenderSend(t *testing.T) {
+	server := startFakeSMTP(t, "250 2.1.5 OK")
+	sender, err := NewSMTPSender("127.0.0.1", server.port(), "school", "secret", "School ERP <noreply@school.test>")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = sender.Send(context.Background(), Message{
+		To:      []string{"parent@example.com", "student@example.com"},
+		Subject: "Réinitialiser le mot de passe",
+		Body:    "Use the link below.\n.hidden\r\nThanks",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	server.wait(t)
+
+	// Credentials are sent since the server is on localhost
+	auth := "AUTH PLAIN " + base64.StdEncoding.EncodeToString([]byte("\x00school\x00secret"))
+	want := []string{
+		"EHLO localhost",
+		auth,
+		"MAIL FROM:<noreply@school.test>",
+		"RCPT TO:<parent@example.com>",
+		"RCPT TO:<student@example.com>",
+		"DATA",
+		"QUIT",
+	}
+	if !reflect.DeepEqual(server.commands, want) {
+		t.Errorf("commands =\n%q\nwant\n%q", server.commands, want)
+	}
+
+	msg, err := mail.ReadMessage(bytes.NewReader(server.data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	headers := map[string]string{
+		"From":         `"School ERP" <noreply@school.test>`,
+		"To":           "parent@example.com, student@example.com",
+		"Subject":      "=?utf-8?q?R=C3=A9initialiser_le_mot_de_passe?=",
+		"Mime-Version": "1.0",
+		"Content-Type": "text/plain; charset=utf-8",
+	}
+	for name, value := range headers {
+		if got := msg.Header.Get(name); got != value {
+			t.Errorf("header %s = %q, want %q", name, got, value)
+		}
+	}
+	if _, err := msg.Header.Date(); err != nil {
+		t.Errorf("Date header: %v", err)
+	}
+
+	// ReadDotBytes undoes the dot stuffing and turns CRLF into LF
+	body, _ := io.ReadAll(msg.Body)
+	if want := "Use the link below.\n.hidden\nThanks\n"; string(body) != want {
+		t.Errorf("body = %q, want %q", body, want)
+	}
+}
+
+func TestSMTPSenderRecipientRejected(t *testing.T) {
+	server := startFakeSMTP(t, "550 5.1.1 Mailbox unavailable")
+	sender, err := NewSMTPSender("127.0.0.1", server.port(), "", "", "noreply@school.test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = sender.Send(context.Background(), Message{To: []string{"nobody@example.com"}, Subject: "Hello", Body: "Hello"})
+	var reply *textproto.Error
+	if !errors.As(err, &reply) || reply.Code != 550 {
+		t.Fatalf("error = %v, want the 550 reply", err)
+	}
+	server.wait(t)
+
+	// No credentials without a username, and no message after the rejection
+	for _, command := range server.commands {
+		if strings.HasPrefix(command, "AUTH") || command == "DATA" {
+			t.Errorf("sender sent %q", command)
+		}
+	}
+}
+
+func TestNewSMTPSenderInvalidFrom(t *testing.T) {
+	if _, err := NewSMTPSender("localhost", 25, "", "", "not an address"); err == nil {
+		t.Error("NewSMTPSender accepted an invalid sender address")
+	}
+}
@@ -0,0 +1,159 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestTextWidth(t *testing.T) {
+	tests := []struct {
+		font Font
+		size float64
+		text string
+		want float64
+	}{
+		{Helvetica, 10, "", 0},
+		{Helvetica, 10, "Hello", 22.78},     // 722 + 556 + 222 + 222 + 556
+		{HelveticaBold, 10, "Hello", 24.45}, // 722 + 556 + 278 + 278 + 611
+		{Helvetica, 12, "A1 ", 18.012},      // (667 + 556 + 278) * 12 / 1000
+		{Helvetica, 10, "é", 5.56},          // outside 32-126, counted at 556
+		{Helvetica, 10, "€", 5.56},          // replaced by '?', also 556
+	}
+	for _, tt := range tests {
+		page := New().AddPage()
+		page.SetFont(tt.font, tt.size)
+		if got := page.TextWidth(tt.text); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("TextWidth(%s %v, %q) = %v, want %v", tt.font, tt.size, tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestEscape(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"Plain", "Plain"},
+		{`a (b) \c`, `a \(b\) \\c`},
+		{"two\nlines\r", "two lines "},
+		{"Café", "Caf\xe9"},
+		{"₹ 100", "? 100"},
+	}
+	for _, tt := range tests {
+		if got := escape(tt.text); got != tt.want {
+			t.Errorf("escape(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestDrawingOperators(t *testing.T) {
+	page := New().AddPage()
+	page.SetFont(HelveticaBold, 12)
+	page.Text(50, 100, "Name (full)")
+	page.TextRight(300, 100, "Hello")
+	page.SetFont(Helvetica, 10)
+	page.TextCenter(100, 120, "Hello")
+	page.SetLineWidth(0.5)
+	page.SetFillGray(0.9)
+	page.Line(10, 20, 30, 40)
+	page.Rect(10, 20, 100, 50, true)
+	page.Rect(10, 20, 100, 50, false)
+
+	want := strings.Join([]string{
+		"BT /F2 12.00 Tf 50.00 741.89 Td (Name \\(full\\)) Tj ET",
+		"BT /F2 12.00 Tf 270.66 741.89 Td (Hello) Tj ET", // 300 - 24.45 * 1.2
+		"BT /F1 10.00 Tf 88.61 721.89 Td (Hello) Tj ET",  // 100 - 22.78 / 2
+		"0.50 w",
+		"0.90 g",
+		"10.00 821.89 m 30.00 801.89 l S",
+		"10.00 771.89 100.00 50.00 re f",
+		"10.00 771.89 100.00 50.00 re S",
+	}, "\n") + "\n"
+	if got := page.content.String(); got != want {
+		t.Errorf("content =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestWriteToStructure(t *testing.T) {
+	doc := New()
+	doc.AddPage().Text(50, 50, "Page one")
+	second := doc.AddPage()
+	second.Text(50, 50, "Page two")
+	second.Line(0, 0, 10, 10)
+
+	var buf bytes.Buffer
+	n, err := doc.WriteTo(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+	if n != int64(len(data)) {
+		t.Errorf("WriteTo returned %d, wrote %d bytes", n, len(data))
+	}
+	if !bytes.Equal(doc.Bytes(), data) {
+		t.Error("Bytes differs from WriteTo")
+	}
+	if !bytes.HasPrefix(data, []byte("%PDF-1.4\n")) || !bytes.HasSuffix(data, []byte("%%EOF\n")) {
+		t.Fatalf("document does not start with the header and end with %%%%EOF")
+	}
+
+	// startxref points at the cross-reference table
+	match := regexp.MustCompile(`startxref\n(\d+)\n%%EOF\n$`).FindSubmatch(data)
+	if match == nil {
+		t.Fatal("no startxref")
+	}
+	xref, _ := strconv.Atoi(string(match[1]))
+	if !bytes.HasPrefix(data[xref:], []byte("xref\n0 9\n0000000000 65535 f \n")) {
+		t.Fatalf("startxref %d does not point at an xref table of 9 entries", xref)
+	}
+
+	// Every entry points at its object: catalog, pages, two fonts and two
+	// objects per page
+	entries := regexp.MustCompile(`(\d{10}) 00000 n \n`).FindAllSubmatch(data[xref:], -1)
+	if len(entries) != 8 {
+		t.Fatalf("xref has %d objects, want 8", len(entries))
+	}
+	for i, entry := range entries {
+		offset, _ := strconv.Atoi(string(entry[1]))
+		if header := fmt.Sprintf("%d 0 obj\n", i+1); !bytes.HasPrefix(data[offset:], []byte(header)) {
+			t.Errorf("xref entry %d points at %q, want %q", i+1, data[offset:offset+len(header)], header)
+		}
+	}
+
+	if !bytes.Contains(data, []byte("<< /Type /Pages /Kids [5 0 R 7 0 R] /Count 2 >>")) {
+		t.Error("page tree does not list both pages")
+	}
+	if !bytes.Contains(data, []byte("/Contents 6 0 R")) || !bytes.Contains(data, []byte("/Contents 8 0 R")) {
+		t.Error("pages do not refer to their content streams")
+	}
+	if !bytes.Contains(data, []byte("/Size 9 /Root 1 0 R")) {
+		t.Error("trailer does not give the size and root")
+	}
+
+	// Stream lengths match the content between stream and endstream
+	streams := regexp.MustCompile(`<< /Length (\d+) >>\nstream\n`).FindAllSubmatchIndex(data, -1)
+	if len(streams) != 2 {
+		t.Fatalf("document has %d content streams, want 2", len(streams))
+	}
+	for _, stream := range streams {
+		length, _ := strconv.Atoi(string(data[stream[2]:stream[3]]))
+		if !bytes.HasPrefix(data[stream[1]+length:], []byte("endstream\n")) {
+			t.Errorf("stream at %d is not %d bytes long", stream[1], length)
+		}
+	}
+}
+
+func TestEmptyDocument(t *testing.T) {
+	data := New().Bytes()
+	if !bytes.Contains(data, []byte("<< /Type /Pages /Kids [] /Count 0 >>")) {
+		t.Error("empty document does not have an empty page tree")
+	}
+	if !bytes.Contains(data, []byte("xref\n0 5\n")) {
+		t.Error("empty document does not have the four fixed objects")
+	}
+}
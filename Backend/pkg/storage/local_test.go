@@ -0,0 +1,131 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCleanKey(t *testing.T) {
+	valid := []string{"a.pdf", "assignments/12/34-1700000000.pdf", "a..b/c"}
+	for _, key := range valid {
+		if cleaned, err := cleanKey(key); err != nil || cleaned != key {
+			t.Errorf("cleanKey(%q) = %q, %v, want the key unchanged", key, cleaned, err)
+		}
+	}
+
+	invalid := []string{"", "/", "..", "../a", "a/../../b", "a/../b", "/a", "a/", "a//b", "./a", "a/./b"}
+	for _, key := range invalid {
+		if _, err := cleanKey(key); err == nil {
+			t.Errorf("cleanKey(%q) accepted the key", key)
+		}
+	}
+}
+
+func TestLocalStoragePaths(t *testing.T) {
+	root := t.TempDir()
+	s := NewLocalStorage(filepath.Join(root, "uploads"), "http://localhost/api/files/signed", "secret")
+	ctx := context.Background()
+
+	if err := s.Put(ctx, "../outside.txt", strings.NewReader("x"), 1, ""); err == nil {
+		t.Error("Put accepted a key outside the root")
+	}
+	if _, err := os.Stat(filepath.Join(root, "outside.txt")); !errors.Is(err, os.ErrNotExist) {
+		t.Error("Put wrote a file outside the root")
+	}
+	if _, err := s.Get(ctx, "../../etc/passwd"); err == nil || errors.Is(err, ErrNotFound) {
+		t.Errorf("Get of a key outside the root error = %v, want an invalid key", err)
+	}
+
+	content := []byte("report")
+	if err := s.Put(ctx, "a/b.txt", bytes.NewReader(content), int64(len(content)), "text/plain"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	object, err := s.Get(ctx, "a/b.txt")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	body, _ := io.ReadAll(object.Body)
+	object.Body.Close()
+	if !bytes.Equal(body, content) || object.Size != int64(len(content)) {
+		t.Errorf("Get = %q (%d bytes), want %q", body, object.Size, content)
+	}
+
+	if err := s.Delete(ctx, "a/b.txt"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := s.Get(ctx, "a/b.txt"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get after Delete error = %v, want ErrNotFound", err)
+	}
+	if err := s.Delete(ctx, "a/b.txt"); err != nil {
+		t.Errorf("Delete of a missing file: %v, want nil", err)
+	}
+}
+
+// signedParams returns the key, expires and signature parameters of a URL
+// returned by SignedURL.
+func signedParams(t *testing.T, s *LocalStorage, key string, expiry time.Duration) (string, string, string) {
+	t.Helper()
+	signed, err := s.SignedURL(context.Background(), key, expiry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(signed, "http://localhost/api/files/signed?") {
+		t.Fatalf("SignedURL = %q, want the signed endpoint", signed)
+	}
+	parsed, err := url.Parse(signed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	query := parsed.Query()
+	return query.Get("key"), query.Get("expires"), query.Get("signature")
+}
+
+func TestLocalStorageSignedURL(t *testing.T) {
+	s := NewLocalStorage(t.TempDir(), "http://localhost/api/files/signed", "secret")
+
+	key, expires, signature := signedParams(t, s, "a/b.pdf", 15*time.Minute)
+	if key != "a/b.pdf" {
+		t.Errorf("signed key = %q, want a/b.pdf", key)
+	}
+	if err := s.VerifySignature(key, expires, signature); err != nil {
+		t.Errorf("VerifySignature of a fresh URL: %v", err)
+	}
+
+	tests := []struct {
+		name                    string
+		key, expires, signature string
+	}{
+		{"other key", "a/c.pdf", expires, signature},
+		{"later expiry", key, expires + "0", signature},
+		{"tampered signature", key, expires, strings.Repeat("0", len(signature))},
+		{"missing signature", key, expires, ""},
+		{"invalid expiry", key, "soon", signature},
+	}
+	for _, tt := range tests {
+		if err := s.VerifySignature(tt.key, tt.expires, tt.signature); err == nil {
+			t.Errorf("VerifySignature accepted a URL with %s", tt.name)
+		}
+	}
+
+	other := NewLocalStorage(t.TempDir(), "http://localhost/api/files/signed", "other secret")
+	if err := other.VerifySignature(key, expires, signature); err == nil {
+		t.Error("VerifySignature accepted a URL signed with another secret")
+	}
+
+	key, expires, signature = signedParams(t, s, "a/b.pdf", -time.Minute)
+	if err := s.VerifySignature(key, expires, signature); err == nil || !strings.Contains(err.Error(), "expired") {
+		t.Errorf("VerifySignature of an expired URL error = %v, want expired", err)
+	}
+
+	if _, err := s.SignedURL(context.Background(), "../a.pdf", time.Minute); err == nil {
+		t.Error("SignedURL accepted a key outside the root")
+	}
+}
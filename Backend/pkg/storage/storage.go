@@ -0,0 +1,69 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"path"
+	"strings"
+	"time"
+
+	"school-erp-backend/config"
+)
+
+var ErrNotFound = errors.New("file not found")
+
+// Storage stores files under slash separated keys such as
+// "assignments/12/34-1700000000.pdf".
+type Storage interface {
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	// Get opens a file for reading. The caller closes Object.Body.
+	Get(ctx context.Context, key string) (*Object, error)
+	Delete(ctx context.Context, key string) error
+	// SignedURL returns a URL that downloads the file without further
+	// authentication until it expires.
+	SignedURL(ctx context.Context, key string, expiry time.Duration) (string, error)
+}
+
+type Object struct {
+	Body        io.ReadCloser
+	Size        int64
+	ContentType string
+}
+
+var Files Storage
+
+// Init selects the storage backend configured in config.AppConfig.
+func Init() error {
+	cfg := config.AppConfig
+
+	switch cfg.StorageBackend {
+	case "local":
+		Files = NewLocalStorage(cfg.UploadDir, strings.TrimRight(cfg.PublicURL, "/")+"/api/files/signed", cfg.StorageURLSecret)
+	case "s3":
+		if cfg.S3Bucket == "" || cfg.S3AccessKey == "" || cfg.S3SecretKey == "" {
+			return errors.New("S3 storage requires S3_BUCKET, S3_ACCESS_KEY and S3_SECRET_KEY")
+		}
+		s3, err := NewS3Storage(cfg.S3Endpoint, cfg.S3Region, cfg.S3Bucket, cfg.S3AccessKey, cfg.S3SecretKey, cfg.S3PathStyle)
+		if err != nil {
+			return err
+		}
+		Files = s3
+	default:
+		return fmt.Errorf("unsupported storage backend: %s", cfg.StorageBackend)
+	}
+
+	log.Printf("File storage initialized (%s)", cfg.StorageBackend)
+	return nil
+}
+
+// cleanKey rejects keys that are empty or would escape the storage root.
+func cleanKey(key string) (string, error) {
+	cleaned := path.Clean("/" + key)[1:]
+	if cleaned == "" || cleaned != key {
+		return "", fmt.Errorf("invalid storage key %q", key)
+	}
+	return cleaned, nil
+}
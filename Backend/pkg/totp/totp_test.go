@@ -0,0 +1,123 @@
+package totp
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// rfcSecret is the SHA-1 key of the RFC 6238 test vectors, the ASCII string
+// "12345678901234567890", base32 encoded.
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+// The test vectors of RFC 6238, Appendix B, for SHA-1. The RFC lists eight
+// digit codes; six digit codes are their last six digits.
+var rfcVectors = []struct {
+	unix int64
+	code string
+}{
+	{59, "287082"},
+	{1111111109, "081804"},
+	{1111111111, "050471"},
+	{1234567890, "005924"},
+	{2000000000, "279037"},
+	{20000000000, "353130"},
+}
+
+func TestCodeRFC6238(t *testing.T) {
+	for _, tt := range rfcVectors {
+		code, err := Code(rfcSecret, Step(time.Unix(tt.unix, 0)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if code != tt.code {
+			t.Errorf("Code at %d = %s, want %s", tt.unix, code, tt.code)
+		}
+	}
+}
+
+func TestCodeSecretFormats(t *testing.T) {
+	for _, secret := range []string{strings.ToLower(rfcSecret), rfcSecret + "===="} {
+		code, err := Code(secret, Step(time.Unix(59, 0)))
+		if err != nil || code != "287082" {
+			t.Errorf("Code with secret %q = %s, %v, want 287082", secret, code, err)
+		}
+	}
+
+	if _, err := Code("not base32!", 1); err == nil {
+		t.Error("Code accepted an invalid secret")
+	}
+}
+
+func TestValidateWindow(t *testing.T) {
+	// 1111111109 is the last second of step 37037036
+	issued := time.Unix(1111111109, 0)
+	step := Step(issued)
+
+	tests := []struct {
+		name  string
+		at    time.Time
+		valid bool
+	}{
+		{"same step", issued, true},
+		{"first second of the next step", issued.Add(time.Second), true},
+		{"last second of the next step", issued.Add(Period * time.Second), true},
+		{"two steps later", issued.Add((Period + 1) * time.Second), false},
+		{"first second of its step", issued.Add(-(Period - 1) * time.Second), true},
+		{"previous step", issued.Add(-Period * time.Second), true},
+		{"first second of the previous step", issued.Add(-(2*Period - 1) * time.Second), true},
+		{"two steps earlier", issued.Add(-2 * Period * time.Second), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matched, ok := Validate(rfcSecret, "081804", tt.at, 1)
+			if ok != tt.valid {
+				t.Fatalf("Validate = %v, want %v", ok, tt.valid)
+			}
+			if ok && matched != step {
+				t.Errorf("Validate matched step %d, want %d", matched, step)
+			}
+		})
+	}
+}
+
+func TestValidateInput(t *testing.T) {
+	at := time.Unix(59, 0)
+
+	if _, ok := Validate(rfcSecret, " 287 082 ", at, 0); !ok {
+		t.Error("Validate rejected a code with spaces")
+	}
+	if _, ok := Validate(rfcSecret, "287082", at.Add(Period*time.Second), 0); ok {
+		t.Error("Validate without skew accepted the code of the previous step")
+	}
+	for _, code := range []string{"", "28708", "2870820", "94287082", "287083"} {
+		if _, ok := Validate(rfcSecret, code, at, 1); ok {
+			t.Errorf("Validate accepted %q", code)
+		}
+	}
+	if _, ok := Validate("not base32!", "287082", at, 1); ok {
+		t.Error("Validate accepted a code for an invalid secret")
+	}
+}
+
+func TestGenerateSecret(t *testing.T) {
+	secret, err := GenerateSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := encoding.DecodeString(secret)
+	if err != nil || len(key) != secretSize {
+		t.Errorf("GenerateSecret = %q, want %d base32 encoded bytes", secret, secretSize)
+	}
+	if _, err := Code(secret, 1); err != nil {
+		t.Errorf("Code with a generated secret: %v", err)
+	}
+}
+
+func TestProvisioningURI(t *testing.T) {
+	uri := ProvisioningURI("JBSWY3DPEHPK3PXP", "School ERP", "admin@school.test")
+	want := "otpauth://totp/School%20ERP:admin@school.test?algorithm=SHA1&digits=6&issuer=School+ERP&period=30&secret=JBSWY3DPEHPK3PXP"
+	if uri != want {
+		t.Errorf("ProvisioningURI = %q, want %q", uri, want)
+	}
+}